@@ -0,0 +1,150 @@
+package winlsa
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LUIDs of the logon sessions Windows creates at startup.
+var (
+	LUIDSystem         = LUID{LowPart: 0x3e7}
+	LUIDAnonymous      = LUID{LowPart: 0x3e6}
+	LUIDLocalService   = LUID{LowPart: 0x3e5}
+	LUIDNetworkService = LUID{LowPart: 0x3e4}
+	LUIDIUSR           = LUID{LowPart: 0x3e3}
+)
+
+// AccountKind classifies the account of a logon session.
+type AccountKind uint32
+
+const (
+	// AccountKindUser is any account not classified otherwise, usually a
+	// local or domain user or a managed service account.
+	AccountKindUser AccountKind = iota
+	AccountKindSystem
+	AccountKindLocalService
+	AccountKindNetworkService
+	// AccountKindVirtualService is a per-service virtual account,
+	// NT SERVICE\<service>.
+	AccountKindVirtualService
+	// AccountKindAppPool is an IIS application pool identity,
+	// IIS APPPOOL\<pool>.
+	AccountKindAppPool
+	AccountKindAnonymous
+	// AccountKindIUSR is the built-in IIS anonymous user.
+	AccountKindIUSR
+	// AccountKindWindowManager is a Desktop Window Manager account,
+	// Window Manager\DWM-<n>.
+	AccountKindWindowManager
+	// AccountKindFontDriverHost is a user-mode font driver account,
+	// Font Driver Host\UMFD-<n>.
+	AccountKindFontDriverHost
+)
+
+func (k AccountKind) String() string {
+	switch k {
+	case AccountKindUser:
+		return "User"
+	case AccountKindSystem:
+		return "System"
+	case AccountKindLocalService:
+		return "LocalService"
+	case AccountKindNetworkService:
+		return "NetworkService"
+	case AccountKindVirtualService:
+		return "VirtualService"
+	case AccountKindAppPool:
+		return "AppPool"
+	case AccountKindAnonymous:
+		return "Anonymous"
+	case AccountKindIUSR:
+		return "IUSR"
+	case AccountKindWindowManager:
+		return "WindowManager"
+	case AccountKindFontDriverHost:
+		return "FontDriverHost"
+	default:
+		return fmt.Sprintf("Undefined AccountKind(%d)", k)
+	}
+}
+
+func (k AccountKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// wellKnownSids maps SIDs, or SID prefixes ending in "-", to kinds.
+var wellKnownSids = []struct {
+	sid  string
+	kind AccountKind
+}{
+	{"S-1-5-18", AccountKindSystem},
+	{"S-1-5-19", AccountKindLocalService},
+	{"S-1-5-20", AccountKindNetworkService},
+	{"S-1-5-7", AccountKindAnonymous},
+	{"S-1-5-17", AccountKindIUSR},
+	{"S-1-5-80-", AccountKindVirtualService},
+	{"S-1-5-82-", AccountKindAppPool},
+	{"S-1-5-90-", AccountKindWindowManager},
+	{"S-1-5-96-", AccountKindFontDriverHost},
+}
+
+// wellKnownDomains maps the domain names of virtual accounts to kinds, for
+// sessions without a SID.
+var wellKnownDomains = map[string]AccountKind{
+	"nt service":       AccountKindVirtualService,
+	"iis apppool":      AccountKindAppPool,
+	"window manager":   AccountKindWindowManager,
+	"font driver host": AccountKindFontDriverHost,
+}
+
+// AccountKind classifies the session's account by its SID, falling back
+// to the well-known session LUIDs and virtual account domain names when
+// the LSA reports no SID.
+func (sd *LogonSessionData) AccountKind() AccountKind {
+	if sd.Sid != nil {
+		sid := sd.Sid.String()
+		for _, w := range wellKnownSids {
+			if sid == w.sid || strings.HasSuffix(w.sid, "-") && strings.HasPrefix(sid, w.sid) {
+				return w.kind
+			}
+		}
+		return AccountKindUser
+	}
+	switch sd.LogonId {
+	case LUIDSystem:
+		return AccountKindSystem
+	case LUIDAnonymous:
+		return AccountKindAnonymous
+	case LUIDLocalService:
+		return AccountKindLocalService
+	case LUIDNetworkService:
+		return AccountKindNetworkService
+	case LUIDIUSR:
+		return AccountKindIUSR
+	}
+	if kind, ok := wellKnownDomains[strings.ToLower(sd.LogonDomain)]; ok {
+		return kind
+	}
+	return AccountKindUser
+}
+
+// IsWellKnown reports whether the session belongs to a built-in or virtual
+// account rather than a user.
+func (sd *LogonSessionData) IsWellKnown() bool {
+	return sd.AccountKind() != AccountKindUser
+}
+
+// IsAnonymous reports whether the session belongs to ANONYMOUS LOGON
+// (S-1-5-7).
+func (sd *LogonSessionData) IsAnonymous() bool {
+	return sd.AccountKind() == AccountKindAnonymous
+}
+
+// IsNullSession reports whether the session is a null session: a network
+// logon of ANONYMOUS LOGON by a client that gave no user name and password,
+// e.g. to enumerate shares or accounts over SMB. The session Windows
+// creates for ANONYMOUS LOGON at startup, LUIDAnonymous, is not one.
+func (sd *LogonSessionData) IsNullSession() bool {
+	return sd.IsAnonymous() && sd.LogonId != LUIDAnonymous &&
+		(sd.LogonType == LogonTypeNetwork || sd.LogonType == LogonTypeNetworkCleartext)
+}
@@ -0,0 +1,85 @@
+package winlsa
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrSystemSession is returned by TerminateSessionProcesses for the logon
+// sessions of SYSTEM, LOCAL SERVICE and NETWORK SERVICE, whose processes
+// include csrss.exe, lsass.exe and services.exe; terminating those stops
+// the system.
+var ErrSystemSession = errors.New("winlsa: refusing to terminate the processes of a system logon session")
+
+// ErrCriticalProcess is returned by TerminateSessionProcesses for
+// processes Windows marks as critical, which it does not terminate.
+var ErrCriticalProcess = errors.New("winlsa: refusing to terminate a critical process")
+
+// LogoffWTSSession logs off the Terminal Services session id, ending all
+// logon sessions in it; see SessionsForWTSSession. If wait is true, it
+// returns once the logoff has completed. Logging off other users' sessions
+// requires administrator rights.
+func LogoffWTSSession(id uint32, wait bool) error {
+	return logoffWTSSession(id, wait)
+}
+
+// DisconnectWTSSession disconnects the Terminal Services session id,
+// leaving its logon sessions and processes running. If wait is true, it
+// returns once the session is disconnected.
+func DisconnectWTSSession(id uint32, wait bool) error {
+	return disconnectWTSSession(id, wait)
+}
+
+// TerminateOpts are the options of TerminateSessionProcessesWithOpts.
+type TerminateOpts struct {
+	// Force allows terminating the processes of the SYSTEM, LOCAL SERVICE
+	// and NETWORK SERVICE sessions. Critical processes are skipped
+	// regardless.
+	Force bool
+}
+
+// TerminateSessionProcesses terminates the processes whose token belongs
+// to the logon session luid and returns their IDs. The calling process is
+// never terminated, nor are critical processes, which fail with
+// ErrCriticalProcess. The processes of the system sessions LUIDSystem,
+// LUIDLocalService and LUIDNetworkService are refused with
+// ErrSystemSession; see TerminateSessionProcessesWithOpts. Processes of
+// other users can only be found with SeDebugPrivilege enabled. If some
+// processes could not be terminated, the others are still returned, along
+// with the first error.
+func TerminateSessionProcesses(luid LUID) ([]uint32, error) {
+	return TerminateSessionProcessesWithOpts(luid, TerminateOpts{})
+}
+
+// TerminateSessionProcessesWithOpts is like TerminateSessionProcesses
+// with options.
+func TerminateSessionProcessesWithOpts(luid LUID, opts TerminateOpts) ([]uint32, error) {
+	switch luid {
+	case LUIDSystem, LUIDLocalService, LUIDNetworkService:
+		if !opts.Force {
+			return nil, ErrSystemSession
+		}
+	}
+	pids, err := sessionProcessIDs(luid)
+	if err != nil {
+		return nil, err
+	}
+	self := uint32(os.Getpid())
+	var terminated []uint32
+	var firstErr error
+	for _, pid := range pids {
+		if pid == self {
+			continue
+		}
+		err := terminateProcess(pid)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("process %d: %w", pid, err)
+			}
+			continue
+		}
+		terminated = append(terminated, pid)
+	}
+	return terminated, firstErr
+}
@@ -0,0 +1,16 @@
+package winlsa_test
+
+import (
+	"testing"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+func TestTerminateSystemSessions(t *testing.T) {
+	for _, luid := range []winlsa.LUID{winlsa.LUIDSystem, winlsa.LUIDLocalService, winlsa.LUIDNetworkService} {
+		pids, err := winlsa.TerminateSessionProcesses(luid)
+		if err != winlsa.ErrSystemSession || pids != nil {
+			t.Errorf("TerminateSessionProcesses(%v) = %v, %v; want ErrSystemSession", luid, pids, err)
+		}
+	}
+}
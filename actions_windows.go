@@ -0,0 +1,61 @@
+package winlsa
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func logoffWTSSession(id uint32, wait bool) error {
+	return lsa.WTSLogoffSession(lsa.WTS_CURRENT_SERVER_HANDLE, id, wait)
+}
+
+func disconnectWTSSession(id uint32, wait bool) error {
+	return lsa.WTSDisconnectSession(lsa.WTS_CURRENT_SERVER_HANDLE, id, wait)
+}
+
+// sessionProcessIDs lists the processes whose token belongs to the logon
+// session luid.
+func sessionProcessIDs(luid LUID) ([]uint32, error) {
+	snap, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer windows.CloseHandle(snap)
+
+	var pids []uint32
+	entry := windows.ProcessEntry32{Size: uint32(unsafe.Sizeof(windows.ProcessEntry32{}))}
+	for err = windows.Process32First(snap, &entry); err == nil; err = windows.Process32Next(snap, &entry) {
+		if entry.ProcessID == 0 {
+			continue
+		}
+		token, ok := openProcessTokenIn(entry.ProcessID, luid, windows.TOKEN_QUERY)
+		if ok {
+			token.Close()
+			pids = append(pids, entry.ProcessID)
+		}
+	}
+	if err != windows.ERROR_NO_MORE_FILES {
+		return nil, err
+	}
+	return pids, nil
+}
+
+// terminateProcess terminates the process pid unless it is critical.
+func terminateProcess(pid uint32) error {
+	process, err := windows.OpenProcess(windows.PROCESS_TERMINATE|windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseHandle(process)
+	critical, err := lsa.IsProcessCritical(process)
+	if err != nil {
+		return err
+	}
+	if critical {
+		return ErrCriticalProcess
+	}
+	return windows.TerminateProcess(process, 1)
+}
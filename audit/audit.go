@@ -0,0 +1,136 @@
+// Package audit wraps the advanced audit policy APIs, which configure
+// auditing per subcategory in the same way as auditpol.exe.
+//
+// Reading and writing the system audit policy requires SeSecurityPrivilege.
+// On platforms other than Windows, the calls fail with
+// winlsa.ErrUnsupportedPlatform.
+package audit
+
+import (
+	"strings"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// GUID identifies categories and subcategories. It is GUID on
+// Windows.
+type GUID = lsa.GUID
+
+// SID is the same type as winlsa.SID.
+type SID = lsa.SID
+
+// A Category is a top level audit policy category, e.g. "Logon/Logoff".
+type Category struct {
+	GUID GUID
+	Name string
+}
+
+// A SubCategory is an audit policy subcategory, e.g. "Logon". Audit settings
+// are applied per subcategory.
+type SubCategory struct {
+	GUID     GUID
+	Name     string
+	Category GUID
+}
+
+// Setting is the auditing applied to a subcategory.
+type Setting uint32
+
+const (
+	// SettingUnchanged leaves the current setting of a subcategory in
+	// place when passed to SetSystemPolicy.
+	SettingUnchanged Setting = 0x0
+	SettingSuccess   Setting = 0x1
+	SettingFailure   Setting = 0x2
+	SettingNone      Setting = 0x4
+)
+
+func (s Setting) String() string {
+	switch {
+	case s&SettingNone != 0:
+		return "No Auditing"
+	case s&(SettingSuccess|SettingFailure) == SettingSuccess|SettingFailure:
+		return "Success and Failure"
+	case s&SettingSuccess != 0:
+		return "Success"
+	case s&SettingFailure != 0:
+		return "Failure"
+	default:
+		return "No Auditing"
+	}
+}
+
+// ParseSetting parses the strings returned by Setting.String, as well as the
+// shorter "success", "failure", "both" and "none" forms.
+func ParseSetting(s string) (Setting, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "success":
+		return SettingSuccess, true
+	case "failure":
+		return SettingFailure, true
+	case "success and failure", "both":
+		return SettingSuccess | SettingFailure, true
+	case "no auditing", "none":
+		return SettingNone, true
+	case "unchanged":
+		return SettingUnchanged, true
+	default:
+		return 0, false
+	}
+}
+
+// A SubCategoryPolicy is the audit setting of a single subcategory.
+type SubCategoryPolicy struct {
+	SubCategory GUID
+	Category    GUID
+	Setting     Setting
+}
+
+// Categories returns all audit policy categories with their names.
+func Categories() ([]Category, error) {
+	return categories()
+}
+
+// SubCategories returns the subcategories of category with their names.
+func SubCategories(category GUID) ([]SubCategory, error) {
+	return subCategories(category)
+}
+
+// AllSubCategories returns the subcategories of every category.
+func AllSubCategories() ([]SubCategory, error) {
+	categories, err := Categories()
+	if err != nil {
+		return nil, err
+	}
+	var subCategories []SubCategory
+	for _, category := range categories {
+		scs, err := SubCategories(category.GUID)
+		if err != nil {
+			return nil, err
+		}
+		subCategories = append(subCategories, scs...)
+	}
+	return subCategories, nil
+}
+
+// CategoryName returns the display name of the category identified by guid.
+func CategoryName(guid GUID) (string, error) {
+	return categoryName(guid)
+}
+
+// SubCategoryName returns the display name of the subcategory identified by
+// guid.
+func SubCategoryName(guid GUID) (string, error) {
+	return subCategoryName(guid)
+}
+
+// QuerySystemPolicy returns the system audit settings of subCategories.
+func QuerySystemPolicy(subCategories []GUID) ([]SubCategoryPolicy, error) {
+	return querySystemPolicy(subCategories)
+}
+
+// SetSystemPolicy applies policies to the system audit policy. Only the
+// SubCategory and Setting fields are used.
+func SetSystemPolicy(policies []SubCategoryPolicy) error {
+	return setSystemPolicy(policies)
+}
@@ -0,0 +1,194 @@
+package audit
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func categories() ([]Category, error) {
+	var buffer *windows.GUID
+	var cnt uint32
+	err := lsa.AuditEnumerateCategories(&buffer, &cnt)
+	if err != nil {
+		return nil, err
+	}
+	defer lsa.AuditFree(unsafe.Pointer(buffer))
+
+	guids := guidSlice(buffer, cnt)
+	categories := make([]Category, len(guids))
+	for idx, guid := range guids {
+		name, err := CategoryName(guid)
+		if err != nil {
+			return nil, err
+		}
+		categories[idx] = Category{GUID: guid, Name: name}
+	}
+	return categories, nil
+}
+
+func subCategories(category windows.GUID) ([]SubCategory, error) {
+	var buffer *windows.GUID
+	var cnt uint32
+	err := lsa.AuditEnumerateSubCategories(&category, false, &buffer, &cnt)
+	if err != nil {
+		return nil, err
+	}
+	defer lsa.AuditFree(unsafe.Pointer(buffer))
+
+	guids := guidSlice(buffer, cnt)
+	subCategories := make([]SubCategory, len(guids))
+	for idx, guid := range guids {
+		name, err := SubCategoryName(guid)
+		if err != nil {
+			return nil, err
+		}
+		subCategories[idx] = SubCategory{GUID: guid, Name: name, Category: category}
+	}
+	return subCategories, nil
+}
+
+func categoryName(guid windows.GUID) (string, error) {
+	var name *uint16
+	err := lsa.AuditLookupCategoryName(&guid, &name)
+	if err != nil {
+		return "", err
+	}
+	defer lsa.AuditFree(unsafe.Pointer(name))
+	return windows.UTF16PtrToString(name), nil
+}
+
+func subCategoryName(guid windows.GUID) (string, error) {
+	var name *uint16
+	err := lsa.AuditLookupSubCategoryName(&guid, &name)
+	if err != nil {
+		return "", err
+	}
+	defer lsa.AuditFree(unsafe.Pointer(name))
+	return windows.UTF16PtrToString(name), nil
+}
+
+func querySystemPolicy(subCategories []windows.GUID) ([]SubCategoryPolicy, error) {
+	if len(subCategories) == 0 {
+		return nil, nil
+	}
+	var buffer *lsa.AUDIT_POLICY_INFORMATION
+	err := lsa.AuditQuerySystemPolicy(&subCategories[0], uint32(len(subCategories)), &buffer)
+	if err != nil {
+		return nil, err
+	}
+	defer lsa.AuditFree(unsafe.Pointer(buffer))
+
+	data := unsafe.Slice(buffer, len(subCategories))
+	policies := make([]SubCategoryPolicy, len(data))
+	for idx, entry := range data {
+		policies[idx] = SubCategoryPolicy{
+			SubCategory: entry.AuditSubCategoryGuid,
+			Category:    entry.AuditCategoryGuid,
+			Setting:     Setting(entry.AuditingInformation),
+		}
+	}
+	return policies, nil
+}
+
+func setSystemPolicy(policies []SubCategoryPolicy) error {
+	if len(policies) == 0 {
+		return nil
+	}
+	data := make([]lsa.AUDIT_POLICY_INFORMATION, len(policies))
+	for idx, p := range policies {
+		data[idx] = lsa.AUDIT_POLICY_INFORMATION{
+			AuditSubCategoryGuid: p.SubCategory,
+			AuditingInformation:  uint32(p.Setting),
+		}
+	}
+	return lsa.AuditSetSystemPolicy(&data[0], uint32(len(data)))
+}
+
+func queryPerUserPolicy(sid *windows.SID, subCategories []windows.GUID) ([]PerUserPolicy, error) {
+	if len(subCategories) == 0 {
+		return nil, nil
+	}
+	var buffer *lsa.AUDIT_POLICY_INFORMATION
+	err := lsa.AuditQueryPerUserPolicy(sid, &subCategories[0], uint32(len(subCategories)), &buffer)
+	if err != nil {
+		return nil, err
+	}
+	defer lsa.AuditFree(unsafe.Pointer(buffer))
+
+	data := unsafe.Slice(buffer, len(subCategories))
+	policies := make([]PerUserPolicy, len(data))
+	for idx, entry := range data {
+		policies[idx] = PerUserPolicy{
+			SubCategory: entry.AuditSubCategoryGuid,
+			Category:    entry.AuditCategoryGuid,
+			Setting:     PerUserSetting(entry.AuditingInformation),
+		}
+	}
+	return policies, nil
+}
+
+func setPerUserPolicy(sid *windows.SID, policies []PerUserPolicy) error {
+	if len(policies) == 0 {
+		return nil
+	}
+	data := make([]lsa.AUDIT_POLICY_INFORMATION, len(policies))
+	for idx, p := range policies {
+		data[idx] = lsa.AUDIT_POLICY_INFORMATION{
+			AuditSubCategoryGuid: p.SubCategory,
+			AuditingInformation:  uint32(p.Setting),
+		}
+	}
+	return lsa.AuditSetPerUserPolicy(sid, &data[0], uint32(len(data)))
+}
+
+func enumeratePerUserPolicy() ([]*windows.SID, error) {
+	var buffer *lsa.POLICY_AUDIT_SID_ARRAY
+	err := lsa.AuditEnumeratePerUserPolicy(&buffer)
+	if err != nil {
+		return nil, err
+	}
+	defer lsa.AuditFree(unsafe.Pointer(buffer))
+	if buffer.UserSidArray == nil {
+		return nil, nil
+	}
+
+	data := unsafe.Slice(buffer.UserSidArray, buffer.UsersCount)
+	sids := make([]*windows.SID, 0, len(data))
+	for _, sid := range data {
+		sid, err := sid.Copy()
+		if err != nil {
+			return nil, err
+		}
+		sids = append(sids, sid)
+	}
+	return sids, nil
+}
+
+func querySecurity(info windows.SECURITY_INFORMATION) (*windows.SECURITY_DESCRIPTOR, error) {
+	var buffer *windows.SECURITY_DESCRIPTOR
+	err := lsa.AuditQuerySecurity(info, &buffer)
+	if err != nil {
+		return nil, err
+	}
+	defer lsa.AuditFree(unsafe.Pointer(buffer))
+	return copySecurityDescriptor(buffer), nil
+}
+
+func setSecurity(info windows.SECURITY_INFORMATION, sd *windows.SECURITY_DESCRIPTOR) error {
+	return lsa.AuditSetSecurity(info, sd)
+}
+
+func guidSlice(buffer *windows.GUID, cnt uint32) []windows.GUID {
+	data := unsafe.Slice(buffer, cnt)
+	return append([]windows.GUID(nil), data...)
+}
+
+func copySecurityDescriptor(sd *windows.SECURITY_DESCRIPTOR) *windows.SECURITY_DESCRIPTOR {
+	length := int(sd.Length())
+	data := unsafe.Slice((*byte)(unsafe.Pointer(sd)), length)
+	buf := append([]byte(nil), data...)
+	return (*windows.SECURITY_DESCRIPTOR)(unsafe.Pointer(&buf[0]))
+}
@@ -0,0 +1,185 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// A Document is a serializable snapshot of the system and per-user audit
+// policy, suitable for storing alongside golden images and re-applying with
+// Apply.
+type Document struct {
+	System  []SystemRecord `json:"system"`
+	PerUser []UserRecord   `json:"perUser,omitempty"`
+}
+
+// A SystemRecord is the system audit setting of one subcategory. Names are
+// informational only; SubCategoryGUID identifies the subcategory on import.
+type SystemRecord struct {
+	Category        string  `json:"category"`
+	SubCategory     string  `json:"subcategory"`
+	SubCategoryGUID string  `json:"subcategoryGuid"`
+	Setting         Setting `json:"setting"`
+}
+
+// A UserRecord holds the per-user audit exceptions of one principal.
+type UserRecord struct {
+	Sid      string              `json:"sid"`
+	Settings []UserSettingRecord `json:"settings"`
+}
+
+type UserSettingRecord struct {
+	Category        string         `json:"category"`
+	SubCategory     string         `json:"subcategory"`
+	SubCategoryGUID string         `json:"subcategoryGuid"`
+	Setting         PerUserSetting `json:"setting"`
+}
+
+func (s Setting) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+func (s *Setting) UnmarshalText(text []byte) error {
+	setting, ok := ParseSetting(string(text))
+	if !ok {
+		return fmt.Errorf("invalid audit setting %q", text)
+	}
+	*s = setting
+	return nil
+}
+
+func (s PerUserSetting) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+func (s *PerUserSetting) UnmarshalText(text []byte) error {
+	setting, ok := ParsePerUserSetting(string(text))
+	if !ok {
+		return fmt.Errorf("invalid per-user audit setting %q", text)
+	}
+	*s = setting
+	return nil
+}
+
+// Snapshot captures the current system audit policy and, if perUser is set,
+// every per-user audit exception.
+func Snapshot(perUser bool) (*Document, error) {
+	subCategories, err := AllSubCategories()
+	if err != nil {
+		return nil, err
+	}
+	guids := make([]GUID, len(subCategories))
+	for idx, sc := range subCategories {
+		guids[idx] = sc.GUID
+	}
+	categoryNames := map[GUID]string{}
+	categories, err := Categories()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range categories {
+		categoryNames[c.GUID] = c.Name
+	}
+
+	policies, err := QuerySystemPolicy(guids)
+	if err != nil {
+		return nil, err
+	}
+	doc := &Document{System: make([]SystemRecord, len(policies))}
+	for idx, p := range policies {
+		doc.System[idx] = SystemRecord{
+			Category:        categoryNames[subCategories[idx].Category],
+			SubCategory:     subCategories[idx].Name,
+			SubCategoryGUID: p.SubCategory.String(),
+			Setting:         p.Setting,
+		}
+	}
+	if !perUser {
+		return doc, nil
+	}
+
+	sids, err := EnumeratePerUserPolicy()
+	if err != nil {
+		return nil, err
+	}
+	for _, sid := range sids {
+		policies, err := QueryPerUserPolicy(sid, guids)
+		if err != nil {
+			return nil, err
+		}
+		record := UserRecord{Sid: sid.String()}
+		for idx, p := range policies {
+			if p.Setting == PerUserUnchanged {
+				continue
+			}
+			record.Settings = append(record.Settings, UserSettingRecord{
+				Category:        categoryNames[subCategories[idx].Category],
+				SubCategory:     subCategories[idx].Name,
+				SubCategoryGUID: p.SubCategory.String(),
+				Setting:         p.Setting,
+			})
+		}
+		doc.PerUser = append(doc.PerUser, record)
+	}
+	return doc, nil
+}
+
+// Apply writes the settings recorded in doc to the system. Subcategories not
+// present in doc are left unchanged.
+func (doc *Document) Apply() error {
+	system := make([]SubCategoryPolicy, 0, len(doc.System))
+	for _, r := range doc.System {
+		guid, err := lsa.GUIDFromString(r.SubCategoryGUID)
+		if err != nil {
+			return fmt.Errorf("subcategory %q: %v", r.SubCategory, err)
+		}
+		system = append(system, SubCategoryPolicy{SubCategory: guid, Setting: r.Setting})
+	}
+	err := SetSystemPolicy(system)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range doc.PerUser {
+		sid, err := lsa.StringToSid(u.Sid)
+		if err != nil {
+			return fmt.Errorf("per-user policy %q: %v", u.Sid, err)
+		}
+		policies := make([]PerUserPolicy, 0, len(u.Settings))
+		for _, r := range u.Settings {
+			guid, err := lsa.GUIDFromString(r.SubCategoryGUID)
+			if err != nil {
+				return fmt.Errorf("per-user policy %q, subcategory %q: %v", u.Sid, r.SubCategory, err)
+			}
+			policies = append(policies, PerUserPolicy{SubCategory: guid, Setting: r.Setting})
+		}
+		err = SetPerUserPolicy(sid, policies)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Export writes a Snapshot of the full audit policy, including per-user
+// exceptions, to w as indented JSON.
+func Export(w io.Writer) error {
+	doc, err := Snapshot(true)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// Import reads a JSON document produced by Export from r and applies it.
+func Import(r io.Reader) error {
+	var doc Document
+	err := json.NewDecoder(r).Decode(&doc)
+	if err != nil {
+		return err
+	}
+	return doc.Apply()
+}
@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"encoding/json"
+)
+
+// MarshalJSON encodes c with the GUID in string form.
+func (c Category) MarshalJSON() ([]byte, error) {
+	type category Category
+	return json.Marshal(struct {
+		category
+		GUID string
+	}{category(c), c.GUID.String()})
+}
+
+// MarshalJSON encodes sc with the GUIDs in string form.
+func (sc SubCategory) MarshalJSON() ([]byte, error) {
+	type subCategory SubCategory
+	return json.Marshal(struct {
+		subCategory
+		GUID     string
+		Category string
+	}{subCategory(sc), sc.GUID.String(), sc.Category.String()})
+}
+
+// MarshalJSON encodes p with the GUIDs in string form.
+func (p SubCategoryPolicy) MarshalJSON() ([]byte, error) {
+	type subCategoryPolicy SubCategoryPolicy
+	return json.Marshal(struct {
+		subCategoryPolicy
+		SubCategory string
+		Category    string
+	}{subCategoryPolicy(p), p.SubCategory.String(), p.Category.String()})
+}
+
+// MarshalJSON encodes p with the GUIDs in string form.
+func (p PerUserPolicy) MarshalJSON() ([]byte, error) {
+	type perUserPolicy PerUserPolicy
+	return json.Marshal(struct {
+		perUserPolicy
+		SubCategory string
+		Category    string
+	}{perUserPolicy(p), p.SubCategory.String(), p.Category.String()})
+}
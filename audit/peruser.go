@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PerUserSetting is a per-user auditing exception layered on top of the
+// system audit policy.
+type PerUserSetting uint32
+
+const (
+	// PerUserUnchanged leaves the current setting of a subcategory in place
+	// when passed to SetPerUserPolicy.
+	PerUserUnchanged      PerUserSetting = 0x00
+	PerUserSuccessInclude PerUserSetting = 0x01
+	PerUserSuccessExclude PerUserSetting = 0x02
+	PerUserFailureInclude PerUserSetting = 0x04
+	PerUserFailureExclude PerUserSetting = 0x08
+	PerUserNone           PerUserSetting = 0x10
+)
+
+var perUserSettingNames = []struct {
+	flag PerUserSetting
+	name string
+}{
+	{PerUserSuccessInclude, "SuccessInclude"},
+	{PerUserSuccessExclude, "SuccessExclude"},
+	{PerUserFailureInclude, "FailureInclude"},
+	{PerUserFailureExclude, "FailureExclude"},
+	{PerUserNone, "None"},
+}
+
+func (s PerUserSetting) String() string {
+	if s == PerUserUnchanged {
+		return "Unchanged"
+	}
+	var names []string
+	for _, n := range perUserSettingNames {
+		if s&n.flag != 0 {
+			names = append(names, n.name)
+			s &^= n.flag
+		}
+	}
+	if s != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint32(s)))
+	}
+	return strings.Join(names, "|")
+}
+
+// ParsePerUserSetting parses the strings returned by PerUserSetting.String.
+func ParsePerUserSetting(s string) (PerUserSetting, bool) {
+	var setting PerUserSetting
+	for _, part := range strings.Split(s, "|") {
+		part = strings.TrimSpace(part)
+		if strings.EqualFold(part, "Unchanged") {
+			continue
+		}
+		found := false
+		for _, n := range perUserSettingNames {
+			if strings.EqualFold(part, n.name) {
+				setting |= n.flag
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, false
+		}
+	}
+	return setting, true
+}
+
+// A PerUserPolicy is the per-user audit setting of a single subcategory.
+type PerUserPolicy struct {
+	SubCategory GUID
+	Category    GUID
+	Setting     PerUserSetting
+}
+
+// QueryPerUserPolicy returns the per-user audit settings of sid for
+// subCategories.
+func QueryPerUserPolicy(sid *SID, subCategories []GUID) ([]PerUserPolicy, error) {
+	return queryPerUserPolicy(sid, subCategories)
+}
+
+// SetPerUserPolicy applies policies to the per-user audit policy of sid.
+// Only the SubCategory and Setting fields are used.
+func SetPerUserPolicy(sid *SID, policies []PerUserPolicy) error {
+	return setPerUserPolicy(sid, policies)
+}
+
+// EnumeratePerUserPolicy returns the SIDs of all principals that have a
+// per-user audit policy defined.
+func EnumeratePerUserPolicy() ([]*SID, error) {
+	return enumeratePerUserPolicy()
+}
@@ -0,0 +1,40 @@
+package audit
+
+import "github.com/cobraqxx/winlsa/internal/lsa"
+
+// A SecurityDescriptor is SecurityDescriptor on Windows.
+type SecurityDescriptor = lsa.SecurityDescriptor
+
+// SecurityInformation selects the parts of a SecurityDescriptor. It is
+// SecurityInformation on Windows.
+type SecurityInformation = lsa.SecurityInformation
+
+// Access rights controlled by the audit policy security descriptor.
+type Access uint32
+
+const (
+	AccessSetSystemPolicy   Access = 0x00000001
+	AccessQuerySystemPolicy Access = 0x00000002
+	AccessSetUserPolicy     Access = 0x00000004
+	AccessQueryUserPolicy   Access = 0x00000008
+	AccessEnumerateUsers    Access = 0x00000010
+	AccessSetMiscPolicy     Access = 0x00000020
+	AccessQueryMiscPolicy   Access = 0x00000040
+	AccessGenericRead       Access = 0x0002005A
+	AccessGenericWrite      Access = 0x00020025
+	AccessGenericExecute    Access = 0x00020000
+	AccessGenericAll        Access = 0x000F007F
+)
+
+// QuerySecurity returns the security descriptor that controls access to the
+// audit policy. info selects the parts of the descriptor to retrieve; reading
+// the SACL requires SeSecurityPrivilege.
+func QuerySecurity(info SecurityInformation) (*SecurityDescriptor, error) {
+	return querySecurity(info)
+}
+
+// SetSecurity replaces the parts of the audit policy security descriptor
+// selected by info with those of sd.
+func SetSecurity(info SecurityInformation, sd *SecurityDescriptor) error {
+	return setSecurity(info, sd)
+}
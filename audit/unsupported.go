@@ -0,0 +1,50 @@
+//go:build !windows
+// +build !windows
+
+package audit
+
+import "github.com/cobraqxx/winlsa/internal/lsa"
+
+func categories() ([]Category, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func subCategories(category GUID) ([]SubCategory, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func categoryName(guid GUID) (string, error) {
+	return "", lsa.ErrUnsupportedPlatform
+}
+
+func subCategoryName(guid GUID) (string, error) {
+	return "", lsa.ErrUnsupportedPlatform
+}
+
+func querySystemPolicy(subCategories []GUID) ([]SubCategoryPolicy, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func setSystemPolicy(policies []SubCategoryPolicy) error {
+	return lsa.ErrUnsupportedPlatform
+}
+
+func queryPerUserPolicy(sid *SID, subCategories []GUID) ([]PerUserPolicy, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func setPerUserPolicy(sid *SID, policies []PerUserPolicy) error {
+	return lsa.ErrUnsupportedPlatform
+}
+
+func enumeratePerUserPolicy() ([]*SID, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func querySecurity(info SecurityInformation) (*SecurityDescriptor, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func setSecurity(info SecurityInformation, sd *SecurityDescriptor) error {
+	return lsa.ErrUnsupportedPlatform
+}
@@ -0,0 +1,126 @@
+package winlsa
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// GetLogonSessionsDataParallel returns the data of all logon sessions,
+// querying up to workers sessions concurrently; workers <= 0 selects
+// GOMAXPROCS. Each query is a round trip to LSASS, so on hosts with many
+// sessions this is much faster than calling GetLogonSessionData in a loop.
+//
+// The result is in enumeration order. Sessions that end between enumeration
+// and query are skipped. If other sessions cannot be queried, the remaining
+// sessions are returned with a SessionErrors error.
+func GetLogonSessionsDataParallel(workers int) ([]*LogonSessionData, error) {
+	return GetLogonSessionsDataParallelContext(context.Background(), workers)
+}
+
+// GetLogonSessionsDataParallelContext is like GetLogonSessionsDataParallel
+// but stops querying sessions once ctx is done and returns ctx.Err().
+// Queries already sent to LSASS are not interrupted.
+func GetLogonSessionsDataParallelContext(ctx context.Context, workers int) (_ []*LogonSessionData, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	ctx, op := lsa.StartOp(ctx, "winlsa.GetLogonSessionsDataParallel", nil)
+	defer func() { op.End(err) }()
+
+	luids, err := appendSessions(ctx, nil)
+	if err != nil && !IsFreeBufferError(err) {
+		return nil, err
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(luids) {
+		workers = len(luids)
+	}
+
+	data := make([]*LogonSessionData, len(luids))
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = SessionErrors{}
+		next = make(chan int)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range next {
+				sd, err := sessionData(ctx, luids[idx], GetLogonSessionDataOpts{Fields: SessionFieldAll})
+				if err == ErrNoSuchLogonSession {
+					continue
+				}
+				if err != nil && !IsFreeBufferError(err) {
+					mu.Lock()
+					errs[luids[idx]] = err
+					mu.Unlock()
+					continue
+				}
+				data[idx] = sd
+			}
+		}()
+	}
+dispatch:
+	for idx := range luids {
+		select {
+		case next <- idx:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(next)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sessions := data[:0]
+	for _, sd := range data {
+		if sd != nil {
+			sessions = append(sessions, sd)
+		}
+	}
+	if len(errs) > 0 {
+		return sessions, errs
+	}
+	return sessions, nil
+}
+
+// SessionsForWTSSession returns the logon sessions whose Session, the
+// Terminal Services session ID, equals id. A WTS session typically holds
+// the user's logon sessions (two for elevated administrators) and those of
+// the window manager and font driver accounts. Like FindLogonSessions, it
+// returns the matches found with a SessionErrors error if some sessions
+// could not be queried.
+func SessionsForWTSSession(id uint32) ([]LUID, error) {
+	luids, err := GetLogonSessions()
+	if err != nil && !IsFreeBufferError(err) {
+		return nil, err
+	}
+	var matches []LUID
+	errs := SessionErrors{}
+	for idx := range luids {
+		sd, err := GetLogonSessionDataWithOpts(&luids[idx], GetLogonSessionDataOpts{})
+		if err == ErrNoSuchLogonSession {
+			continue
+		}
+		if err != nil && !IsFreeBufferError(err) {
+			errs[luids[idx]] = err
+			continue
+		}
+		if sd.Session == id {
+			matches = append(matches, luids[idx])
+		}
+	}
+	if len(errs) > 0 {
+		return matches, errs
+	}
+	return matches, nil
+}
@@ -0,0 +1,103 @@
+package winlsa
+
+import (
+	"sync"
+	"time"
+)
+
+// A SessionCache memoizes GetLogonSessionData for callers that look up the
+// same sessions at a high rate, e.g. once per connection in a proxy. It is
+// safe for concurrent use.
+//
+// The cached *LogonSessionData values are shared between callers and must
+// not be modified.
+type SessionCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[LUID]cacheEntry
+	gen     uint64
+}
+
+// A cacheEntry with nil data is a tombstone left by Invalidate. Every
+// change of an entry gives it a new gen, so that Get does not cache the
+// result of a query that raced with a logoff or a newer put.
+type cacheEntry struct {
+	data    *LogonSessionData
+	expires time.Time
+	gen     uint64
+}
+
+// NewSessionCache returns a cache that keeps session data for ttl.
+func NewSessionCache(ttl time.Duration) *SessionCache {
+	return &SessionCache{ttl: ttl, entries: map[LUID]cacheEntry{}}
+}
+
+// Get returns the data of the logon session luid, querying the LSA if it
+// is not cached or its entry expired. Errors are not cached.
+func (c *SessionCache) Get(luid LUID) (*LogonSessionData, error) {
+	now := time.Now()
+	c.mu.Lock()
+	e := c.entries[luid]
+	c.mu.Unlock()
+	if e.data != nil && now.Before(e.expires) {
+		return e.data, nil
+	}
+
+	sd, err := GetLogonSessionData(&luid)
+	if err != nil && !IsFreeBufferError(err) {
+		c.Invalidate(luid)
+		return nil, err
+	}
+	c.mu.Lock()
+	if c.entries[luid].gen == e.gen {
+		c.set(luid, sd, now)
+	}
+	c.mu.Unlock()
+	return sd, nil
+}
+
+// set replaces the entry of luid. c.mu must be held.
+func (c *SessionCache) set(luid LUID, sd *LogonSessionData, now time.Time) {
+	c.gen++
+	c.entries[luid] = cacheEntry{data: sd, expires: now.Add(c.ttl), gen: c.gen}
+}
+
+// Invalidate drops the entry of luid. Lookups of luid that are in flight
+// when Invalidate is called return their result but do not cache it.
+func (c *SessionCache) Invalidate(luid LUID) {
+	c.mu.Lock()
+	c.set(luid, nil, time.Now())
+	c.mu.Unlock()
+}
+
+// Observe updates the cache with an event from a Watcher: logoffs drop the
+// session's entry and logons seed it with the event's data. Feeding all
+// events of a watcher to Observe keeps entries of ended sessions from
+// being served for the rest of their TTL.
+func (c *SessionCache) Observe(ev SessionEvent) {
+	switch ev.Type {
+	case SessionLogoff:
+		c.Invalidate(ev.LogonId)
+	case SessionLogon:
+		if ev.Data != nil {
+			c.mu.Lock()
+			c.set(ev.LogonId, ev.Data, ev.Time)
+			c.mu.Unlock()
+		}
+	}
+}
+
+// Prune drops expired entries and tombstones. Get refreshes expired entries on access, so
+// Prune only needs to be called to release the memory of sessions that are
+// no longer looked up.
+func (c *SessionCache) Prune() {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for luid, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, luid)
+		}
+	}
+}
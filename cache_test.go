@@ -0,0 +1,134 @@
+package winlsa_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/fakelsa"
+)
+
+// pausingLSA is a fake LSA whose GetLogonSessionData signals queried once it
+// has read the session and then waits for resume, so that a test can run
+// code between a query and its return.
+type pausingLSA struct {
+	*fakelsa.LSA
+	queried chan struct{}
+	resume  chan struct{}
+}
+
+func (p *pausingLSA) GetLogonSessionData(luid winlsa.LUID, opts winlsa.GetLogonSessionDataOpts) (*winlsa.LogonSessionData, error) {
+	sd, err := p.LSA.GetLogonSessionData(luid, opts)
+	p.queried <- struct{}{}
+	<-p.resume
+	return sd, err
+}
+
+// getPaused starts c.Get(luid) and returns once it has queried the LSA,
+// with the Get paused until done is called, which waits for its result.
+func getPaused(t *testing.T, lsa *pausingLSA, c *winlsa.SessionCache, luid winlsa.LUID) (done func() *winlsa.LogonSessionData) {
+	t.Helper()
+	result := make(chan *winlsa.LogonSessionData)
+	go func() {
+		sd, err := c.Get(luid)
+		if err != nil {
+			t.Error(err)
+		}
+		result <- sd
+	}()
+	<-lsa.queried
+	return func() *winlsa.LogonSessionData {
+		lsa.resume <- struct{}{}
+		return <-result
+	}
+}
+
+// TestSessionCacheLogoffDuringGet ends a session while a Get of it is
+// querying the LSA. The Get returns the data it read but must not cache
+// it, or the ended session would be served for the rest of the TTL.
+func TestSessionCacheLogoffDuringGet(t *testing.T) {
+	for _, cached := range []bool{false, true} {
+		name := "uncached"
+		if cached {
+			name = "expired"
+		}
+		t.Run(name, func(t *testing.T) {
+			luid := winlsa.LUID{LowPart: 0x3e7a1}
+			lsa := &pausingLSA{
+				LSA:     fakelsa.New(&winlsa.LogonSessionData{LogonId: luid, UserName: "alice"}),
+				queried: make(chan struct{}),
+				resume:  make(chan struct{}),
+			}
+			winlsa.SetProvider(lsa)
+			defer winlsa.SetProvider(nil)
+
+			c := winlsa.NewSessionCache(time.Hour)
+			if cached {
+				// An entry that expired long ago, so that Get queries.
+				c.Observe(winlsa.SessionEvent{
+					Type:    winlsa.SessionLogon,
+					Time:    time.Now().Add(-2 * time.Hour),
+					LogonId: luid,
+					Data:    &winlsa.LogonSessionData{LogonId: luid, UserName: "alice"},
+				})
+			}
+			done := getPaused(t, lsa, c, luid)
+			lsa.Remove(luid)
+			c.Observe(winlsa.SessionEvent{Type: winlsa.SessionLogoff, Time: time.Now(), LogonId: luid})
+			if sd := done(); sd == nil || sd.UserName != "alice" {
+				t.Fatalf("Get returned %+v, want the session read before the logoff", sd)
+			}
+
+			go func() { <-lsa.queried; lsa.resume <- struct{}{} }()
+			if sd, err := c.Get(luid); !errors.Is(err, winlsa.ErrNoSuchLogonSession) {
+				t.Errorf("Get after the logoff returned %+v, %v, want %v", sd, err, winlsa.ErrNoSuchLogonSession)
+			}
+		})
+	}
+}
+
+// TestSessionCacheLogonDuringGet seeds the cache from a logon event while a
+// Get is querying the LSA. The event's data is newer and must be kept.
+func TestSessionCacheLogonDuringGet(t *testing.T) {
+	luid := winlsa.LUID{LowPart: 0x3e7a1}
+	lsa := &pausingLSA{
+		LSA:     fakelsa.New(&winlsa.LogonSessionData{LogonId: luid, UserName: "alice"}),
+		queried: make(chan struct{}),
+		resume:  make(chan struct{}),
+	}
+	winlsa.SetProvider(lsa)
+	defer winlsa.SetProvider(nil)
+
+	c := winlsa.NewSessionCache(time.Hour)
+	done := getPaused(t, lsa, c, luid)
+	bob := &winlsa.LogonSessionData{LogonId: luid, UserName: "bob"}
+	c.Observe(winlsa.SessionEvent{Type: winlsa.SessionLogon, Time: time.Now(), LogonId: luid, Data: bob})
+	done()
+
+	// The cached entry is served without querying the LSA, which would
+	// block.
+	if sd, err := c.Get(luid); err != nil || sd != bob {
+		t.Errorf("Get returned %+v, %v, want the data of the logon event", sd, err)
+	}
+}
+
+// TestSessionCacheGetAfterLogoff checks that a session is cached again once
+// it is looked up after its logoff, as LUIDs may be reused.
+func TestSessionCacheGetAfterLogoff(t *testing.T) {
+	luid := winlsa.LUID{LowPart: 0x3e7a1}
+	lsa := fakelsa.New(&winlsa.LogonSessionData{LogonId: luid, UserName: "alice"})
+	winlsa.SetProvider(lsa)
+	defer winlsa.SetProvider(nil)
+
+	c := winlsa.NewSessionCache(time.Hour)
+	c.Observe(winlsa.SessionEvent{Type: winlsa.SessionLogoff, Time: time.Now(), LogonId: luid})
+	first, err := c.Get(luid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lsa.Remove(luid)
+	if sd, err := c.Get(luid); err != nil || sd != first {
+		t.Errorf("Get returned %+v, %v, want the cached %+v", sd, err, first)
+	}
+}
@@ -0,0 +1,121 @@
+package winlsa
+
+// A CapabilityReport describes the security features of the host that
+// limit what the package can do, so that tools can explain missing data
+// instead of failing obscurely.
+type CapabilityReport struct {
+	// OSVersion is the Windows version as major.minor.build.
+	OSVersion string
+	OSBuild   uint32
+	// Elevated reports whether the caller runs with administrator rights.
+	Elevated bool
+	// CredentialGuard reports whether Credential Guard is running, i.e.
+	// whether the isolated LSA process LsaIso.exe exists.
+	CredentialGuard bool
+	// CredentialGuardConfigured reports whether LsaCfgFlags enables
+	// Credential Guard, which takes effect after a reboot.
+	CredentialGuardConfigured bool
+	// LSAProtection reports whether LSASS runs as a protected process
+	// light (PPL).
+	LSAProtection bool
+	// LSAProtectionConfigured reports whether RunAsPPL is set.
+	LSAProtectionConfigured bool
+	// AllowTgtSessionKey reports whether the Kerberos package returns the
+	// session keys of TGTs, see kerberos.AllowTgtSessionKey.
+	AllowTgtSessionKey bool
+	// WDigestLoaded reports whether the WDigest package is listed in the
+	// Security Packages the LSA loads.
+	WDigestLoaded bool
+	// WDigestUseLogonCredential is the effective UseLogonCredential
+	// setting of WDigest: the registry value if set, otherwise the default
+	// of the Windows version, which is on before Windows 8.1 and Server
+	// 2012 R2.
+	WDigestUseLogonCredential bool
+	// Features are the version dependent features of OSBuild.
+	Features RuntimeFeatures
+	// Unavailable lists the features that do not work, or return less
+	// data, on this host and why.
+	Unavailable []Limitation
+	// Findings lists the settings security teams treat as
+	// misconfigurations.
+	Findings []Finding `json:",omitempty"`
+}
+
+// Severity ranks a Finding.
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityWarning  Severity = "warning"
+)
+
+// A Finding is a host setting that weakens the protection of the
+// credentials the LSA holds.
+type Finding struct {
+	Severity Severity
+	Setting  string
+	Issue    string
+}
+
+func (f Finding) String() string {
+	return string(f.Severity) + ": " + f.Setting + ": " + f.Issue
+}
+
+// A Limitation is a feature unavailable on the host.
+type Limitation struct {
+	Feature string
+	Reason  string
+}
+
+func (l Limitation) String() string {
+	return l.Feature + ": " + l.Reason
+}
+
+// Capabilities inspects the host's LSA configuration. Fields it cannot
+// determine are left false.
+func Capabilities() (*CapabilityReport, error) {
+	r, err := probeCapabilities()
+	if err != nil {
+		return nil, err
+	}
+
+	r.Features = FeaturesForBuild(r.OSBuild)
+	if !r.Features.CloudAP {
+		r.Unavailable = append(r.Unavailable,
+			Limitation{"CloudAP and Primary Refresh Token state", "the cloud authentication package requires Windows 10"},
+		)
+	}
+	if !r.Features.LastLogonInfo {
+		r.Unavailable = append(r.Unavailable,
+			Limitation{"last logon information and password times", "the LSA returns them from Windows Vista"},
+		)
+	}
+	if !r.Elevated {
+		r.Unavailable = append(r.Unavailable,
+			Limitation{"Kerberos tickets of other sessions", "kerberos.ConnectTrusted requires administrator rights"},
+			Limitation{"Security log enrichment", "requires administrator rights"},
+			Limitation{"SMB session correlation", "requires administrator rights"},
+			Limitation{"session tokens of other users", "requires administrator rights and SeDebugPrivilege"},
+		)
+	}
+	if r.CredentialGuard {
+		r.Unavailable = append(r.Unavailable,
+			Limitation{"Kerberos TGT session keys", "Credential Guard keeps them in the isolated LSA, so retrieved TGTs have none"},
+		)
+	} else if !r.AllowTgtSessionKey {
+		r.Unavailable = append(r.Unavailable,
+			Limitation{"Kerberos TGT session keys", "the AllowTgtSessionKey policy is not set, so retrieved TGTs have zeroed keys"},
+		)
+	}
+	if r.LSAProtection {
+		r.Unavailable = append(r.Unavailable,
+			Limitation{"terminating LSASS or opening it for reading", "LSASS runs as a protected process"},
+		)
+	}
+	if r.WDigestLoaded && r.WDigestUseLogonCredential {
+		r.Findings = append(r.Findings,
+			Finding{SeverityCritical, "WDigest UseLogonCredential", "WDigest caches the plaintext passwords of interactive logons in LSASS memory"},
+		)
+	}
+	return r, nil
+}
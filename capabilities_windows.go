@@ -0,0 +1,119 @@
+package winlsa
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/cobraqxx/winlsa/kerberos"
+)
+
+const lsaKey = `SYSTEM\CurrentControlSet\Control\Lsa`
+
+func osBuild() uint32 {
+	_, _, build := windows.RtlGetNtVersionNumbers()
+	return build & 0xFFFF
+}
+
+// probeCapabilities fills in the report fields other than Unavailable.
+func probeCapabilities() (*CapabilityReport, error) {
+	major, minor, build := windows.RtlGetNtVersionNumbers()
+	build &= 0xFFFF
+	r := &CapabilityReport{
+		OSVersion: fmt.Sprintf("%d.%d.%d", major, minor, build),
+		OSBuild:   build,
+		Elevated:  windows.GetCurrentProcessToken().IsElevated(),
+	}
+
+	if k, err := registry.OpenKey(registry.LOCAL_MACHINE, lsaKey, registry.QUERY_VALUE); err == nil {
+		if v, _, err := k.GetIntegerValue("LsaCfgFlags"); err == nil {
+			r.CredentialGuardConfigured = v != 0
+		}
+		if v, _, err := k.GetIntegerValue("RunAsPPL"); err == nil {
+			r.LSAProtectionConfigured = v != 0
+		}
+		k.Close()
+	}
+
+	r.AllowTgtSessionKey, _ = kerberos.AllowTgtSessionKey()
+	r.WDigestLoaded, r.WDigestUseLogonCredential = probeWDigest(build)
+
+	pids, err := processIDsByName("lsaiso.exe", "lsass.exe")
+	if err != nil {
+		return nil, err
+	}
+	r.CredentialGuard = pids["lsaiso.exe"] != 0
+	if pid := pids["lsass.exe"]; pid != 0 {
+		r.LSAProtection = isProtectedProcess(pid)
+	}
+	return r, nil
+}
+
+const wdigestKey = `SYSTEM\CurrentControlSet\Control\SecurityProviders\WDigest`
+
+// windows81Build is the build of Windows 8.1 and Server 2012 R2, which
+// turned WDigest's UseLogonCredential off by default.
+const windows81Build = 9600
+
+// probeWDigest reports whether the LSA loads WDigest and whether WDigest
+// keeps logon passwords.
+func probeWDigest(build uint32) (loaded, useLogonCredential bool) {
+	if k, err := registry.OpenKey(registry.LOCAL_MACHINE, lsaKey, registry.QUERY_VALUE); err == nil {
+		if pkgs, _, err := k.GetStringsValue("Security Packages"); err == nil {
+			for _, pkg := range pkgs {
+				loaded = loaded || strings.EqualFold(strings.Trim(pkg, `"`), "wdigest")
+			}
+		}
+		k.Close()
+	}
+	useLogonCredential = build < windows81Build
+	if k, err := registry.OpenKey(registry.LOCAL_MACHINE, wdigestKey, registry.QUERY_VALUE); err == nil {
+		if v, _, err := k.GetIntegerValue("UseLogonCredential"); err == nil {
+			useLogonCredential = v != 0
+		}
+		k.Close()
+	}
+	return loaded, useLogonCredential
+}
+
+// processIDsByName returns the ID of one process for each of the given
+// lower case executable names that is running.
+func processIDsByName(names ...string) (map[string]uint32, error) {
+	snap, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer windows.CloseHandle(snap)
+
+	pids := map[string]uint32{}
+	entry := windows.ProcessEntry32{Size: uint32(unsafe.Sizeof(windows.ProcessEntry32{}))}
+	for err = windows.Process32First(snap, &entry); err == nil; err = windows.Process32Next(snap, &entry) {
+		exe := strings.ToLower(windows.UTF16ToString(entry.ExeFile[:]))
+		for _, name := range names {
+			if exe == name {
+				pids[name] = entry.ProcessID
+			}
+		}
+	}
+	if err != windows.ERROR_NO_MORE_FILES {
+		return nil, err
+	}
+	return pids, nil
+}
+
+// isProtectedProcess reports whether process pid runs with a protection
+// level, i.e. as PP or PPL.
+func isProtectedProcess(pid uint32) bool {
+	process, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return false
+	}
+	defer windows.CloseHandle(process)
+	// PS_PROTECTION: the low three bits are the protection type.
+	var protection byte
+	err = windows.NtQueryInformationProcess(process, windows.ProcessProtectionInformation, unsafe.Pointer(&protection), 1, nil)
+	return err == nil && protection&0x7 != 0
+}
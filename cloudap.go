@@ -0,0 +1,128 @@
+package winlsa
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ErrNotCloudAP is returned for sessions not authenticated by CloudAP.
+var ErrNotCloudAP = errors.New("session was not authenticated by CloudAP")
+
+// AADJoinType is how a device is registered with Entra ID (Azure AD).
+type AADJoinType uint32
+
+const (
+	AADJoinTypeUnknown AADJoinType = iota
+	// AADJoinTypeDevice is an Entra ID joined device.
+	AADJoinTypeDevice
+	// AADJoinTypeWorkplace is a device with a registered work or school
+	// account.
+	AADJoinTypeWorkplace
+)
+
+func (t AADJoinType) String() string {
+	switch t {
+	case AADJoinTypeDevice:
+		return "Device"
+	case AADJoinTypeWorkplace:
+		return "Workplace"
+	default:
+		return "Unknown"
+	}
+}
+
+func (t AADJoinType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// CloudAPInfo describes the Entra ID context of a CloudAP logon session.
+type CloudAPInfo struct {
+	JoinType          AADJoinType
+	TenantID          string
+	TenantDisplayName string `json:",omitempty"`
+	DeviceID          string
+	UserEmail         string `json:",omitempty"`
+	// PRT is the state of the session's Primary Refresh Token. It is only
+	// known for the caller's own logon session and nil otherwise.
+	PRT *PRTStatus `json:",omitempty"`
+}
+
+// PRTStatus is the state of a Primary Refresh Token.
+type PRTStatus struct {
+	Present    bool
+	Authority  string `json:",omitempty"`
+	UpdateTime time.Time
+	ExpiryTime time.Time
+}
+
+// GetCloudAPInfo returns the Entra ID join information for a session
+// authenticated by CloudAP, and the state of its Primary Refresh Token if
+// sd is the caller's own logon session. The CloudAP package does not
+// document calls to query the token of other sessions, so its state is
+// taken from "dsregcmd /status", which only reports on its caller. It
+// returns ErrNotCloudAP for other sessions and a nil CloudAPInfo if the
+// device is not registered with Entra ID.
+func GetCloudAPInfo(sd *LogonSessionData) (*CloudAPInfo, error) {
+	if !sd.IsCloudAP() {
+		return nil, ErrNotCloudAP
+	}
+	info, err := aadJoinInfo()
+	if err != nil || info == nil {
+		return nil, err
+	}
+
+	current, err := GetCurrentTokenInfo()
+	if err != nil || current.LogonId != sd.LogonId {
+		return info, nil
+	}
+	info.PRT, err = currentPRTStatus()
+	return info, err
+}
+
+// currentPRTStatus reads the SSO state of the calling user from dsregcmd.
+func currentPRTStatus() (*PRTStatus, error) {
+	out, err := exec.Command("dsregcmd", "/status").Output()
+	if err != nil {
+		return nil, err
+	}
+	var prt PRTStatus
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		key, value, ok := cutField(scanner.Text())
+		if !ok {
+			continue
+		}
+		switch key {
+		case "AzureAdPrt":
+			prt.Present = value == "YES"
+		case "AzureAdPrtAuthority":
+			prt.Authority = value
+		case "AzureAdPrtUpdateTime":
+			prt.UpdateTime = parseDsregTime(value)
+		case "AzureAdPrtExpiryTime":
+			prt.ExpiryTime = parseDsregTime(value)
+		}
+	}
+	return &prt, scanner.Err()
+}
+
+// cutField splits a "key : value" line of dsregcmd output.
+func cutField(line string) (key, value string, ok bool) {
+	idx := strings.Index(line, " : ")
+	if idx < 0 {
+		return "", "", false
+	}
+	return strings.TrimSpace(line[:idx]), strings.TrimSpace(line[idx+3:]), true
+}
+
+func parseDsregTime(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04:05.000 MST", s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,32 @@
+package winlsa
+
+import (
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// aadJoinInfo returns the device's Entra ID join information, or nil if
+// it is not registered.
+func aadJoinInfo() (*CloudAPInfo, error) {
+	var join *lsa.DSREG_JOIN_INFO
+	err := lsa.NetGetAadJoinInformation(nil, &join)
+	if err != nil {
+		return nil, err
+	}
+	if join == nil {
+		return nil, nil
+	}
+	defer lsa.NetFreeAadJoinInformation(join)
+	info := &CloudAPInfo{
+		JoinType:          AADJoinType(join.JoinType),
+		TenantID:          windows.UTF16PtrToString(join.TenantId),
+		TenantDisplayName: windows.UTF16PtrToString(join.TenantDisplayName),
+		DeviceID:          windows.UTF16PtrToString(join.DeviceId),
+		UserEmail:         windows.UTF16PtrToString(join.JoinUserEmail),
+	}
+	if join.UserInfo != nil && join.UserInfo.UserEmail != nil {
+		info.UserEmail = windows.UTF16PtrToString(join.UserInfo.UserEmail)
+	}
+	return info, nil
+}
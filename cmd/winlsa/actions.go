@@ -0,0 +1,143 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+func runLogoff(args []string) error {
+	return runWTSAction("logoff", args, winlsa.LogoffWTSSession)
+}
+
+func runDisconnect(args []string) error {
+	return runWTSAction("disconnect", args, winlsa.DisconnectWTSSession)
+}
+
+func runWTSAction(name string, args []string, action func(id uint32, wait bool) error) error {
+	fs := newFlagSet("winlsa "+name, "<wts-session-id>")
+	wait := fs.Bool("wait", false, "wait until the operation has completed")
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	id, err := strconv.ParseUint(fs.Arg(0), 10, 32)
+	if err != nil {
+		return usagef("invalid session ID %q", fs.Arg(0))
+	}
+	return action(uint32(id), *wait)
+}
+
+// ensurePrivileges enables the named privileges and warns about those the
+// caller does not hold, leaving the actual failure to the operation.
+func ensurePrivileges(names ...string) {
+	_, err := winlsa.EnsurePrivileges(names...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "winlsa:", err)
+	}
+}
+
+func runTerminate(args []string) error {
+	fs := newFlagSet("winlsa terminate", "<luid>")
+	out := addOutputFlag(fs)
+	force := fs.Bool("force", false, "also terminate the processes of the SYSTEM, LOCAL SERVICE and NETWORK SERVICE sessions")
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	luid, err := winlsa.ParseLUID(fs.Arg(0))
+	if err != nil {
+		return &usageError{msg: err.Error()}
+	}
+	ensurePrivileges(winlsa.PrivilegeDebug)
+	pids, err := winlsa.TerminateSessionProcessesWithOpts(luid, winlsa.TerminateOpts{Force: *force})
+	if len(pids) > 0 {
+		printErr := out.list(pids, func(w io.Writer) error {
+			for _, pid := range pids {
+				fmt.Fprintln(w, "terminated", pid)
+			}
+			return nil
+		})
+		if err == nil {
+			err = printErr
+		}
+	}
+	return err
+}
+
+func runStale(args []string) error {
+	fs := newFlagSet("winlsa stale", "")
+	out := addOutputFlag(fs)
+	threshold := fs.Duration("threshold", time.Hour, "report sessions disconnected or without input for at least `duration`")
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	stale, err := winlsa.StaleSessions(*threshold)
+	if _, partial := err.(winlsa.SessionErrors); err != nil && !partial {
+		return fmt.Errorf("StaleSessions: %v", err)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, "winlsa:", err)
+	}
+	return out.list(stale, func(w io.Writer) error {
+		rows := make([][]string, len(stale))
+		for idx, s := range stale {
+			rows[idx] = []string{
+				strconv.FormatUint(uint64(s.WTSSession), 10),
+				s.State.String(),
+				accountName(s.LogonDomain, s.UserName),
+				s.Idle.Round(time.Second).String(),
+				strconv.Itoa(len(s.Sessions)),
+			}
+		}
+		return writeTable(w, []string{"wts", "state", "account", "idle", "sessions"}, rows)
+	})
+}
+
+func runNetonly(args []string) error {
+	fs := newFlagSet("winlsa netonly", "")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	sessions, err := winlsa.NewCredentialsSessions()
+	if _, partial := err.(winlsa.SessionErrors); err != nil && !partial {
+		return fmt.Errorf("NewCredentialsSessions: %v", err)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, "winlsa:", err)
+	}
+	return out.list(sessions, func(w io.Writer) error {
+		rows := make([][]string, len(sessions))
+		for idx, s := range sessions {
+			outbound, origin, parent := "-", "-", "-"
+			if s.OutboundUserName != "" {
+				outbound = accountName(s.OutboundDomainName, s.OutboundUserName)
+			}
+			if s.OriginProcessName != "" {
+				origin = fmt.Sprintf("%s (%d)", s.OriginProcessName, s.OriginProcessId)
+			}
+			if s.OriginLogonId != (winlsa.LUID{}) {
+				parent = s.OriginLogonId.String()
+			}
+			rows[idx] = []string{
+				s.Data.LogonId.String(),
+				accountName(s.Data.LogonDomain, s.Data.UserName),
+				outbound,
+				parent,
+				origin,
+				strconv.Itoa(len(s.Processes)),
+				strings.Join(s.KerberosClients, ","),
+			}
+		}
+		return writeTable(w, []string{"luid", "account", "outbound", "parent", "origin", "processes", "kerberos"}, rows)
+	})
+}
@@ -0,0 +1,195 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/audit"
+)
+
+var auditCommands []*command
+
+func init() {
+	auditCommands = []*command{
+		{name: "categories", summary: "list audit policy categories and subcategories", run: runAuditCategories},
+		{name: "get", args: "[category-or-subcategory]...", summary: "show the system audit policy", run: runAuditGet},
+		{name: "set", args: "<category-or-subcategory> <setting>", summary: "change the system audit policy", run: runAuditSet},
+		{name: "export", summary: "write the system and per-user audit policy as JSON", run: runAuditExport},
+		{name: "import", args: "<file>", summary: "apply an audit policy written by export", run: runAuditImport},
+	}
+}
+
+func runAudit(args []string) error {
+	return dispatch("winlsa audit", args, auditCommands)
+}
+
+func runAuditCategories(args []string) error {
+	fs := newFlagSet("winlsa audit categories", "")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	categories, err := audit.Categories()
+	if err != nil {
+		return fmt.Errorf("AuditEnumerateCategories: %v", err)
+	}
+	type categoryInfo struct {
+		GUID          string
+		Name          string
+		SubCategories []audit.SubCategory
+	}
+	infos := make([]categoryInfo, len(categories))
+	for idx, c := range categories {
+		subCategories, err := audit.SubCategories(c.GUID)
+		if err != nil {
+			return fmt.Errorf("AuditEnumerateSubCategories: %v", err)
+		}
+		infos[idx] = categoryInfo{GUID: c.GUID.String(), Name: c.Name, SubCategories: subCategories}
+	}
+
+	return out.list(infos, func(w io.Writer) error {
+		for _, c := range infos {
+			fmt.Fprintf(w, "%s %s\n", c.GUID, c.Name)
+			for _, sc := range c.SubCategories {
+				fmt.Fprintf(w, "  %s %s\n", sc.GUID, sc.Name)
+			}
+		}
+		return nil
+	})
+}
+
+// matchAuditRecords returns the records of doc selected by names, each of
+// which is a category or subcategory name or a subcategory GUID. No names
+// select every record.
+func matchAuditRecords(doc *audit.Document, names []string) ([]audit.SystemRecord, error) {
+	if len(names) == 0 {
+		return doc.System, nil
+	}
+	var records []audit.SystemRecord
+	for _, name := range names {
+		var found bool
+		for _, r := range doc.System {
+			if strings.EqualFold(name, r.Category) || strings.EqualFold(name, r.SubCategory) || strings.EqualFold(name, r.SubCategoryGUID) {
+				records = append(records, r)
+				found = true
+			}
+		}
+		if !found {
+			return nil, usagef("no audit category or subcategory %q", name)
+		}
+	}
+	return records, nil
+}
+
+func runAuditGet(args []string) error {
+	fs := newFlagSet("winlsa audit get", "[category-or-subcategory]...")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 0, -1)
+	if err != nil {
+		return err
+	}
+
+	doc, err := audit.Snapshot(false)
+	if err != nil {
+		return fmt.Errorf("AuditQuerySystemPolicy: %v", err)
+	}
+	records, err := matchAuditRecords(doc, fs.Args())
+	if err != nil {
+		return err
+	}
+	return out.list(records, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		var category string
+		for _, r := range records {
+			if r.Category != category {
+				category = r.Category
+				fmt.Fprintf(tw, "%s\t\n", category)
+			}
+			fmt.Fprintf(tw, "  %s\t%v\n", r.SubCategory, r.Setting)
+		}
+		return tw.Flush()
+	})
+}
+
+func runAuditSet(args []string) error {
+	fs := newFlagSet("winlsa audit set", "<category-or-subcategory> <setting>")
+	err := parseFlags(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	setting, ok := audit.ParseSetting(fs.Arg(1))
+	if !ok {
+		return usagef("invalid setting %q (available: success, failure, both, none)", fs.Arg(1))
+	}
+
+	doc, err := audit.Snapshot(false)
+	if err != nil {
+		return fmt.Errorf("AuditQuerySystemPolicy: %v", err)
+	}
+	records, err := matchAuditRecords(doc, fs.Args()[:1])
+	if err != nil {
+		return err
+	}
+	policies := make([]audit.SubCategoryPolicy, len(records))
+	for idx, r := range records {
+		guid, err := windows.GUIDFromString(r.SubCategoryGUID)
+		if err != nil {
+			return err
+		}
+		policies[idx] = audit.SubCategoryPolicy{SubCategory: guid, Setting: setting}
+	}
+	err = audit.SetSystemPolicy(policies)
+	if err != nil {
+		return fmt.Errorf("AuditSetSystemPolicy: %v", err)
+	}
+	return nil
+}
+
+func runAuditExport(args []string) error {
+	fs := newFlagSet("winlsa audit export", "")
+	file := fs.String("o", "", "write to `file` instead of stdout")
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	w := io.Writer(os.Stdout)
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return audit.Export(w)
+}
+
+func runAuditImport(args []string) error {
+	fs := newFlagSet("winlsa audit import", "<file>")
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	err = audit.Import(f)
+	if err != nil {
+		return fmt.Errorf("%s: %v", fs.Arg(0), err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+func runCapabilities(args []string) error {
+	fs := newFlagSet("winlsa capabilities", "")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	r, err := winlsa.Capabilities()
+	if err != nil {
+		return err
+	}
+	return out.object(r, func(w io.Writer) error {
+		fmt.Fprintf(w, "%-26s %s\n", "OSVersion:", r.OSVersion)
+		fmt.Fprintf(w, "%-26s %v\n", "Elevated:", r.Elevated)
+		fmt.Fprintf(w, "%-26s %v\n", "CredentialGuard:", r.CredentialGuard)
+		fmt.Fprintf(w, "%-26s %v\n", "CredentialGuardConfigured:", r.CredentialGuardConfigured)
+		fmt.Fprintf(w, "%-26s %v\n", "LSAProtection:", r.LSAProtection)
+		fmt.Fprintf(w, "%-26s %v\n", "LSAProtectionConfigured:", r.LSAProtectionConfigured)
+		fmt.Fprintf(w, "%-26s %v\n", "AllowTgtSessionKey:", r.AllowTgtSessionKey)
+		fmt.Fprintf(w, "%-26s %v\n", "WDigestLoaded:", r.WDigestLoaded)
+		fmt.Fprintf(w, "%-26s %v\n", "WDigestUseLogonCredential:", r.WDigestUseLogonCredential)
+		fmt.Fprintf(w, "%-26s %v\n", "TicketCacheEx3:", r.Features.TicketCacheEx3)
+		fmt.Fprintf(w, "%-26s %v\n", "CloudAP:", r.Features.CloudAP)
+		fmt.Fprintf(w, "%-26s %v\n", "LastLogonInfo:", r.Features.LastLogonInfo)
+		fmt.Fprintf(w, "%-26s %v\n", "S4U2Self:", r.Features.S4U2Self)
+		if len(r.Unavailable) > 0 {
+			fmt.Fprintln(w, "\nUnavailable:")
+			for _, l := range r.Unavailable {
+				fmt.Fprintf(w, "  %v\n", l)
+			}
+		}
+		if len(r.Findings) > 0 {
+			fmt.Fprintln(w, "\nFindings:")
+			for _, f := range r.Findings {
+				fmt.Fprintf(w, "  %v\n", f)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,58 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+func runCloudAP(args []string) error {
+	fs := newFlagSet("winlsa cloudap", "")
+	out := addOutputFlag(fs)
+	session := fs.String("session", "", "show the logon session `luid` instead of the caller's")
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	luid, err := parseSessionFlag(*session)
+	if err != nil {
+		return err
+	}
+	if *session == "" {
+		current, err := winlsa.GetCurrentTokenInfo()
+		if err != nil {
+			return err
+		}
+		luid = current.LogonId
+	}
+
+	sd, err := winlsa.GetLogonSessionData(&luid)
+	if err != nil {
+		return fmt.Errorf("GetLogonSessionData: %v", err)
+	}
+	info, err := winlsa.GetCloudAPInfo(sd)
+	if err != nil {
+		return err
+	}
+	if info == nil {
+		return fmt.Errorf("the device is not registered with Entra ID")
+	}
+	return out.object(info, func(w io.Writer) error {
+		fmt.Fprintf(w, "%-18s %v\n", "JoinType:", info.JoinType)
+		fmt.Fprintf(w, "%-18s %s\n", "TenantID:", info.TenantID)
+		fmt.Fprintf(w, "%-18s %s\n", "TenantName:", info.TenantDisplayName)
+		fmt.Fprintf(w, "%-18s %s\n", "DeviceID:", info.DeviceID)
+		fmt.Fprintf(w, "%-18s %s\n", "UserEmail:", info.UserEmail)
+		if prt := info.PRT; prt != nil {
+			fmt.Fprintf(w, "%-18s %v\n", "PRT:", prt.Present)
+			fmt.Fprintf(w, "%-18s %s\n", "PRTAuthority:", prt.Authority)
+			fmt.Fprintf(w, "%-18s %s\n", "PRTUpdateTime:", formatTime(prt.UpdateTime))
+			fmt.Fprintf(w, "%-18s %s\n", "PRTExpiryTime:", formatTime(prt.ExpiryTime))
+		}
+		return nil
+	})
+}
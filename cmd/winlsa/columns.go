@@ -0,0 +1,151 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+// A sessionColumn is a selectable field of tabular session listings.
+type sessionColumn struct {
+	name   string
+	header string
+	value  func(sd *winlsa.LogonSessionData) string
+}
+
+var sessionColumns = []sessionColumn{
+	{"luid", "LogonId", func(sd *winlsa.LogonSessionData) string { return sd.LogonId.String() }},
+	{"user", "User", func(sd *winlsa.LogonSessionData) string { return accountName(sd.LogonDomain, sd.UserName) }},
+	{"resolved", "ResolvedAccount", func(sd *winlsa.LogonSessionData) string {
+		if sd.ResolvedAccount == nil {
+			return "-"
+		}
+		return sd.ResolvedAccount.String()
+	}},
+	{"username", "UserName", func(sd *winlsa.LogonSessionData) string { return sd.UserName }},
+	{"domain", "LogonDomain", func(sd *winlsa.LogonSessionData) string { return sd.LogonDomain }},
+	{"kind", "AccountKind", func(sd *winlsa.LogonSessionData) string { return sd.AccountKind().String() }},
+	{"null", "NullSession", func(sd *winlsa.LogonSessionData) string { return strconv.FormatBool(sd.IsNullSession()) }},
+	{"type", "LogonType", func(sd *winlsa.LogonSessionData) string { return sd.LogonType.String() }},
+	{"package", "AuthenticationPackage", func(sd *winlsa.LogonSessionData) string { return sd.AuthenticationPackage }},
+	{"session", "Session", func(sd *winlsa.LogonSessionData) string { return strconv.FormatUint(uint64(sd.Session), 10) }},
+	{"sid", "Sid", func(sd *winlsa.LogonSessionData) string { return sidString(sd) }},
+	{"logontime", "LogonTime", func(sd *winlsa.LogonSessionData) string { return formatTime(sd.LogonTime) }},
+	{"age", "Age", func(sd *winlsa.LogonSessionData) string {
+		if sd.LogonTime.IsZero() {
+			return "-"
+		}
+		return sd.Age().Round(time.Second).String()
+	}},
+	{"source", "Source", func(sd *winlsa.LogonSessionData) string {
+		o := sd.RemoteOrigin
+		switch {
+		case o == nil:
+			return "-"
+		case o.Address != "" && o.Workstation != "":
+			return o.Address + " (" + o.Workstation + ")"
+		case o.Address != "":
+			return o.Address
+		default:
+			return o.Workstation
+		}
+	}},
+	{"logonserver", "LogonServer", func(sd *winlsa.LogonSessionData) string { return sd.LogonServer }},
+	{"dnsdomain", "DnsDomainName", func(sd *winlsa.LogonSessionData) string { return sd.DnsDomainName }},
+	{"upn", "Upn", func(sd *winlsa.LogonSessionData) string { return sd.Upn }},
+	{"userflags", "UserFlags", func(sd *winlsa.LogonSessionData) string { return fmt.Sprintf("0x%x", sd.UserFlags) }},
+	{"lastsuccessfullogon", "LastSuccessfulLogon", func(sd *winlsa.LogonSessionData) string { return formatTime(sd.LastSuccessfulLogon) }},
+	{"lastfailedlogon", "LastFailedLogon", func(sd *winlsa.LogonSessionData) string { return formatTime(sd.LastFailedLogon) }},
+	{"failedattempts", "FailedAttemptCount", func(sd *winlsa.LogonSessionData) string {
+		return strconv.FormatUint(uint64(sd.FailedAttemptCountSinceLastSuccessfulLogon), 10)
+	}},
+	{"logonscript", "LogonScript", func(sd *winlsa.LogonSessionData) string { return sd.LogonScript }},
+	{"profilepath", "ProfilePath", func(sd *winlsa.LogonSessionData) string { return sd.ProfilePath }},
+	{"homedirectory", "HomeDirectory", func(sd *winlsa.LogonSessionData) string { return sd.HomeDirectory }},
+	{"homedrive", "HomeDirectoryDrive", func(sd *winlsa.LogonSessionData) string { return sd.HomeDirectoryDrive }},
+	{"logofftime", "LogoffTime", func(sd *winlsa.LogonSessionData) string { return formatTime(sd.LogoffTime) }},
+	{"kickofftime", "KickOffTime", func(sd *winlsa.LogonSessionData) string { return formatTime(sd.KickOffTime) }},
+	{"passwordlastset", "PasswordLastSet", func(sd *winlsa.LogonSessionData) string { return formatTime(sd.PasswordLastSet) }},
+	{"passwordcanchange", "PasswordCanChange", func(sd *winlsa.LogonSessionData) string { return formatTime(sd.PasswordCanChange) }},
+	{"passwordmustchange", "PasswordMustChange", func(sd *winlsa.LogonSessionData) string { return formatTime(sd.PasswordMustChange) }},
+	{"passwordexpiresin", "PasswordExpiresIn", func(sd *winlsa.LogonSessionData) string {
+		d, ok := sd.PasswordExpiresIn()
+		if !ok {
+			return "-"
+		}
+		return d.Round(time.Minute).String()
+	}},
+}
+
+const defaultSessionColumns = "luid,user,type,package,session,logontime"
+
+// selectSessionColumns parses a comma separated list of column names.
+func selectSessionColumns(spec string) ([]sessionColumn, error) {
+	var columns []sessionColumn
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, c := range sessionColumns {
+			if c.name == name {
+				columns = append(columns, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, usagef("unknown column %q (available: %s)", name, sessionColumnNames())
+		}
+	}
+	if len(columns) == 0 {
+		return nil, usagef("no columns selected")
+	}
+	return columns, nil
+}
+
+func sessionColumnNames() string {
+	names := make([]string, len(sessionColumns))
+	for idx, c := range sessionColumns {
+		names[idx] = c.name
+	}
+	return strings.Join(names, ",")
+}
+
+// sessionRows returns the header and rows of sessions for columns.
+func sessionRows(sessions []*winlsa.LogonSessionData, columns []sessionColumn) ([]string, [][]string) {
+	header := make([]string, len(columns))
+	for idx, c := range columns {
+		header[idx] = c.header
+	}
+	rows := make([][]string, len(sessions))
+	for i, sd := range sessions {
+		row := make([]string, len(columns))
+		for j, c := range columns {
+			row[j] = c.value(sd)
+		}
+		rows[i] = row
+	}
+	return header, rows
+}
+
+func accountName(domain, user string) string {
+	if domain == "" {
+		return user
+	}
+	return domain + `\` + user
+}
+
+func sidString(sd *winlsa.LogonSessionData) string {
+	if sd.Sid == nil {
+		return ""
+	}
+	return sd.Sid.String()
+}
@@ -0,0 +1,146 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/kerberos"
+)
+
+type checkStatus string
+
+const (
+	checkOK   checkStatus = "ok"
+	checkWarn checkStatus = "warn"
+	checkFail checkStatus = "fail"
+)
+
+// A check is one line of the doctor report. Fix says what to change if
+// the status is not ok.
+type check struct {
+	Name   string
+	Status checkStatus
+	Detail string
+	Fix    string `json:",omitempty"`
+}
+
+// runDoctor exits with status 1 if a check fails, i.e. if the LSA cannot
+// be used at all; warnings only limit what other commands can see.
+func runDoctor(args []string) error {
+	fs := newFlagSet("winlsa doctor", "")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	checks := diagnose()
+	err = out.list(checks, func(w io.Writer) error {
+		for _, c := range checks {
+			fmt.Fprintf(w, "%-6s %s: %s\n", "["+string(c.Status)+"]", c.Name, c.Detail)
+			if c.Fix != "" {
+				fmt.Fprintf(w, "%-6s fix: %s\n", "", c.Fix)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, c := range checks {
+		if c.Status == checkFail {
+			return exitStatus(1)
+		}
+	}
+	return nil
+}
+
+func diagnose() []check {
+	var checks []check
+	add := func(name string, status checkStatus, detail, fix string) {
+		checks = append(checks, check{name, status, detail, fix})
+	}
+
+	features := winlsa.RuntimeCapabilities()
+	if features.LastLogonInfo && features.CloudAP {
+		add("OS build", checkOK, fmt.Sprintf("build %d supports all features", features.OSBuild), "")
+	} else {
+		add("OS build", checkWarn, fmt.Sprintf("build %d lacks some features, see winlsa capabilities", features.OSBuild), "")
+	}
+
+	if _, err := winlsa.GetLogonSessions(); err != nil {
+		add("logon sessions", checkFail, "cannot enumerate the logon sessions: "+err.Error(), "check that the LSA (lsass.exe) is running and that security software does not block LsaEnumerateLogonSessions")
+	} else {
+		add("logon sessions", checkOK, "logon sessions can be enumerated", "")
+	}
+
+	info, err := winlsa.GetCurrentTokenInfo()
+	if err != nil {
+		add("token", checkFail, "cannot read the process token: "+err.Error(), "")
+		return checks
+	}
+	switch {
+	case info.Elevated:
+		add("elevation", checkOK, "running elevated", "")
+	case info.ElevationType == winlsa.ElevationTypeLimited:
+		add("elevation", checkWarn, "running with the filtered token of an administrator; sessions, tickets and tokens of other users are access denied", "start the shell with \"Run as administrator\"")
+	default:
+		add("elevation", checkWarn, "not an administrator; sessions, tickets and tokens of other users are access denied", "run as a member of Administrators")
+	}
+
+	held := map[string]bool{}
+	for _, p := range info.Privileges {
+		held[strings.ToLower(p.Name)] = true
+	}
+	privileges := []struct{ name, needed, fix string }{
+		{winlsa.PrivilegeTcb, "trusted LSA connections and the Kerberos tickets of other sessions", "run as LocalSystem, e.g. from a service or a scheduled task"},
+		{winlsa.PrivilegeDebug, "the tokens and processes of other users", "run elevated as an administrator"},
+		{winlsa.PrivilegeSecurity, "the Security log and the audit policy", "run elevated as an administrator"},
+	}
+	for _, p := range privileges {
+		if held[strings.ToLower(p.name)] {
+			add(p.name, checkOK, "held; needed for "+p.needed, "")
+		} else {
+			add(p.name, checkWarn, "not held; needed for "+p.needed, p.fix)
+		}
+	}
+
+	if conn, err := kerberos.ConnectTrusted("winlsa"); err == nil {
+		conn.Close()
+		add("LSA connection", checkOK, "trusted; all ticket caches are accessible", "")
+	} else if conn, err := kerberos.Connect(); err == nil {
+		conn.Close()
+		add("LSA connection", checkWarn, "untrusted only; ticket caches of other sessions need elevation or a trusted connection", "hold SeTcbPrivilege, see above")
+	} else {
+		add("LSA connection", checkFail, "cannot connect to the Kerberos package: "+err.Error(), "")
+	}
+
+	r, err := winlsa.Capabilities()
+	if err != nil {
+		add("LSA protection", checkWarn, "cannot inspect the LSA configuration: "+err.Error(), "")
+		return checks
+	}
+	if r.LSAProtection {
+		add("LSA protection", checkOK, "LSASS runs as a protected process; it cannot be opened for reading or terminated", "")
+	} else if r.LSAProtectionConfigured {
+		add("LSA protection", checkOK, "RunAsPPL is set and takes effect after a reboot", "")
+	} else {
+		add("LSA protection", checkOK, "LSASS is not protected", "")
+	}
+	if r.CredentialGuard {
+		add("Credential Guard", checkOK, "running; retrieved TGTs carry no session keys", "")
+	} else {
+		add("Credential Guard", checkOK, "not running", "")
+	}
+	if !r.CredentialGuard && !r.AllowTgtSessionKey {
+		add("AllowTgtSessionKey", checkWarn, "not set; retrieved TGTs have zeroed session keys", `set HKLM\SYSTEM\CurrentControlSet\Control\Lsa\Kerberos\Parameters\AllowTgtSessionKey to 1 if exported TGTs must be usable`)
+	}
+	if r.WDigestLoaded && r.WDigestUseLogonCredential {
+		add("WDigest", checkWarn, "WDigest caches plaintext passwords of interactive logons", `set HKLM\SYSTEM\CurrentControlSet\Control\SecurityProviders\WDigest\UseLogonCredential to 0`)
+	}
+	return checks
+}
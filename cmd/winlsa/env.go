@@ -0,0 +1,36 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+func runEnv(args []string) error {
+	fs := newFlagSet("winlsa env", "<luid>")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	luid, err := winlsa.ParseLUID(fs.Arg(0))
+	if err != nil {
+		return &usageError{msg: err.Error()}
+	}
+	ensurePrivileges(winlsa.PrivilegeDebug)
+	env, err := winlsa.GetSessionEnvironment(luid)
+	if err != nil {
+		return fmt.Errorf("GetSessionEnvironment: %v", err)
+	}
+	return out.object(env, func(w io.Writer) error {
+		fmt.Fprintf(w, "# profile %s\n", env.ProfileDirectory)
+		for _, kv := range env.Env {
+			fmt.Fprintln(w, kv)
+		}
+		return nil
+	})
+}
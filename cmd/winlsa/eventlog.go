@@ -0,0 +1,100 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/windows/svc/eventlog"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+// defaultEventSource is the event source registered by "winlsa eventlog
+// install".
+const defaultEventSource = "winlsa"
+
+// Event IDs written by the event log sink.
+const (
+	eventIDLogon               = 1
+	eventIDLogoff              = 2
+	eventIDExplicitCredentials = 3
+)
+
+var eventlogCommands []*command
+
+func init() {
+	eventlogCommands = []*command{
+		{name: "install", summary: "register the event source in the Application log", run: runEventlogInstall},
+		{name: "remove", summary: "unregister the event source", run: runEventlogRemove},
+	}
+}
+
+func runEventlog(args []string) error {
+	return dispatch("winlsa eventlog", args, eventlogCommands)
+}
+
+func runEventlogInstall(args []string) error {
+	fs := newFlagSet("winlsa eventlog install", "")
+	source := fs.String("source", defaultEventSource, "event source `name`")
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	return eventlog.InstallAsEventCreate(*source, eventlog.Info|eventlog.Warning|eventlog.Error)
+}
+
+func runEventlogRemove(args []string) error {
+	fs := newFlagSet("winlsa eventlog remove", "")
+	source := fs.String("source", defaultEventSource, "event source `name`")
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	return eventlog.Remove(*source)
+}
+
+// eventlogSink writes session events to the Windows event log. Each entry
+// holds a summary line followed by the event as JSON, so forwarded events
+// can be parsed without knowing the message layout.
+type eventlogSink struct {
+	log *eventlog.Log
+}
+
+func newEventlogSink(source string) (*eventlogSink, error) {
+	log, err := eventlog.Open(source)
+	if err != nil {
+		return nil, fmt.Errorf("opening event source %q: %v", source, err)
+	}
+	return &eventlogSink{log: log}, nil
+}
+
+func (s *eventlogSink) send(ev winlsa.SessionEvent) error {
+	var msg strings.Builder
+	err := writeSessionEvent(&msg, ev)
+	if err != nil {
+		return err
+	}
+	msg.WriteString("\n")
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	msg.Write(data)
+
+	eid := uint32(eventIDLogon)
+	switch ev.Type {
+	case winlsa.SessionLogoff:
+		eid = eventIDLogoff
+	case winlsa.SessionExplicitCredentials:
+		eid = eventIDExplicitCredentials
+	}
+	return s.log.Info(eid, msg.String())
+}
+
+func (s *eventlogSink) Close() error {
+	return s.log.Close()
+}
@@ -0,0 +1,105 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/metrics"
+)
+
+func runExport(args []string) error {
+	fs := newFlagSet("winlsa export", "")
+	listen := fs.String("listen", ":9752", "serve metrics on `address`")
+	path := fs.String("path", "/metrics", "serve metrics under URL `path`")
+	interval := fs.Duration("interval", time.Second, "poll the session list for the event counters every `duration`")
+	expiry := fs.Duration("expiry-threshold", time.Hour, "count Kerberos tickets expiring within `duration`")
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	w, err := winlsa.Watch(winlsa.WatchOptions{Interval: *interval})
+	if err != nil {
+		return fmt.Errorf("Watch: %v", err)
+	}
+	defer w.Close()
+	events := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "winlsa_session_events_total",
+		Help: "Number of logon and logoff events observed since startup.",
+	}, []string{"type"})
+	go func() {
+		for ev := range w.Events() {
+			events.WithLabelValues(ev.Type.String()).Inc()
+		}
+	}()
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.NewCollector(metrics.Options{PerUser: true}))
+	reg.MustRegister(events)
+	reg.MustRegister(newTicketCollector(*expiry))
+	mux := http.NewServeMux()
+	mux.Handle(*path, promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	fmt.Fprintf(os.Stderr, "winlsa: serving metrics on %s%s\n", *listen, *path)
+	return http.ListenAndServe(*listen, mux)
+}
+
+// ticketCollector counts the cached Kerberos tickets of all sessions that
+// have not expired yet but expire within a threshold. Sessions whose cache cannot be read, e.g.
+// without SeTcbPrivilege, are skipped.
+type ticketCollector struct {
+	expiry   time.Duration
+	expiring *prometheus.Desc
+}
+
+func newTicketCollector(expiry time.Duration) *ticketCollector {
+	return &ticketCollector{
+		expiry: expiry,
+		expiring: prometheus.NewDesc("winlsa_kerberos_tickets_expiring",
+			fmt.Sprintf("Number of cached Kerberos tickets expiring within %v.", expiry), []string{"kind"}, nil),
+	}
+}
+
+func (c *ticketCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.expiring
+}
+
+func (c *ticketCollector) Collect(ch chan<- prometheus.Metric) {
+	luids, err := winlsa.GetLogonSessions()
+	if err != nil && !winlsa.IsFreeBufferError(err) {
+		return
+	}
+	conn, err := connectKerberos(true)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	now := time.Now()
+	var tgts, service int
+	for _, luid := range luids {
+		tickets, err := conn.QueryTicketCache(luid)
+		if err != nil && !winlsa.IsFreeBufferError(err) {
+			continue
+		}
+		for _, t := range tickets {
+			if t.EndTime.Before(now) || t.EndTime.Sub(now) > c.expiry {
+				continue
+			}
+			if t.IsTGT() {
+				tgts++
+			} else {
+				service++
+			}
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(c.expiring, prometheus.GaugeValue, float64(tgts), "tgt")
+	ch <- prometheus.MustNewConstMetric(c.expiring, prometheus.GaugeValue, float64(service), "service")
+}
@@ -0,0 +1,94 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+// addSessionFilterFlags registers the session filter flags on fs. The
+// returned filter is populated when fs is parsed.
+func addSessionFilterFlags(fs *flag.FlagSet) *winlsa.SessionFilter {
+	f := &winlsa.SessionFilter{}
+	fs.Var(logonTypesFlag{&f.LogonTypes}, "type", "only show sessions of the comma separated logon `types`")
+	fs.Var(userFlag{f}, "user", "only show sessions of `user` (name or DOMAIN\\name)")
+	fs.StringVar(&f.LogonDomain, "domain", "", "only show sessions of logon `domain`")
+	fs.StringVar(&f.AuthenticationPackage, "package", "", "only show sessions authenticated by `package`")
+	fs.Var(sinceFlag{&f.Since}, "since", "only show sessions logged on after `time` (RFC 3339) or within a duration such as 2h")
+	fs.BoolVar(&f.Anonymous, "anonymous", false, "only show ANONYMOUS LOGON sessions, including null sessions")
+	return f
+}
+
+type logonTypesFlag struct {
+	types *[]winlsa.LogonType
+}
+
+func (f logonTypesFlag) String() string {
+	if f.types == nil {
+		return ""
+	}
+	names := make([]string, len(*f.types))
+	for idx, lt := range *f.types {
+		names[idx] = lt.String()
+	}
+	return strings.Join(names, ",")
+}
+func (f logonTypesFlag) Set(s string) error {
+	for _, name := range strings.Split(s, ",") {
+		var lt winlsa.LogonType
+		err := lt.UnmarshalText([]byte(strings.TrimSpace(name)))
+		if err != nil {
+			return err
+		}
+		*f.types = append(*f.types, lt)
+	}
+	return nil
+}
+
+type userFlag struct {
+	filter *winlsa.SessionFilter
+}
+
+func (f userFlag) String() string {
+	if f.filter == nil {
+		return ""
+	}
+	return f.filter.UserName
+}
+func (f userFlag) Set(s string) error {
+	if idx := strings.IndexByte(s, '\\'); idx >= 0 {
+		f.filter.LogonDomain = s[:idx]
+		s = s[idx+1:]
+	}
+	f.filter.UserName = s
+	return nil
+}
+
+type sinceFlag struct {
+	since *time.Time
+}
+
+func (f sinceFlag) String() string {
+	if f.since == nil || f.since.IsZero() {
+		return ""
+	}
+	return f.since.Format(time.RFC3339)
+}
+func (f sinceFlag) Set(s string) error {
+	if d, err := time.ParseDuration(s); err == nil {
+		*f.since = time.Now().Add(-d)
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return fmt.Errorf("expected an RFC 3339 time or a duration")
+	}
+	*f.since = t
+	return nil
+}
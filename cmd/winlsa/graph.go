@@ -0,0 +1,42 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+func runGraph(args []string) error {
+	fs := newFlagSet("winlsa graph", "")
+	dot := fs.Bool("dot", false, "write the graph in the Graphviz DOT language")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	g, err := winlsa.SessionGraph()
+	if _, partial := err.(winlsa.SessionErrors); err != nil && !partial {
+		return fmt.Errorf("SessionGraph: %v", err)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, "winlsa:", err)
+	}
+	if *dot {
+		return g.WriteDOT(os.Stdout)
+	}
+	return out.object(g, func(w io.Writer) error {
+		labels := make(map[string]string, len(g.Nodes))
+		for _, n := range g.Nodes {
+			labels[n.ID] = n.Label
+		}
+		rows := make([][]string, len(g.Edges))
+		for idx, e := range g.Edges {
+			rows[idx] = []string{labels[e.From], string(e.Kind), labels[e.To]}
+		}
+		return writeTable(w, []string{"from", "edge", "to"}, rows)
+	})
+}
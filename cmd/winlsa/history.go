@@ -0,0 +1,112 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cobraqxx/winlsa/history"
+)
+
+var historyCommands []*command
+
+func init() {
+	historyCommands = []*command{
+		{name: "user", args: "<[DOMAIN\\]user>", summary: "list the recorded events of an account", run: runHistoryUser},
+		{name: "sessions", summary: "list the recorded sessions of a time range", run: runHistorySessions},
+	}
+}
+
+func runHistory(args []string) error {
+	return dispatch("winlsa history", args, historyCommands)
+}
+
+func openHistory(path string) (*history.Store, error) {
+	store, err := history.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening history %s: %v", path, err)
+	}
+	return store, nil
+}
+
+func runHistoryUser(args []string) error {
+	fs := newFlagSet("winlsa history user", "<[DOMAIN\\]user>")
+	out := addOutputFlag(fs)
+	db := fs.String("db", "", "read the history database `file` written by watch -history")
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if *db == "" {
+		return usagef("-db is required")
+	}
+	domain, user := "", fs.Arg(0)
+	if idx := strings.IndexByte(user, '\\'); idx >= 0 {
+		domain, user = user[:idx], user[idx+1:]
+	}
+
+	store, err := openHistory(*db)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	events, err := store.HistoryForUser(domain, user)
+	if err != nil {
+		return err
+	}
+	return out.list(events, func(w io.Writer) error {
+		for _, ev := range events {
+			err := writeSessionEvent(w, ev)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func runHistorySessions(args []string) error {
+	fs := newFlagSet("winlsa history sessions", "")
+	out := addOutputFlag(fs)
+	db := fs.String("db", "", "read the history database `file` written by watch -history")
+	var since, until time.Time
+	fs.Var(sinceFlag{&since}, "since", "only show sessions that existed after `time` (RFC 3339) or within a duration such as 2h")
+	fs.Var(sinceFlag{&until}, "until", "only show sessions that existed before `time` (RFC 3339) or a duration ago")
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	if *db == "" {
+		return usagef("-db is required")
+	}
+	if until.IsZero() {
+		until = time.Now()
+	}
+
+	store, err := openHistory(*db)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	sessions, err := store.SessionsBetween(since, until)
+	if err != nil {
+		return err
+	}
+	return out.list(sessions, func(w io.Writer) error {
+		header := []string{"logon id", "user", "type", "start", "end"}
+		rows := make([][]string, len(sessions))
+		for idx, s := range sessions {
+			user, logonType := "-", "-"
+			if s.Data != nil {
+				user = accountName(s.Data.LogonDomain, s.Data.UserName)
+				logonType = s.Data.LogonType.String()
+			}
+			rows[idx] = []string{s.LogonId.String(), user, logonType, formatTime(s.Start), formatTime(s.End)}
+		}
+		return writeTable(w, header, rows)
+	})
+}
@@ -0,0 +1,544 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/kerberos"
+)
+
+var kerberosCommands []*command
+
+func init() {
+	kerberosCommands = []*command{
+		{name: "tickets", summary: "list cached Kerberos tickets", run: runKerberosTickets},
+		{name: "purge", summary: "remove tickets from a ticket cache", run: runKerberosPurge},
+		{name: "renew", args: "[target]...", summary: "renew tickets, by default the cached TGTs", run: runKerberosRenew},
+		{name: "get", args: "<target>", summary: "retrieve a ticket from the cache or the KDC", run: runKerberosGet},
+		{name: "expiry", summary: "report tickets as they near their expiry", run: runKerberosExpiry},
+		{name: "export", args: "[target]...", summary: "write tickets, by default all cached ones, to an MIT krb5 ccache file", run: runKerberosExport},
+		{name: "keytab", args: "<user@domain>", summary: "write a keytab for an account, reading its password from $WINLSA_KEYTAB_PASSWORD or standard input", run: runKerberosKeyTab},
+		{name: "import", args: "<file>", summary: "submit the tickets of an MIT krb5 ccache or KRB-CRED (.kirbi) file to a ticket cache", run: runKerberosImport},
+	}
+}
+
+func runKerberos(args []string) error {
+	return dispatch("winlsa kerberos", args, kerberosCommands)
+}
+
+// connectKerberos connects to the Kerberos package. Accessing other logon
+// sessions needs a trusted connection, which requires SeTcbPrivilege; when
+// that fails an untrusted connection is used, which still works for
+// elevated callers.
+func connectKerberos(otherSessions bool) (*kerberos.Conn, error) {
+	if otherSessions {
+		conn, err := kerberos.ConnectTrusted("winlsa")
+		if err == nil {
+			return conn, nil
+		}
+	}
+	conn, err := kerberos.Connect()
+	if err != nil {
+		return nil, fmt.Errorf("LsaConnectUntrusted: %v", err)
+	}
+	return conn, nil
+}
+
+// parseSessionFlag parses the value of a -luid flag; the empty string
+// selects the caller's logon session.
+func parseSessionFlag(s string) (winlsa.LUID, error) {
+	if s == "" {
+		return winlsa.LUID{}, nil
+	}
+	luid, err := winlsa.ParseLUID(s)
+	if err != nil {
+		return luid, &usageError{msg: err.Error()}
+	}
+	return luid, nil
+}
+
+// sessionTickets is the ticket cache of one logon session.
+type sessionTickets struct {
+	LogonId winlsa.LUID
+	User    string `json:",omitempty"`
+	Tickets []kerberos.TicketCacheInfo
+}
+
+func runKerberosTickets(args []string) error {
+	fs := newFlagSet("winlsa kerberos tickets", "")
+	out := addOutputFlag(fs)
+	luidFlag := fs.String("luid", "", "list the tickets of logon session `luid` instead of the current one")
+	all := fs.Bool("all-sessions", false, "list the tickets of every logon session")
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	if *luidFlag != "" && *all {
+		return usagef("-luid and -all-sessions are mutually exclusive")
+	}
+
+	var luids []winlsa.LUID
+	switch {
+	case *all:
+		luids, err = winlsa.GetLogonSessions()
+		if err != nil {
+			return fmt.Errorf("GetLogonSessions: %v", err)
+		}
+	default:
+		luid, err := parseSessionFlag(*luidFlag)
+		if err != nil {
+			return err
+		}
+		luids = []winlsa.LUID{luid}
+	}
+
+	conn, err := connectKerberos(*all || *luidFlag != "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	var caches []sessionTickets
+	for _, luid := range luids {
+		tickets, err := conn.QueryTicketCache(luid)
+		if err != nil {
+			if !*all {
+				return fmt.Errorf("QueryTicketCache: %v", err)
+			}
+			fmt.Fprintf(os.Stderr, "winlsa: session %v: %v\n", luid, err)
+			continue
+		}
+		cache := sessionTickets{LogonId: luid, Tickets: tickets}
+		if sd, err := winlsa.GetLogonSessionData(&luid); err == nil {
+			cache.LogonId = sd.LogonId
+			cache.User = accountName(sd.LogonDomain, sd.UserName)
+		}
+		if *all && len(tickets) == 0 {
+			continue
+		}
+		caches = append(caches, cache)
+	}
+
+	if !*all && len(caches) == 1 {
+		return out.object(caches[0], func(w io.Writer) error {
+			return writeTicketCache(w, caches[0])
+		})
+	}
+	return out.list(caches, func(w io.Writer) error {
+		for _, cache := range caches {
+			err := writeTicketCache(w, cache)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func runKerberosPurge(args []string) error {
+	fs := newFlagSet("winlsa kerberos purge", "")
+	luidFlag := fs.String("luid", "", "purge the ticket cache of logon session `luid` instead of the current one")
+	server := fs.String("server", "", "only purge the ticket for service `name`, e.g. cifs/fs01.contoso.com, or the tickets matching a pattern such as *.contoso.com")
+	realm := fs.String("realm", "", "`realm` of the -server ticket, or a pattern")
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	pattern := strings.ContainsAny(*server+*realm, "*?")
+	if !pattern && (*server == "") != (*realm == "") {
+		return usagef("-server and -realm must be given together unless one is a pattern")
+	}
+	luid, err := parseSessionFlag(*luidFlag)
+	if err != nil {
+		return err
+	}
+
+	conn, err := connectKerberos(*luidFlag != "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if pattern {
+		purged, err := conn.PurgeTickets(luid, *server, *realm)
+		if _, partial := err.(kerberos.TicketErrors); err != nil && !partial {
+			return fmt.Errorf("QueryTicketCache: %v", err)
+		}
+		for _, t := range purged {
+			fmt.Printf("purged %s @ %s\n", t.ServerName, t.ServerRealm)
+		}
+		return err
+	}
+	err = conn.PurgeTicketCache(luid, *server, *realm)
+	if err != nil {
+		return fmt.Errorf("PurgeTicketCache: %v", err)
+	}
+	return nil
+}
+
+func runKerberosRenew(args []string) error {
+	fs := newFlagSet("winlsa kerberos renew", "[target]...")
+	out := addOutputFlag(fs)
+	luidFlag := fs.String("luid", "", "renew the tickets of logon session `luid` instead of the current one")
+	all := fs.Bool("all", false, "renew every renewable ticket, not only the TGTs")
+	err := parseFlags(fs, args, 0, -1)
+	if err != nil {
+		return err
+	}
+	if *all && fs.NArg() > 0 {
+		return usagef("-all cannot be combined with targets")
+	}
+	luid, err := parseSessionFlag(*luidFlag)
+	if err != nil {
+		return err
+	}
+
+	conn, err := connectKerberos(*luidFlag != "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	var renewed []*kerberos.Ticket
+	targets := fs.Args()
+	if *all {
+		renewed, err = conn.RenewTickets(luid)
+		if _, partial := err.(kerberos.TicketErrors); partial {
+			fmt.Fprintln(os.Stderr, "winlsa:", err)
+		} else if err != nil {
+			return fmt.Errorf("QueryTicketCache: %v", err)
+		}
+	} else if len(targets) == 0 {
+		tickets, err := conn.QueryTicketCache(luid)
+		if err != nil {
+			return fmt.Errorf("QueryTicketCache: %v", err)
+		}
+		for _, t := range tickets {
+			if t.IsTGT() && t.TicketFlags&kerberos.TicketFlagRenewable != 0 {
+				targets = append(targets, t.ServerName)
+			}
+		}
+		if len(targets) == 0 {
+			return errors.New("no renewable TGT in the ticket cache")
+		}
+	}
+
+	for _, target := range targets {
+		ticket, err := conn.RenewTicket(luid, target)
+		if err != nil {
+			return fmt.Errorf("RenewTicket %s: %v", target, err)
+		}
+		renewed = append(renewed, ticket)
+	}
+	return out.list(renewed, func(w io.Writer) error {
+		for _, t := range renewed {
+			fmt.Fprintf(w, "%s @ %s: valid until %s, renewable until %s\n",
+				t.ServiceName, t.TargetDomainName, formatTime(t.EndTime), formatTime(t.RenewUntil))
+		}
+		return nil
+	})
+}
+
+func runKerberosExport(args []string) error {
+	fs := newFlagSet("winlsa kerberos export", "[target]...")
+	luidFlag := fs.String("luid", "", "export the tickets of logon session `luid` instead of the current one")
+	file := fs.String("out", "", "write the ccache to `file`")
+	err := parseFlags(fs, args, 0, -1)
+	if err != nil {
+		return err
+	}
+	if *file == "" {
+		return usagef("-out is required")
+	}
+	luid, err := parseSessionFlag(*luidFlag)
+	if err != nil {
+		return err
+	}
+
+	conn, err := connectKerberos(*luidFlag != "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	targets := fs.Args()
+	if len(targets) == 0 {
+		cached, err := conn.QueryTicketCache(luid)
+		if err != nil {
+			return fmt.Errorf("QueryTicketCache: %v", err)
+		}
+		for _, t := range cached {
+			targets = append(targets, t.ServerName+"@"+t.ServerRealm)
+		}
+		if len(targets) == 0 {
+			return errors.New("the ticket cache is empty")
+		}
+	}
+
+	var tickets []*kerberos.Ticket
+	for _, target := range targets {
+		ticket, err := conn.RetrieveTicket(luid, target)
+		if err != nil {
+			return fmt.Errorf("RetrieveTicket %s: %v", target, err)
+		}
+		if status := ticket.SessionKeyStatus(); status != kerberos.SessionKeyPresent {
+			fmt.Fprintf(os.Stderr, "winlsa: session key of %s %v; the exported ticket is unusable\n", target, status)
+		}
+		tickets = append(tickets, ticket)
+	}
+	f, err := os.Create(*file)
+	if err != nil {
+		return err
+	}
+	err = kerberos.WriteCCache(f, tickets)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func runKerberosImport(args []string) error {
+	fs := newFlagSet("winlsa kerberos import", "<file>")
+	luidFlag := fs.String("luid", "", "submit the tickets to logon session `luid` instead of the current one")
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	luid, err := parseSessionFlag(*luidFlag)
+	if err != nil {
+		return err
+	}
+	b, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	var tickets []*kerberos.Ticket
+	// A KRB-CRED message, e.g. a .kirbi file, starts with its application
+	// tag, a ccache with its version.
+	if len(b) > 0 && b[0] == 0x76 {
+		tickets, err = kerberos.UnmarshalKRBCred(b)
+	} else {
+		tickets, err = kerberos.ReadCCache(bytes.NewReader(b))
+	}
+	if err != nil {
+		return err
+	}
+	if len(tickets) == 0 {
+		return errors.New("the file holds no tickets")
+	}
+	conn, err := connectKerberos(*luidFlag != "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	// KerbSubmitTicketMessage is only documented for one ticket per message.
+	for _, t := range tickets {
+		krbCred, err := kerberos.MarshalKRBCred(t)
+		if err != nil {
+			return err
+		}
+		err = conn.SubmitTicket(luid, krbCred)
+		if err != nil {
+			return fmt.Errorf("SubmitTicket %s: %v", t.ServiceName, err)
+		}
+	}
+	return nil
+}
+
+func runKerberosGet(args []string) error {
+	fs := newFlagSet("winlsa kerberos get", "<target>")
+	out := addOutputFlag(fs)
+	luidFlag := fs.String("luid", "", "retrieve the ticket in logon session `luid` instead of the current one")
+	cacheOnly := fs.Bool("cache-only", false, "fail if the ticket is not cached")
+	noCache := fs.Bool("no-cache", false, "always request a new ticket from the KDC")
+	store := fs.Bool("store", false, "store a ticket requested from the KDC in the cache")
+	forwardable := fs.Bool("forwardable", false, "request a forwardable ticket")
+	maxLifetime := fs.Bool("max-lifetime", false, "request the longest lifetime the KDC allows")
+	etype := fs.String("etype", "", "require session key encryption type `etype`, by name or number")
+	requireKey := fs.Bool("require-session-key", false, "fail if the LSA withholds the session key")
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if *cacheOnly && (*noCache || *store) {
+		return usagef("-cache-only cannot be combined with -no-cache or -store")
+	}
+	luid, err := parseSessionFlag(*luidFlag)
+	if err != nil {
+		return err
+	}
+	opts := kerberos.RetrieveTicketOpts{RequireSessionKey: *requireKey}
+	if *etype != "" {
+		n, err := strconv.ParseInt(*etype, 10, 32)
+		if err == nil {
+			opts.EncryptionType = kerberos.EncryptionType(n)
+		} else if err := opts.EncryptionType.UnmarshalText([]byte(*etype)); err != nil {
+			return &usageError{msg: err.Error()}
+		}
+	}
+	for _, o := range []struct {
+		set bool
+		opt kerberos.RetrieveCacheOptions
+	}{
+		{*cacheOnly, kerberos.RetrieveUseCacheOnly},
+		{*noCache, kerberos.RetrieveDontUseCache},
+		{*store, kerberos.RetrieveCacheTicket},
+		{*maxLifetime, kerberos.RetrieveMaxLifetime},
+	} {
+		if o.set {
+			opts.CacheOptions |= o.opt
+		}
+	}
+	if *forwardable {
+		opts.KDCOptions |= kerberos.TicketFlagForwardable
+	}
+
+	conn, err := connectKerberos(*luidFlag != "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	t, err := conn.RetrieveTicketWithOpts(luid, fs.Arg(0), opts)
+	if err != nil {
+		return fmt.Errorf("RetrieveTicket %s: %v", fs.Arg(0), err)
+	}
+	return out.object(t, func(w io.Writer) error {
+		fmt.Fprintf(w, "Client: %s @ %s\n", t.ClientName, t.AltTargetDomainName)
+		fmt.Fprintf(w, "Server: %s @ %s\n", t.ServiceName, t.DomainName)
+		fmt.Fprintf(w, "Ticket Flags 0x%x -> %v\n", uint32(t.TicketFlags), t.TicketFlags)
+		fmt.Fprintf(w, "Start Time: %s\n", formatTime(t.StartTime))
+		fmt.Fprintf(w, "End Time:   %s\n", formatTime(t.EndTime))
+		fmt.Fprintf(w, "Renew Time: %s\n", formatTime(t.RenewUntil))
+		fmt.Fprintf(w, "Session Key Type: %v\n", t.SessionKeyType)
+		_, err := fmt.Fprintf(w, "Session Key: %v\n", t.SessionKeyStatus())
+		return err
+	})
+}
+
+func runKerberosExpiry(args []string) error {
+	fs := newFlagSet("winlsa kerberos expiry", "")
+	out := addOutputFlag(fs)
+	luidFlag := fs.String("luid", "", "watch the tickets of logon session `luid` instead of the current one")
+	threshold := fs.Duration("threshold", 10*time.Minute, "report tickets expiring within `duration`")
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	luid, err := parseSessionFlag(*luidFlag)
+	if err != nil {
+		return err
+	}
+	conn, err := connectKerberos(*luidFlag != "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	w, err := conn.WatchExpiryContext(ctx, luid, *threshold)
+	if err != nil {
+		return fmt.Errorf("WatchExpiry: %v", err)
+	}
+	for ev := range w.Events() {
+		err := out.object(ev, func(w io.Writer) error {
+			renew := "not renewable"
+			if ev.Renewable {
+				renew = "renewable until " + formatTime(ev.Ticket.RenewTime)
+			}
+			_, err := fmt.Fprintf(w, "%s %s @ %s: %s, %s\n", ev.Time.Format(time.RFC3339),
+				ev.Ticket.ServerName, ev.Ticket.ServerRealm, formatExpiry(ev.Ticket.EndTime, ev.Time), renew)
+			return err
+		})
+		if err != nil {
+			w.Close()
+			return err
+		}
+	}
+	return nil
+}
+
+func runKerberosKeyTab(args []string) error {
+	fs := newFlagSet("winlsa kerberos keytab", "<user@domain>")
+	file := fs.String("out", "", "write the keytab to `file`")
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if *file == "" {
+		return usagef("-out is required")
+	}
+	idx := strings.LastIndex(fs.Arg(0), "@")
+	if idx <= 0 || idx == len(fs.Arg(0))-1 {
+		return usagef("account %q is not of the form user@domain", fs.Arg(0))
+	}
+	user, domain := fs.Arg(0)[:idx], fs.Arg(0)[idx+1:]
+	password := os.Getenv("WINLSA_KEYTAB_PASSWORD")
+	if password == "" {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("reading the password: %v", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	conn, err := connectKerberos(false)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	keyTab, err := conn.RetrieveKeyTab(user, domain, password)
+	if err != nil {
+		return fmt.Errorf("RetrieveKeyTab: %v", err)
+	}
+	return os.WriteFile(*file, keyTab, 0o600)
+}
+
+// writeTicketCache prints a ticket cache in the layout of klist.exe.
+func writeTicketCache(w io.Writer, cache sessionTickets) error {
+	fmt.Fprintf(w, "Current LogonId is %v", cache.LogonId)
+	if cache.User != "" {
+		fmt.Fprintf(w, " (%s)", cache.User)
+	}
+	fmt.Fprintf(w, "\n\nCached Tickets: (%d)\n", len(cache.Tickets))
+	now := time.Now()
+	for idx, t := range cache.Tickets {
+		fmt.Fprintf(w, "\n#%d>\tClient: %s @ %s\n", idx, t.ClientName, t.ClientRealm)
+		fmt.Fprintf(w, "\tServer: %s @ %s\n", t.ServerName, t.ServerRealm)
+		fmt.Fprintf(w, "\tKerbTicket Encryption Type: %v\n", t.EncryptionType)
+		fmt.Fprintf(w, "\tTicket Flags 0x%x -> %v\n", uint32(t.TicketFlags), t.TicketFlags)
+		fmt.Fprintf(w, "\tStart Time: %s\n", formatTime(t.StartTime))
+		fmt.Fprintf(w, "\tEnd Time:   %s (%s)\n", formatTime(t.EndTime), formatExpiry(t.EndTime, now))
+		fmt.Fprintf(w, "\tRenew Time: %s\n", formatTime(t.RenewTime))
+		fmt.Fprintf(w, "\tSession Key Type: %v\n", t.SessionKeyType)
+		fmt.Fprintf(w, "\tCache Flags: %s\n", cacheKind(t))
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+func formatExpiry(end, now time.Time) string {
+	if end.IsZero() {
+		return "no expiry"
+	}
+	if !end.After(now) {
+		return "expired"
+	}
+	return "expires in " + end.Sub(now).Round(time.Second).String()
+}
+
+func cacheKind(t kerberos.TicketCacheInfo) string {
+	if t.IsTGT() {
+		return "TGT"
+	}
+	return "service ticket"
+}
@@ -0,0 +1,109 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/policy"
+)
+
+// lookupResult is a resolved argument of "winlsa lookup".
+type lookupResult struct {
+	Input  string
+	Sid    string `json:",omitempty"`
+	Name   string `json:",omitempty"`
+	Use    policy.SidNameUse
+	Domain string `json:",omitempty"`
+}
+
+func runLookup(args []string) error {
+	fs := newFlagSet("winlsa lookup", "<sid-or-name>...")
+	system := fs.String("system", "", "resolve on `host` instead of the local system")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 1, -1)
+	if err != nil {
+		return err
+	}
+
+	p, err := openPolicy(*system, policy.AccessLookupNames)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	// Resolve all SIDs and all names with a single call each, then merge
+	// the results back in argument order.
+	results := make([]lookupResult, fs.NArg())
+	var sids []*windows.SID
+	var sidIdx []int
+	var names []string
+	var nameIdx []int
+	for idx, arg := range fs.Args() {
+		results[idx].Input = arg
+		if sid, err := windows.StringToSid(arg); err == nil {
+			sids = append(sids, sid)
+			sidIdx = append(sidIdx, idx)
+			continue
+		}
+		names = append(names, arg)
+		nameIdx = append(nameIdx, idx)
+	}
+	translatedNames, err := p.LookupSids(sids, 0)
+	if err != nil {
+		return fmt.Errorf("LsaLookupSids2: %v", err)
+	}
+	for i, t := range translatedNames {
+		r := &results[sidIdx[i]]
+		r.Sid = t.Sid.String()
+		r.Use = t.Use
+		if t.Use.Mapped() {
+			r.Name = t.AccountName()
+			r.Domain = t.Domain.Name
+		}
+	}
+	translatedSids, err := p.LookupNames(names, 0)
+	if err != nil {
+		return fmt.Errorf("LsaLookupNames2: %v", err)
+	}
+	for i, t := range translatedSids {
+		r := &results[nameIdx[i]]
+		r.Use = t.Use
+		if t.Use.Mapped() {
+			r.Sid = t.Sid.String()
+			r.Name = t.Name
+			r.Domain = t.Domain.Name
+		}
+	}
+
+	var unresolved int
+	for _, r := range results {
+		if !r.Use.Mapped() {
+			unresolved++
+		}
+	}
+	err = out.list(results, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "INPUT\tSID\tNAME\tTYPE\tDOMAIN")
+		for _, r := range results {
+			if !r.Use.Mapped() {
+				fmt.Fprintf(tw, "%s\t\t\tnot mapped\t\n", r.Input)
+				continue
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%v\t%s\n", r.Input, r.Sid, r.Name, r.Use, r.Domain)
+		}
+		return tw.Flush()
+	})
+	if err != nil {
+		return err
+	}
+	if unresolved > 0 {
+		return fmt.Errorf("%d of %d entries could not be resolved", unresolved, fs.NArg())
+	}
+	return nil
+}
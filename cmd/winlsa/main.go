@@ -0,0 +1,166 @@
+//go:build windows
+// +build windows
+
+// Command winlsa exposes the features of the winlsa packages on the command
+// line.
+//
+// Usage:
+//
+//	winlsa <command> [arguments]
+//
+// Run "winlsa help" for the list of commands. winlsa exits with status 0 on
+// success, 1 if a command failed and 2 on invalid usage.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// A command is a (sub)command of the CLI.
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(args []string) error
+}
+
+// A usageError is reported for invalid arguments and results in exit
+// status 2.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, a ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, a...)}
+}
+
+// exitStatus is returned by commands that ran a child process to exit
+// with its status instead of reporting an error.
+type exitStatus int
+
+func (e exitStatus) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
+
+var commands []*command
+
+func init() {
+	commands = []*command{
+		{name: "sessions", summary: "list logon sessions", run: runSessions},
+		{name: "session", args: "<luid>", summary: "show the details of a logon session", run: runSession},
+		{name: "env", args: "<luid>", summary: "show the environment the processes of a logon session start with", run: runEnv},
+		{name: "snapshot", summary: "save the logon sessions as JSON", run: runSnapshot},
+		{name: "diff", args: "<old.json> <new.json>", summary: "compare two snapshots", run: runDiff},
+		{name: "whoami", summary: "show the logon session and token of the caller or of a session", run: runWhoami},
+		{name: "stale", summary: "list disconnected and long idle Terminal Services sessions", run: runStale},
+		{name: "netonly", summary: "report NewCredentials (runas /netonly) sessions and their network credentials", run: runNetonly},
+		{name: "graph", summary: "map users, logon sessions, processes and the sessions they created", run: runGraph},
+		{name: "logoff", args: "<wts-session-id>", summary: "log off a Terminal Services session", run: runLogoff},
+		{name: "disconnect", args: "<wts-session-id>", summary: "disconnect a Terminal Services session", run: runDisconnect},
+		{name: "terminate", args: "<luid>", summary: "terminate the processes of a logon session", run: runTerminate},
+		{name: "start", args: "-luid <luid> -- <command> [arguments]", summary: "start a command as the user of a logon session", run: runStart},
+		{name: "smb", summary: "match network logon sessions to SMB client sessions", run: runSMB},
+		{name: "pku2u", summary: "list PKU2U peer-to-peer logon sessions and their peers", run: runPKU2U},
+		{name: "cloudap", summary: "show the Entra ID join and Primary Refresh Token state of a session", run: runCloudAP},
+		{name: "watch", summary: "stream logon and logoff events", run: runWatch},
+		{name: "history", args: "<command>", summary: "query the session history recorded by watch -history", run: runHistory},
+		{name: "eventlog", args: "<command>", summary: "manage the event source used by watch -eventlog", run: runEventlog},
+		{name: "export", summary: "serve session metrics for Prometheus", run: runExport},
+		{name: "serve", summary: "answer session queries for unprivileged clients on a named pipe", run: runServe},
+		{name: "kerberos", args: "<command>", summary: "inspect Kerberos ticket caches", run: runKerberos},
+		{name: "policy", args: "<command>", summary: "query the LSA policy", run: runPolicy},
+		{name: "trust", args: "<command>", summary: "inspect domain trust relationships", run: runTrust},
+		{name: "rights", args: "<command>", summary: "manage user rights assignments", run: runRights},
+		{name: "secret", args: "<command>", summary: "manage LSA secrets (private data)", run: runSecret},
+		{name: "s4u", args: "<command>", summary: "run commands as other users without their password", run: runS4U},
+		{name: "lookup", args: "<sid-or-name>...", summary: "resolve SIDs and account names", run: runLookup},
+		{name: "capabilities", summary: "report Credential Guard, LSA protection, WDigest and the features they disable", run: runCapabilities},
+		{name: "doctor", summary: "check the privileges and protections behind access denied errors and how to fix them", run: runDoctor},
+		{name: "audit", args: "<command>", summary: "inspect the advanced audit policy", run: runAudit},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	err := dispatch("winlsa", args, commands)
+	if err == nil || err == flag.ErrHelp {
+		return 0
+	}
+	var status exitStatus
+	if errors.As(err, &status) {
+		return int(status)
+	}
+	fmt.Fprintln(os.Stderr, "winlsa:", err)
+	var ue *usageError
+	if errors.As(err, &ue) {
+		return 2
+	}
+	return 1
+}
+
+// dispatch runs the command of cmds named by args[0].
+func dispatch(prog string, args []string, cmds []*command) error {
+	if len(args) == 0 {
+		printUsage(os.Stderr, prog, cmds)
+		return usagef("missing command")
+	}
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		printUsage(os.Stdout, prog, cmds)
+		return nil
+	}
+	for _, cmd := range cmds {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:])
+		}
+	}
+	printUsage(os.Stderr, prog, cmds)
+	return usagef("unknown command %q", args[0])
+}
+
+func printUsage(w io.Writer, prog string, cmds []*command) {
+	fmt.Fprintf(w, "Usage: %s <command> [arguments]\n\nCommands:\n", prog)
+	for _, cmd := range cmds {
+		fmt.Fprintf(w, "  %-30s %s\n", strings.TrimSpace(cmd.name+" "+cmd.args), cmd.summary)
+	}
+}
+
+// newFlagSet returns a flag set for the command prog whose positional
+// arguments are described by args.
+func newFlagSet(prog, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(prog, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s [flags] %s\n", prog, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args into fs and checks that the number of positional
+// arguments is within [min, max]; max < 0 means unbounded.
+func parseFlags(fs *flag.FlagSet, args []string, min, max int) error {
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return err
+	}
+	if err != nil {
+		return &usageError{msg: err.Error()}
+	}
+	if fs.NArg() < min || (max >= 0 && fs.NArg() > max) {
+		fs.Usage()
+		return usagef("%s: wrong number of arguments", fs.Name())
+	}
+	return nil
+}
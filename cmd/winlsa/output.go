@@ -0,0 +1,180 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// output renders command results in the format selected by -output, or
+// with the Go template given by -format.
+type output struct {
+	format  string
+	formats []string
+	tmpl    *template.Template
+	w       io.Writer
+}
+
+func (o *output) String() string {
+	return o.format
+}
+func (o *output) Set(s string) error {
+	for _, f := range o.formats {
+		if s == f {
+			o.format = s
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s", strings.Join(o.formats, ", "))
+}
+
+// addOutputFlag registers the -output flag on fs. The text, json and ndjson
+// formats are always available; commands producing tabular data may offer
+// extra formats such as csv.
+func addOutputFlag(fs *flag.FlagSet, extra ...string) *output {
+	o := &output{
+		format:  "text",
+		formats: append([]string{"text", "json", "ndjson"}, extra...),
+		w:       os.Stdout,
+	}
+	fs.Var(o, "output", "output `format`: "+strings.Join(o.formats, ", "))
+	fs.Var(templateFlag{o}, "format", "render each result with the Go `template`, e.g. '{{.UserName}} {{.LogonType}}'")
+	return o
+}
+
+// templateFuncs are available to -format templates.
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// templateFlag is the -format flag of an output.
+type templateFlag struct {
+	o *output
+}
+
+func (f templateFlag) String() string {
+	if f.o == nil || f.o.tmpl == nil {
+		return ""
+	}
+	return f.o.tmpl.Root.String()
+}
+func (f templateFlag) Set(s string) error {
+	tmpl, err := template.New("format").Funcs(templateFuncs).Parse(s)
+	if err != nil {
+		return err
+	}
+	f.o.tmpl = tmpl
+	return nil
+}
+
+func (o *output) execute(v interface{}) error {
+	err := o.tmpl.Execute(o.w, v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(o.w)
+	return err
+}
+
+// tabular reports whether the format is csv or tsv.
+func (o *output) tabular() bool {
+	return o.tmpl == nil && (o.format == "csv" || o.format == "tsv")
+}
+
+// csv writes header and rows as CSV, or as TSV for the tsv format.
+func (o *output) csv(header []string, rows [][]string) error {
+	cw := csv.NewWriter(o.w)
+	if o.format == "tsv" {
+		cw.Comma = '\t'
+	}
+	err := cw.Write(header)
+	if err != nil {
+		return err
+	}
+	err = cw.WriteAll(rows)
+	if err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// list writes the elements of the slice items, executing the -format
+// template once per element. For the text format, text is called instead.
+func (o *output) list(items interface{}, text func(w io.Writer) error) error {
+	v := reflect.ValueOf(items)
+	if o.tmpl != nil {
+		for idx := 0; idx < v.Len(); idx++ {
+			err := o.execute(v.Index(idx).Interface())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	switch o.format {
+	case "json":
+		if v.Len() == 0 {
+			// Encode empty results as [] rather than null.
+			items = []struct{}{}
+		}
+		return o.encodeJSON(items, "  ")
+	case "ndjson":
+		for idx := 0; idx < v.Len(); idx++ {
+			err := o.encodeJSON(v.Index(idx).Interface(), "")
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return text(o.w)
+	}
+}
+
+// object writes the single value v. For the text format, text is called
+// instead.
+func (o *output) object(v interface{}, text func(w io.Writer) error) error {
+	if o.tmpl != nil {
+		return o.execute(v)
+	}
+	switch o.format {
+	case "json":
+		return o.encodeJSON(v, "  ")
+	case "ndjson":
+		return o.encodeJSON(v, "")
+	default:
+		return text(o.w)
+	}
+}
+
+func (o *output) encodeJSON(v interface{}, indent string) error {
+	enc := json.NewEncoder(o.w)
+	enc.SetIndent("", indent)
+	return enc.Encode(v)
+}
+
+// writeTable writes header and rows as aligned columns.
+func writeTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,64 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+// pku2uSession is a PKU2U logon session and its peer.
+type pku2uSession struct {
+	LogonId   winlsa.LUID
+	LogonType winlsa.LogonType
+	*winlsa.PKU2UInfo
+	// PeerError is why the peer device is unknown, if it is.
+	PeerError string `json:",omitempty"`
+}
+
+func runPKU2U(args []string) error {
+	fs := newFlagSet("winlsa pku2u", "")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	found, err := winlsa.FindLogonSessions(winlsa.SessionFilter{AuthenticationPackage: winlsa.AuthenticationPackagePKU2U})
+	if _, partial := err.(winlsa.SessionErrors); err != nil && !partial {
+		return fmt.Errorf("FindLogonSessions: %v", err)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, "winlsa:", err)
+	}
+	var sessions []pku2uSession
+	for _, sd := range found {
+		info, err := winlsa.GetPKU2UInfo(sd)
+		if info == nil {
+			continue
+		}
+		s := pku2uSession{LogonId: sd.LogonId, LogonType: sd.LogonType, PKU2UInfo: info}
+		if err != nil {
+			s.PeerError = err.Error()
+		}
+		sessions = append(sessions, s)
+	}
+	return out.list(sessions, func(w io.Writer) error {
+		rows := make([][]string, len(sessions))
+		for idx, s := range sessions {
+			address, workstation := "-", "-"
+			if s.Peer != nil {
+				if s.Peer.Address != "" {
+					address = s.Peer.Address
+				}
+				if s.Peer.Workstation != "" {
+					workstation = s.Peer.Workstation
+				}
+			}
+			rows[idx] = []string{s.LogonId.String(), s.LogonType.String(), s.PeerIdentity, workstation, address}
+		}
+		return writeTable(w, []string{"luid", "type", "peer identity", "workstation", "address"}, rows)
+	})
+}
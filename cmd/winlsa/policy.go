@@ -0,0 +1,194 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/cobraqxx/winlsa/policy"
+)
+
+var policyCommands []*command
+
+func init() {
+	policyCommands = []*command{
+		{name: "info", summary: "show the domain membership and audit settings", run: runPolicyInfo},
+		{name: "kerberos", summary: "show the domain Kerberos ticket policy", run: runPolicyKerberos},
+		{name: "forest-trust", args: "<trusted-domain>", summary: "show the forest trust information of a trust", run: runPolicyForestTrust},
+		{name: "caps", summary: "list the applied Central Access Policies", run: runPolicyCAPs},
+	}
+}
+
+func runPolicy(args []string) error {
+	return dispatch("winlsa policy", args, policyCommands)
+}
+
+// openPolicy opens the policy object of the system named by the -system
+// flag, or of the local system.
+func openPolicy(system string, access policy.Access) (*policy.Policy, error) {
+	p, err := policy.Open(system, access)
+	if err != nil {
+		return nil, fmt.Errorf("LsaOpenPolicy: %v", err)
+	}
+	return p, nil
+}
+
+func runPolicyKerberos(args []string) error {
+	fs := newFlagSet("winlsa policy kerberos", "")
+	system := fs.String("system", "", "query the policy of `host` instead of the local system")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	p, err := openPolicy(*system, policy.AccessViewLocalInformation)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	info, err := p.QueryKerberosTicketInfo()
+	if err != nil {
+		return fmt.Errorf("LsaQueryDomainInformationPolicy: %v", err)
+	}
+	return out.object(info, func(w io.Writer) error {
+		fmt.Fprintf(w, "ValidateClient:      %v\n", info.ValidateClient())
+		fmt.Fprintf(w, "MaxServiceTicketAge: %v\n", info.MaxServiceTicketAge)
+		fmt.Fprintf(w, "MaxTicketAge:        %v\n", info.MaxTicketAge)
+		fmt.Fprintf(w, "MaxRenewAge:         %v\n", info.MaxRenewAge)
+		fmt.Fprintf(w, "MaxClockSkew:        %v\n", info.MaxClockSkew)
+		return nil
+	})
+}
+
+func runPolicyForestTrust(args []string) error {
+	fs := newFlagSet("winlsa policy forest-trust", "<trusted-domain>")
+	system := fs.String("system", "", "query the domain controller `host` instead of the local system")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	p, err := openPolicy(*system, policy.AccessViewLocalInformation)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	fti, err := p.QueryForestTrustInformation(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("LsaQueryForestTrustInformation: %v", err)
+	}
+	return out.list(fti.Records, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "TYPE\tNAME\tNETBIOS\tSID\tFLAGS")
+		for _, r := range fti.Records {
+			switch r.Type {
+			case policy.ForestTrustDomainInfo:
+				fmt.Fprintf(tw, "%v\t%s\t%s\t%v\t0x%x\n", r.Type, r.DnsName, r.NetbiosName, r.Sid, r.Flags)
+			default:
+				fmt.Fprintf(tw, "%v\t%s\t\t\t0x%x\n", r.Type, r.TopLevelName, r.Flags)
+			}
+		}
+		return tw.Flush()
+	})
+}
+
+func runPolicyCAPs(args []string) error {
+	fs := newFlagSet("winlsa policy caps", "")
+	system := fs.String("system", "", "query `host` instead of the local system")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	caps, err := policy.AppliedCentralAccessPolicies(*system)
+	if err != nil {
+		return fmt.Errorf("LsaGetAppliedCAPIDs: %v", err)
+	}
+	return out.list(caps, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME\tRULES\tDESCRIPTION")
+		for _, c := range caps {
+			fmt.Fprintf(tw, "%v\t%s\t%d\t%s\n", c.ID, c.Name, c.EntryCount, c.Description)
+		}
+		return tw.Flush()
+	})
+}
+
+// policyInfo is the report of "winlsa policy info".
+type policyInfo struct {
+	AccountDomain  *policy.DomainInfo
+	PrimaryDomain  *policy.DomainInfo
+	DnsDomain      *policy.DnsDomainInfo
+	ServerRole     policy.ServerRole
+	MachineAccount *policy.MachineAccount `json:",omitempty"`
+	AuditEvents    *policy.AuditEventsInfo
+}
+
+func runPolicyInfo(args []string) error {
+	fs := newFlagSet("winlsa policy info", "")
+	system := fs.String("system", "", "query the policy of `host` instead of the local system")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	p, err := openPolicy(*system, policy.AccessViewLocalInformation|policy.AccessViewAuditInformation)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	var info policyInfo
+	info.AccountDomain, err = p.QueryAccountDomain()
+	if err != nil {
+		return fmt.Errorf("PolicyAccountDomainInformation: %v", err)
+	}
+	info.PrimaryDomain, err = p.QueryPrimaryDomain()
+	if err != nil {
+		return fmt.Errorf("PolicyPrimaryDomainInformation: %v", err)
+	}
+	info.DnsDomain, err = p.QueryDnsDomain()
+	if err != nil {
+		return fmt.Errorf("PolicyDnsDomainInformation: %v", err)
+	}
+	info.ServerRole, err = p.QueryServerRole()
+	if err != nil {
+		return fmt.Errorf("PolicyLsaServerRoleInformation: %v", err)
+	}
+	// Not available before Windows 10 and Windows Server 2016.
+	info.MachineAccount, _ = p.QueryMachineAccount()
+	info.AuditEvents, err = p.QueryAuditEvents()
+	if err != nil {
+		return fmt.Errorf("PolicyAuditEventsInformation: %v", err)
+	}
+
+	return out.object(info, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 0, 4, 1, ' ', 0)
+		fmt.Fprintf(tw, "Account domain:\t%s\t%v\n", info.AccountDomain.Name, info.AccountDomain.Sid)
+		fmt.Fprintf(tw, "Primary domain:\t%s\t%v\n", info.PrimaryDomain.Name, info.PrimaryDomain.Sid)
+		if info.DnsDomain.DnsDomainName != "" {
+			fmt.Fprintf(tw, "DNS domain:\t%s\n", info.DnsDomain.DnsDomainName)
+			fmt.Fprintf(tw, "DNS forest:\t%s\n", info.DnsDomain.DnsForestName)
+			fmt.Fprintf(tw, "Domain GUID:\t%v\n", info.DnsDomain.DomainGuid)
+		}
+		fmt.Fprintf(tw, "Server role:\t%v\n", info.ServerRole)
+		if info.MachineAccount != nil && info.MachineAccount.Sid != nil {
+			fmt.Fprintf(tw, "Machine account:\t%v\n", info.MachineAccount.Sid)
+		}
+		mode := "disabled"
+		if info.AuditEvents.AuditingMode {
+			mode = "enabled"
+		}
+		fmt.Fprintf(tw, "Auditing:\t%s\n", mode)
+		for idx, o := range info.AuditEvents.Options {
+			fmt.Fprintf(tw, "  %v:\t%v\n", policy.AuditEventCategory(idx), o)
+		}
+		return tw.Flush()
+	})
+}
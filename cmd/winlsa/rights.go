@@ -0,0 +1,229 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/policy"
+)
+
+var rightsCommands []*command
+
+func init() {
+	rightsCommands = []*command{
+		{name: "list", args: "<account>", summary: "list the rights assigned to an account", run: runRightsList},
+		{name: "grant", args: "<account> <right>...", summary: "assign rights to an account", run: runRightsGrant},
+		{name: "revoke", args: "<account> <right>...", summary: "remove rights from an account", run: runRightsRevoke},
+		{name: "who-has", args: "<right>", summary: "list the accounts holding a right", run: runRightsWhoHas},
+		{name: "export", summary: "write all user rights assignments as JSON", run: runRightsExport},
+		{name: "import", args: "<file>", summary: "restore user rights assignments from JSON", run: runRightsImport},
+	}
+}
+
+func runRights(args []string) error {
+	return dispatch("winlsa rights", args, rightsCommands)
+}
+
+// resolveAccount returns the SID of account, which is either a SID string
+// or an account name.
+func resolveAccount(p *policy.Policy, account string) (*windows.SID, error) {
+	if sid, err := windows.StringToSid(account); err == nil {
+		return sid, nil
+	}
+	translated, err := p.LookupNames([]string{account}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("LsaLookupNames2: %v", err)
+	}
+	if !translated[0].Use.Mapped() || translated[0].Sid == nil {
+		return nil, fmt.Errorf("account %q could not be resolved", account)
+	}
+	return translated[0].Sid, nil
+}
+
+// accountRight is a right as listed by "winlsa rights list".
+type accountRight struct {
+	Right       string
+	DisplayName string `json:",omitempty"`
+}
+
+func runRightsList(args []string) error {
+	fs := newFlagSet("winlsa rights list", "<account>")
+	system := fs.String("system", "", "query `host` instead of the local system")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	p, err := openPolicy(*system, policy.AccessViewLocalInformation|policy.AccessLookupNames)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	sid, err := resolveAccount(p, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	names, err := p.EnumerateAccountRights(sid)
+	if err != nil {
+		return fmt.Errorf("LsaEnumerateAccountRights: %v", err)
+	}
+	rights := make([]accountRight, len(names))
+	for idx, name := range names {
+		rights[idx].Right = name
+		// Logon rights have no display name.
+		rights[idx].DisplayName, _ = p.LookupPrivilegeDisplayName(name)
+	}
+	return out.list(rights, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, r := range rights {
+			fmt.Fprintf(tw, "%s\t%s\n", r.Right, r.DisplayName)
+		}
+		return tw.Flush()
+	})
+}
+
+func runRightsGrant(args []string) error {
+	return changeRights("winlsa rights grant", args, (*policy.Policy).AddAccountRights)
+}
+
+func runRightsRevoke(args []string) error {
+	return changeRights("winlsa rights revoke", args, (*policy.Policy).RemoveAccountRights)
+}
+
+func changeRights(prog string, args []string, change func(*policy.Policy, *windows.SID, ...string) error) error {
+	fs := newFlagSet(prog, "<account> <right>...")
+	system := fs.String("system", "", "change the rights on `host` instead of the local system")
+	err := parseFlags(fs, args, 2, -1)
+	if err != nil {
+		return err
+	}
+
+	p, err := openPolicy(*system, policy.AccessLookupNames|policy.AccessCreateAccount)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	sid, err := resolveAccount(p, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return change(p, sid, fs.Args()[1:]...)
+}
+
+func runRightsWhoHas(args []string) error {
+	fs := newFlagSet("winlsa rights who-has", "<right>")
+	system := fs.String("system", "", "query `host` instead of the local system")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	p, err := openPolicy(*system, policy.AccessViewLocalInformation|policy.AccessLookupNames)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	sids, err := p.EnumerateAccountsWithUserRight(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("LsaEnumerateAccountsWithUserRight: %v", err)
+	}
+	names, err := p.LookupSids(sids, 0)
+	if err != nil {
+		return fmt.Errorf("LsaLookupSids2: %v", err)
+	}
+	holders := make([]policy.RightHolder, len(names))
+	for idx, n := range names {
+		holders[idx] = policy.RightHolder{Sid: n.Sid.String()}
+		if n.Use.Mapped() {
+			holders[idx].Name = n.AccountName()
+		}
+	}
+	return out.list(holders, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "SID\tNAME")
+		for _, h := range holders {
+			fmt.Fprintf(tw, "%s\t%s\n", h.Sid, h.Name)
+		}
+		return tw.Flush()
+	})
+}
+
+func runRightsExport(args []string) error {
+	fs := newFlagSet("winlsa rights export", "")
+	system := fs.String("system", "", "export the rights of `host` instead of the local system")
+	file := fs.String("o", "", "write to `file` instead of stdout")
+	out := addOutputFlag(fs)
+	out.format = "json"
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	p, err := openPolicy(*system, policy.AccessViewLocalInformation|policy.AccessLookupNames)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	doc, err := p.SnapshotRights()
+	if err != nil {
+		return err
+	}
+
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out.w = f
+	}
+	if out.format == "ndjson" {
+		return out.list(doc.Rights, nil)
+	}
+	return out.object(doc, func(w io.Writer) error {
+		for _, a := range doc.Rights {
+			fmt.Fprintln(w, a.Right)
+			for _, h := range a.Accounts {
+				fmt.Fprintf(w, "  %s %s\n", h.Sid, h.Name)
+			}
+		}
+		return nil
+	})
+}
+
+func runRightsImport(args []string) error {
+	fs := newFlagSet("winlsa rights import", "<file>")
+	system := fs.String("system", "", "restore the rights of `host` instead of the local system")
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var doc policy.RightsDocument
+	err = json.NewDecoder(f).Decode(&doc)
+	if err != nil {
+		return fmt.Errorf("%s: %v", fs.Arg(0), err)
+	}
+
+	p, err := openPolicy(*system, policy.AccessAll)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	return p.RestoreRights(&doc)
+}
@@ -0,0 +1,62 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/cobraqxx/winlsa/s4u"
+)
+
+var s4uCommands []*command
+
+func init() {
+	s4uCommands = []*command{
+		{name: "run", args: "-user <user> -- <command> [arguments]", summary: "run a command as a user via an S4U logon", run: runS4URun},
+	}
+}
+
+func runS4U(args []string) error {
+	return dispatch("winlsa s4u", args, s4uCommands)
+}
+
+func runS4URun(args []string) error {
+	fs := newFlagSet("winlsa s4u run", "-user <user> -- <command> [arguments]")
+	user := fs.String("user", "", "run as `user`, a UPN or a local account name")
+	domain := fs.String("domain", "", "`domain` of -user if it is not a UPN; . for the local computer")
+	err := parseFlags(fs, args, 1, -1)
+	if err != nil {
+		return err
+	}
+	if *user == "" {
+		return usagef("missing -user")
+	}
+
+	res, err := s4u.Logon(*user, *domain)
+	if err != nil {
+		return fmt.Errorf("LsaLogonUser: %v", err)
+	}
+	defer res.Token.Close()
+	primary, err := res.PrimaryToken()
+	if err != nil {
+		return fmt.Errorf("DuplicateTokenEx (running as SYSTEM is required to start processes): %v", err)
+	}
+	defer primary.Close()
+
+	cmd := exec.Command(fs.Arg(0), fs.Args()[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.SysProcAttr = &syscall.SysProcAttr{Token: syscall.Token(primary)}
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitStatus(exitErr.ExitCode())
+	}
+	return err
+}
@@ -0,0 +1,146 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"unicode/utf16"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/policy"
+)
+
+var secretCommands []*command
+
+func init() {
+	secretCommands = []*command{
+		{name: "get", args: "<name>", summary: "print an LSA secret", run: runSecretGet},
+		{name: "set", args: "<name> [value]", summary: "store an LSA secret", run: runSecretSet},
+		{name: "delete", args: "<name>", summary: "delete an LSA secret", run: runSecretDelete},
+	}
+}
+
+func runSecret(args []string) error {
+	return dispatch("winlsa secret", args, secretCommands)
+}
+
+func runSecretGet(args []string) error {
+	fs := newFlagSet("winlsa secret get", "<name>")
+	system := fs.String("system", "", "read the secret of `host` instead of the local system")
+	encoding := fs.String("encoding", "text", "print the secret as `encoding`: text (UTF-16 decoded), hex or base64")
+	understood := fs.Bool("i-understand", false, "confirm that the secret will be printed in clear text")
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if !*understood {
+		return usagef("reading a secret prints it in clear text; pass -i-understand to proceed")
+	}
+	switch *encoding {
+	case "text", "hex", "base64":
+	default:
+		return usagef("invalid encoding %q", *encoding)
+	}
+
+	p, err := openPolicy(*system, policy.AccessGetPrivateInformation)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	data, err := p.RetrievePrivateData(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("LsaRetrievePrivateData: %v", err)
+	}
+	switch *encoding {
+	case "hex":
+		fmt.Println(hex.EncodeToString(data))
+	case "base64":
+		fmt.Println(base64.StdEncoding.EncodeToString(data))
+	default:
+		fmt.Println(decodeUTF16(data))
+	}
+	return nil
+}
+
+func runSecretSet(args []string) error {
+	fs := newFlagSet("winlsa secret set", "<name> [value]")
+	system := fs.String("system", "", "store the secret on `host` instead of the local system")
+	file := fs.String("file", "", "store the raw contents of `file` instead of value; - reads stdin")
+	err := parseFlags(fs, args, 1, 2)
+	if err != nil {
+		return err
+	}
+	var data []byte
+	switch {
+	case *file != "" && fs.NArg() == 2:
+		return usagef("value and -file are mutually exclusive")
+	case *file == "-":
+		data, err = ioutil.ReadAll(os.Stdin)
+	case *file != "":
+		data, err = ioutil.ReadFile(*file)
+	case fs.NArg() == 2:
+		// Secrets such as service account passwords are stored as UTF-16
+		// without a terminating NUL.
+		data = encodeUTF16(fs.Arg(1))
+	default:
+		return usagef("missing value or -file")
+	}
+	if err != nil {
+		return err
+	}
+
+	p, err := openPolicy(*system, policy.AccessCreateSecret)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	err = p.StorePrivateData(fs.Arg(0), data)
+	if err != nil {
+		return fmt.Errorf("LsaStorePrivateData: %v", err)
+	}
+	return nil
+}
+
+func runSecretDelete(args []string) error {
+	fs := newFlagSet("winlsa secret delete", "<name>")
+	system := fs.String("system", "", "delete the secret on `host` instead of the local system")
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	p, err := openPolicy(*system, policy.AccessCreateSecret)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	err = p.DeletePrivateData(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("LsaStorePrivateData: %v", err)
+	}
+	return nil
+}
+
+func encodeUTF16(s string) []byte {
+	u := utf16.Encode([]rune(s))
+	data := make([]byte, 2*len(u))
+	for idx, c := range u {
+		data[2*idx] = byte(c)
+		data[2*idx+1] = byte(c >> 8)
+	}
+	return data
+}
+
+func decodeUTF16(data []byte) string {
+	u := make([]uint16, len(data)/2)
+	for idx := range u {
+		u[idx] = uint16(data[2*idx]) | uint16(data[2*idx+1])<<8
+	}
+	return windows.UTF16ToString(u)
+}
@@ -0,0 +1,36 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/cobraqxx/winlsa/server"
+)
+
+func runServe(args []string) error {
+	fs := newFlagSet("winlsa serve", "")
+	pipe := fs.String("pipe", server.DefaultPipe, "listen on the named pipe `name`")
+	sddl := fs.String("sddl", server.DefaultSecurityDescriptor, "restrict access to the pipe with the security descriptor `sddl`")
+	users := fs.Bool("users", false, "let all authenticated users connect; overrides -sddl")
+	interval := fs.Duration("interval", time.Second, "poll the session list for watch streams every `duration`")
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	if *users {
+		*sddl = server.UsersSecurityDescriptor
+	}
+	l, err := server.ListenPipe(*pipe, *sddl)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %v", *pipe, err)
+	}
+	defer l.Close()
+	fmt.Fprintf(os.Stderr, "winlsa: serving on %s\n", *pipe)
+	return http.Serve(l, server.Handler(server.Options{WatchInterval: *interval}))
+}
@@ -0,0 +1,316 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+func runSessions(args []string) error {
+	fs := newFlagSet("winlsa sessions", "")
+	out := addOutputFlag(fs, "csv", "tsv")
+	columnSpec := fs.String("columns", defaultSessionColumns, "comma separated `list` of columns for text, csv and tsv output: "+sessionColumnNames())
+	sortKey := fs.String("sort", "", "sort sessions by `key`: "+strings.Join(sessionSortKeys, ", "))
+	tree := fs.Bool("tree", false, "group sessions by account")
+	resolve := fs.Bool("resolve", false, "resolve session SIDs to their current account names; implied by the resolved column")
+	expiring := fs.Duration("password-expiring", 0, "only show sessions whose password must be changed within `duration`")
+	filter := addSessionFilterFlags(fs)
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	if *tree && out.tabular() {
+		return usagef("-tree cannot be combined with csv or tsv output")
+	}
+	columns, err := selectSessionColumns(*columnSpec)
+	if err != nil {
+		return err
+	}
+	if *sortKey != "" && !validSortKey(*sortKey) {
+		return usagef("invalid sort key %q (available: %s)", *sortKey, strings.Join(sessionSortKeys, ", "))
+	}
+
+	luids, err := winlsa.GetLogonSessions()
+	if err != nil {
+		return fmt.Errorf("GetLogonSessions: %v", err)
+	}
+	sessions := make([]*winlsa.LogonSessionData, 0, len(luids))
+	var queryFailed int
+	for _, luid := range luids {
+		sd, err := winlsa.GetLogonSessionData(&luid)
+		if err != nil {
+			// Sessions may end between enumeration and query.
+			fmt.Fprintf(os.Stderr, "winlsa: session %v: %v\n", luid, err)
+			queryFailed++
+			continue
+		}
+		if !filter.Match(sd) {
+			continue
+		}
+		if *expiring > 0 {
+			if left, ok := sd.PasswordExpiresIn(); !ok || left > *expiring {
+				continue
+			}
+		}
+		sessions = append(sessions, sd)
+	}
+	if queryFailed == len(luids) && queryFailed > 0 {
+		return fmt.Errorf("no session could be queried")
+	}
+
+	var origins bool
+	for _, c := range columns {
+		*resolve = *resolve || c.name == "resolved"
+		origins = origins || c.name == "source"
+	}
+	if origins {
+		for _, sd := range sessions {
+			err := winlsa.EnrichRemoteOrigin(sd)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "winlsa: session %v: reading the Security log: %v\n", sd.LogonId, err)
+				break
+			}
+		}
+	}
+	if *resolve {
+		err := winlsa.ResolveAccounts(sessions)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "winlsa: resolving accounts: %v\n", err)
+		}
+	}
+
+	sortSessions(sessions, *sortKey)
+	if *tree {
+		accounts := groupSessions(sessions)
+		return out.list(accounts, func(w io.Writer) error {
+			return writeSessionTree(w, accounts, columns)
+		})
+	}
+	if out.tabular() {
+		return out.csv(sessionRows(sessions, columns))
+	}
+	return out.list(sessions, func(w io.Writer) error {
+		header, rows := sessionRows(sessions, columns)
+		return writeTable(w, header, rows)
+	})
+}
+
+// accountSessions is an account with its logon sessions, as listed by
+// "winlsa sessions -tree".
+type accountSessions struct {
+	Account     string
+	Sid         string `json:",omitempty"`
+	Count       int
+	LogonTypes  []winlsa.LogonType
+	OldestLogon time.Time
+	Sessions    []*winlsa.LogonSessionData
+}
+
+// groupSessions groups sessions by account, keeping the order in which the
+// accounts first appear.
+func groupSessions(sessions []*winlsa.LogonSessionData) []*accountSessions {
+	var accounts []*accountSessions
+	byName := map[string]*accountSessions{}
+	for _, sd := range sessions {
+		name := accountName(sd.LogonDomain, sd.UserName)
+		key := strings.ToLower(name)
+		a, ok := byName[key]
+		if !ok {
+			a = &accountSessions{Account: name, Sid: sidString(sd)}
+			byName[key] = a
+			accounts = append(accounts, a)
+		}
+		a.Count++
+		a.Sessions = append(a.Sessions, sd)
+		if !sd.LogonTime.IsZero() && (a.OldestLogon.IsZero() || sd.LogonTime.Before(a.OldestLogon)) {
+			a.OldestLogon = sd.LogonTime
+		}
+		var seen bool
+		for _, lt := range a.LogonTypes {
+			seen = seen || lt == sd.LogonType
+		}
+		if !seen {
+			a.LogonTypes = append(a.LogonTypes, sd.LogonType)
+		}
+	}
+	return accounts
+}
+
+func writeSessionTree(w io.Writer, accounts []*accountSessions, columns []sessionColumn) error {
+	for _, a := range accounts {
+		types := make([]string, len(a.LogonTypes))
+		for idx, lt := range a.LogonTypes {
+			types[idx] = lt.String()
+		}
+		name := a.Account
+		if name == "" {
+			name = "(no user)"
+		}
+		fmt.Fprintf(w, "%s  sessions=%d types=%s oldest=%s\n", name, a.Count, strings.Join(types, ","), formatTime(a.OldestLogon))
+		_, rows := sessionRows(a.Sessions, columns)
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for idx, row := range rows {
+			branch := "├─"
+			if idx == len(rows)-1 {
+				branch = "└─"
+			}
+			fmt.Fprintf(tw, "  %s %s\n", branch, strings.Join(row, "\t"))
+		}
+		err := tw.Flush()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var sessionSortKeys = []string{"luid", "logontime", "user", "type"}
+
+func validSortKey(key string) bool {
+	for _, k := range sessionSortKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// sortSessions sorts sessions in place by key; ties keep enumeration order.
+func sortSessions(sessions []*winlsa.LogonSessionData, key string) {
+	switch key {
+	case "luid":
+		sort.SliceStable(sessions, func(i, j int) bool {
+			return luidValue(sessions[i].LogonId) < luidValue(sessions[j].LogonId)
+		})
+	case "logontime":
+		sort.Stable(winlsa.ByLogonTime(sessions))
+	case "user":
+		sort.Stable(winlsa.ByUser(sessions))
+	case "type":
+		sort.Stable(winlsa.ByType(sessions))
+	}
+}
+
+func luidValue(luid winlsa.LUID) uint64 {
+	return uint64(uint32(luid.HighPart))<<32 | uint64(luid.LowPart)
+}
+
+func runSession(args []string) error {
+	fs := newFlagSet("winlsa session", "<luid>")
+	out := addOutputFlag(fs)
+	raw := fs.Bool("raw-times", false, "include the raw FILETIME values and tell unset times from \"never\"")
+	origin := fs.Bool("origin", false, "look up the client of network and remote interactive logons in the Security log")
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	luid, err := winlsa.ParseLUID(fs.Arg(0))
+	if err != nil {
+		return &usageError{msg: err.Error()}
+	}
+
+	opts := winlsa.GetLogonSessionDataOpts{Fields: winlsa.SessionFieldAll}
+	if *raw {
+		opts.Fields |= winlsa.SessionFieldRawTimes
+	}
+	sd, err := winlsa.GetLogonSessionDataWithOpts(&luid, opts)
+	if err != nil {
+		return fmt.Errorf("GetLogonSessionData: %v", err)
+	}
+	if *origin {
+		err := winlsa.EnrichRemoteOrigin(sd)
+		if err != nil {
+			return fmt.Errorf("reading the Security log: %v", err)
+		}
+	}
+	return out.object(sd, func(w io.Writer) error {
+		printSessionData(w, sd)
+		return nil
+	})
+}
+
+func printSessionData(w io.Writer, sd *winlsa.LogonSessionData) {
+	var raw winlsa.RawTimes
+	if sd.RawTimes != nil {
+		raw = *sd.RawTimes
+	}
+	fields := []struct {
+		name  string
+		value interface{}
+	}{
+		{"LogonId", sd.LogonId},
+		{"UserName", sd.UserName},
+		{"LogonDomain", sd.LogonDomain},
+		{"AuthenticationPackage", sd.AuthenticationPackage},
+		{"LogonType", fmt.Sprintf("%v (%d)", sd.LogonType, sd.LogonType)},
+		{"Session", sd.Session},
+		{"Sid", sd.Sid},
+		{"LogonTime", formatSessionTime(sd.LogonTime, raw.LogonTime)},
+		{"LogonServer", sd.LogonServer},
+		{"DnsDomainName", sd.DnsDomainName},
+		{"Upn", sd.Upn},
+		{"UserFlags", fmt.Sprintf("0x%x", sd.UserFlags)},
+		{"LastSuccessfulLogon", formatSessionTime(sd.LastSuccessfulLogon, raw.LastSuccessfulLogon)},
+		{"LastFailedLogon", formatSessionTime(sd.LastFailedLogon, raw.LastFailedLogon)},
+		{"FailedAttemptCount", sd.FailedAttemptCountSinceLastSuccessfulLogon},
+		{"LogonScript", sd.LogonScript},
+		{"ProfilePath", sd.ProfilePath},
+		{"HomeDirectory", sd.HomeDirectory},
+		{"HomeDirectoryDrive", sd.HomeDirectoryDrive},
+		{"LogoffTime", formatSessionTime(sd.LogoffTime, raw.LogoffTime)},
+		{"KickOffTime", formatSessionTime(sd.KickOffTime, raw.KickOffTime)},
+		{"PasswordLastSet", formatSessionTime(sd.PasswordLastSet, raw.PasswordLastSet)},
+		{"PasswordCanChange", formatSessionTime(sd.PasswordCanChange, raw.PasswordCanChange)},
+		{"PasswordMustChange", formatSessionTime(sd.PasswordMustChange, raw.PasswordMustChange)},
+	}
+	if o := sd.RemoteOrigin; o != nil {
+		fields = append(fields, []struct {
+			name  string
+			value interface{}
+		}{
+			{"SourceAddress", o.Address},
+			{"SourcePort", o.Port},
+			{"SourceWorkstation", o.Workstation},
+			{"LogonGuid", o.LogonGuid},
+		}...)
+	}
+	if sd.Unavailable != 0 {
+		fields = append(fields, struct {
+			name  string
+			value interface{}
+		}{"Unavailable", sd.Unavailable})
+	}
+	for _, f := range fields {
+		fmt.Fprintf(w, "%-22s %v\n", f.name+":", f.value)
+	}
+}
+
+// formatSessionTime formats a session timestamp. If the raw value ft is
+// known, times that are never reached print as "never" and the raw value is
+// appended.
+func formatSessionTime(t time.Time, ft winlsa.FileTime) string {
+	switch {
+	case ft.IsNever():
+		return fmt.Sprintf("never (0x%x)", uint64(ft))
+	case ft.IsSet():
+		return fmt.Sprintf("%s (0x%x)", formatTime(t), uint64(ft))
+	default:
+		return formatTime(t)
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.RFC3339)
+}
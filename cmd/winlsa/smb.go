@@ -0,0 +1,57 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+func runSMB(args []string) error {
+	fs := newFlagSet("winlsa smb", "")
+	out := addOutputFlag(fs)
+	origin := fs.Bool("origin", false, "match client addresses from the Security log, for more precise results")
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := winlsa.FindLogonSessions(winlsa.SessionFilter{LogonTypes: []winlsa.LogonType{winlsa.LogonTypeNetwork}})
+	if _, partial := err.(winlsa.SessionErrors); err != nil && !partial {
+		return fmt.Errorf("FindLogonSessions: %v", err)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, "winlsa:", err)
+	}
+	if *origin {
+		for _, sd := range sessions {
+			err := winlsa.EnrichRemoteOrigin(sd)
+			if err != nil {
+				return fmt.Errorf("reading the Security log: %v", err)
+			}
+		}
+	}
+	matches, err := winlsa.CorrelateSMBSessions(sessions)
+	if err != nil {
+		return fmt.Errorf("NetSessionEnum: %v", err)
+	}
+	return out.list(matches, func(w io.Writer) error {
+		rows := make([][]string, len(matches))
+		for idx, m := range matches {
+			rows[idx] = []string{
+				m.Session.LogonId.String(),
+				accountName(m.Session.LogonDomain, m.Session.UserName),
+				m.SMB.Client,
+				strconv.FormatUint(uint64(m.SMB.OpenFiles), 10),
+				m.SMB.Duration.Round(time.Second).String(),
+				m.SMB.Idle.Round(time.Second).String(),
+			}
+		}
+		return writeTable(w, []string{"luid", "account", "client", "openfiles", "connected", "idle"}, rows)
+	})
+}
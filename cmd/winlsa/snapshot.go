@@ -0,0 +1,231 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+// sessionSnapshot is the document written by "winlsa snapshot".
+type sessionSnapshot struct {
+	Time     time.Time
+	Hostname string
+	// Consistent is false if sessions kept ending while the snapshot was
+	// taken.
+	Consistent bool
+	Sessions   []*winlsa.LogonSessionData
+}
+
+func runSnapshot(args []string) error {
+	fs := newFlagSet("winlsa snapshot", "")
+	file := fs.String("out", "", "write to `file` instead of stdout")
+	retries := fs.Int("retries", 3, "retake the snapshot up to `n` times while sessions end during it")
+	forensic := fs.Bool("forensic", false, "also collect the Kerberos tickets and processes of every session and the Terminal Services sessions")
+	filter := addSessionFilterFlags(fs)
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+	if *forensic {
+		return writeForensics(*file, filter)
+	}
+
+	taken, err := winlsa.TakeSnapshot(*retries)
+	if err != nil {
+		return fmt.Errorf("GetLogonSessions: %v", err)
+	}
+	for luid, err := range taken.Errors {
+		fmt.Fprintf(os.Stderr, "winlsa: session %v: %v\n", luid, err)
+	}
+	if !taken.Consistent {
+		fmt.Fprintf(os.Stderr, "winlsa: sessions ended during %d attempts, the snapshot is incomplete\n", taken.Attempts)
+	}
+	snap := sessionSnapshot{Time: taken.Time, Consistent: taken.Consistent}
+	snap.Hostname, _ = os.Hostname()
+	for _, sd := range taken.Sessions {
+		if filter.Match(sd) {
+			snap.Sessions = append(snap.Sessions, sd)
+		}
+	}
+
+	return writeSnapshotFile(*file, snap)
+}
+
+func writeForensics(file string, filter *winlsa.SessionFilter) error {
+	f, err := winlsa.ForensicSnapshot()
+	if err != nil {
+		return fmt.Errorf("GetLogonSessions: %v", err)
+	}
+	for _, msg := range f.Errors {
+		fmt.Fprintln(os.Stderr, "winlsa:", msg)
+	}
+	sessions := f.Sessions[:0]
+	for _, s := range f.Sessions {
+		if filter.Match(s.Data) {
+			sessions = append(sessions, s)
+		}
+	}
+	f.Sessions = sessions
+	return writeSnapshotFile(file, f)
+}
+
+// writeSnapshotFile writes v as indented JSON to file, or to stdout if
+// file is empty.
+func writeSnapshotFile(file string, v interface{}) error {
+	w := io.Writer(os.Stdout)
+	if file != "" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// snapshotDocument is a snapshot decoded without knowledge of the session
+// fields, so that snapshots of other winlsa versions can be compared.
+type snapshotDocument struct {
+	Time     time.Time
+	Hostname string
+	Sessions []map[string]interface{}
+}
+
+// fieldChange is a session field that differs between two snapshots.
+type fieldChange struct {
+	Field string
+	Old   interface{}
+	New   interface{}
+}
+
+type sessionChange struct {
+	LogonId string
+	Changes []fieldChange
+}
+
+// snapshotDiff is the result of "winlsa diff".
+type snapshotDiff struct {
+	Added   []map[string]interface{}
+	Removed []map[string]interface{}
+	Changed []sessionChange
+}
+
+func readSnapshot(name string) (*snapshotDocument, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var doc snapshotDocument
+	err = json.NewDecoder(f).Decode(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return &doc, nil
+}
+
+func sessionsByLogonId(sessions []map[string]interface{}) (map[string]map[string]interface{}, []string) {
+	byID := make(map[string]map[string]interface{}, len(sessions))
+	var ids []string
+	for _, s := range sessions {
+		id, _ := s["LogonId"].(string)
+		byID[id] = s
+		ids = append(ids, id)
+	}
+	return byID, ids
+}
+
+func diffSnapshots(before, after *snapshotDocument) *snapshotDiff {
+	var diff snapshotDiff
+	oldByID, oldIDs := sessionsByLogonId(before.Sessions)
+	newByID, newIDs := sessionsByLogonId(after.Sessions)
+	for _, id := range oldIDs {
+		if _, ok := newByID[id]; !ok {
+			diff.Removed = append(diff.Removed, oldByID[id])
+		}
+	}
+	for _, id := range newIDs {
+		o, ok := oldByID[id]
+		if !ok {
+			diff.Added = append(diff.Added, newByID[id])
+			continue
+		}
+		n := newByID[id]
+		fields := map[string]bool{}
+		for f := range o {
+			fields[f] = true
+		}
+		for f := range n {
+			fields[f] = true
+		}
+		var names []string
+		for f := range fields {
+			names = append(names, f)
+		}
+		sort.Strings(names)
+		change := sessionChange{LogonId: id}
+		for _, f := range names {
+			if !reflect.DeepEqual(o[f], n[f]) {
+				change.Changes = append(change.Changes, fieldChange{Field: f, Old: o[f], New: n[f]})
+			}
+		}
+		if len(change.Changes) > 0 {
+			diff.Changed = append(diff.Changed, change)
+		}
+	}
+	return &diff
+}
+
+func runDiff(args []string) error {
+	fs := newFlagSet("winlsa diff", "<old.json> <new.json>")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 2, 2)
+	if err != nil {
+		return err
+	}
+	before, err := readSnapshot(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	after, err := readSnapshot(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	diff := diffSnapshots(before, after)
+	return out.object(diff, func(w io.Writer) error {
+		for _, s := range diff.Removed {
+			fmt.Fprintf(w, "- %s\n", describeSnapshotSession(s))
+		}
+		for _, s := range diff.Added {
+			fmt.Fprintf(w, "+ %s\n", describeSnapshotSession(s))
+		}
+		for _, c := range diff.Changed {
+			fmt.Fprintf(w, "~ %s\n", c.LogonId)
+			for _, f := range c.Changes {
+				fmt.Fprintf(w, "    %s: %v -> %v\n", f.Field, f.Old, f.New)
+			}
+		}
+		return nil
+	})
+}
+
+func describeSnapshotSession(s map[string]interface{}) string {
+	str := func(key string) string {
+		v, _ := s[key].(string)
+		return v
+	}
+	return fmt.Sprintf("%s %s %s %s", str("LogonId"), accountName(str("LogonDomain"), str("UserName")), str("LogonType"), str("AuthenticationPackage"))
+}
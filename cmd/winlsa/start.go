@@ -0,0 +1,65 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+func runStart(args []string) error {
+	fs := newFlagSet("winlsa start", "-luid <luid> -- <command> [arguments]")
+	luidFlag := fs.String("luid", "", "start the command in logon session `luid`")
+	wts := fs.Int("wts-session", -1, "move the process to Terminal Services session `id`; requires SeTcbPrivilege")
+	dir := fs.String("dir", "", "working `directory`; defaults to the user's profile")
+	hidden := fs.Bool("hidden", false, "do not show the process window")
+	wait := fs.Bool("wait", false, "wait for the process and exit with its exit code")
+	err := parseFlags(fs, args, 1, -1)
+	if err != nil {
+		return err
+	}
+	if *luidFlag == "" {
+		return usagef("missing -luid")
+	}
+	luid, err := parseSessionFlag(*luidFlag)
+	if err != nil {
+		return err
+	}
+	opts := winlsa.StartProcessOpts{Dir: *dir, Hidden: *hidden}
+	if *wts >= 0 {
+		id := uint32(*wts)
+		opts.WTSSession = &id
+	}
+	quoted := make([]string, fs.NArg())
+	for idx, arg := range fs.Args() {
+		quoted[idx] = syscall.EscapeArg(arg)
+	}
+
+	ensurePrivileges(winlsa.PrivilegeDebug)
+	if opts.WTSSession != nil {
+		ensurePrivileges(winlsa.PrivilegeTcb)
+	}
+	// Either privilege suffices, so a missing one is not reported.
+	winlsa.EnsurePrivileges(winlsa.PrivilegeAssignPrimaryToken, winlsa.PrivilegeImpersonate)
+	p, err := winlsa.StartProcessInSession(luid, strings.Join(quoted, " "), opts)
+	if err != nil {
+		return fmt.Errorf("StartProcessInSession: %v", err)
+	}
+	defer p.Release()
+	fmt.Println(p.Pid)
+	if !*wait {
+		return nil
+	}
+	state, err := p.Wait()
+	if err != nil {
+		return err
+	}
+	if code := state.ExitCode(); code != 0 {
+		return exitStatus(code)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/siem"
+)
+
+// Syslog priority of session events: facility authpriv (10), severity
+// informational (6).
+const syslogPriority = 10*8 + 6
+
+// syslogSink streams session events to a syslog collector as RFC 5424
+// messages over TCP or TLS, framed by octet counting (RFC 6587, RFC 5425).
+// Like webhookSink, it delivers from a background goroutine and reconnects
+// with exponential backoff.
+type syslogSink struct {
+	network string
+	addr    string
+	tls     *tls.Config
+	format  string
+	retries int
+	host    string
+	conn    net.Conn
+	queue   chan []byte
+	done    chan struct{}
+}
+
+// newSyslogSink parses target, a tcp://host:port or tls://host:port URL.
+// With caFile, the collector's certificate is verified against the CA
+// certificates in that PEM file instead of the system roots. format selects
+// the message body: json, ecs or cef.
+func newSyslogSink(target, caFile, format string, retries int) (*syslogSink, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("syslog: %v", err)
+	}
+	if u.Port() == "" {
+		return nil, fmt.Errorf("syslog: %s: missing port", target)
+	}
+	s := &syslogSink{
+		network: "tcp",
+		addr:    u.Host,
+		format:  format,
+		retries: retries,
+		queue:   make(chan []byte, 256),
+		done:    make(chan struct{}),
+	}
+	switch u.Scheme {
+	case "tcp":
+	case "tls":
+		s.tls = &tls.Config{ServerName: u.Hostname()}
+		if caFile != "" {
+			pem, err := ioutil.ReadFile(caFile)
+			if err != nil {
+				return nil, err
+			}
+			s.tls.RootCAs = x509.NewCertPool()
+			if !s.tls.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("syslog: no certificates in %s", caFile)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("syslog: %s: scheme must be tcp or tls", target)
+	}
+	s.host, _ = os.Hostname()
+	if s.host == "" {
+		s.host = "-"
+	}
+	go s.run()
+	return s, nil
+}
+
+func (s *syslogSink) send(ev winlsa.SessionEvent) error {
+	msg, err := s.message(ev)
+	if err != nil {
+		return err
+	}
+	select {
+	case s.queue <- msg:
+		return nil
+	default:
+		return fmt.Errorf("syslog: queue full, dropping %v event of session %v", ev.Type, ev.LogonId)
+	}
+}
+
+// message formats ev as a framed RFC 5424 message.
+func (s *syslogSink) message(ev winlsa.SessionEvent) ([]byte, error) {
+	var body []byte
+	var err error
+	switch s.format {
+	case "ecs":
+		body, err = json.Marshal(siem.ECS(ev))
+	case "cef":
+		body = []byte(siem.CEF(ev))
+	default:
+		body, err = json.Marshal(ev)
+	}
+	if err != nil {
+		return nil, err
+	}
+	msg := fmt.Sprintf("<%d>1 %s %s winlsa %d %s - %s", syslogPriority,
+		ev.Time.UTC().Format("2006-01-02T15:04:05.000000Z07:00"), s.host, os.Getpid(),
+		strings.ToLower(ev.Type.String()), body)
+	return []byte(fmt.Sprintf("%d %s", len(msg), msg)), nil
+}
+
+func (s *syslogSink) run() {
+	defer close(s.done)
+	for msg := range s.queue {
+		err := s.deliver(msg)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "winlsa:", err)
+		}
+	}
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
+
+func (s *syslogSink) deliver(msg []byte) error {
+	var err error
+	backoff := time.Second
+	for attempt := 0; ; attempt++ {
+		err = s.write(msg)
+		if err == nil || attempt >= s.retries {
+			return err
+		}
+		time.Sleep(backoff)
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+// write sends msg on the current connection, dialing a new one first if
+// there is none. A failed connection is dropped.
+func (s *syslogSink) write(msg []byte) error {
+	if s.conn == nil {
+		dialer := &net.Dialer{Timeout: 10 * time.Second}
+		var err error
+		if s.tls != nil {
+			s.conn, err = tls.DialWithDialer(dialer, s.network, s.addr, s.tls)
+		} else {
+			s.conn, err = dialer.Dial(s.network, s.addr)
+		}
+		if err != nil {
+			s.conn = nil
+			return fmt.Errorf("syslog: %v", err)
+		}
+	}
+	s.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	_, err := s.conn.Write(msg)
+	if err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return fmt.Errorf("syslog: %v", err)
+	}
+	return nil
+}
+
+// Close waits for the queued events to be delivered.
+func (s *syslogSink) Close() error {
+	close(s.queue)
+	<-s.done
+	return nil
+}
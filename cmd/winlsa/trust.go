@@ -0,0 +1,82 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/cobraqxx/winlsa/policy"
+)
+
+var trustCommands []*command
+
+func init() {
+	trustCommands = []*command{
+		{name: "list", summary: "list the trust relationships of the domain", run: runTrustList},
+		{name: "show", args: "<domain>", summary: "show a trust relationship", run: runTrustShow},
+	}
+}
+
+func runTrust(args []string) error {
+	return dispatch("winlsa trust", args, trustCommands)
+}
+
+func runTrustList(args []string) error {
+	fs := newFlagSet("winlsa trust list", "")
+	system := fs.String("system", "", "query the domain controller `host` instead of the local system")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	p, err := openPolicy(*system, policy.AccessViewLocalInformation)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	domains, err := p.EnumerateTrustedDomains()
+	if err != nil {
+		return fmt.Errorf("LsaEnumerateTrustedDomainsEx: %v", err)
+	}
+	return out.list(domains, func(w io.Writer) error {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tFLATNAME\tDIRECTION\tTYPE\tATTRIBUTES")
+		for _, td := range domains {
+			fmt.Fprintf(tw, "%s\t%s\t%v\t%v\t%v\n", td.Name, td.FlatName, td.Direction, td.Type, td.Attributes)
+		}
+		return tw.Flush()
+	})
+}
+
+func runTrustShow(args []string) error {
+	fs := newFlagSet("winlsa trust show", "<domain>")
+	system := fs.String("system", "", "query the domain controller `host` instead of the local system")
+	out := addOutputFlag(fs)
+	err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	p, err := openPolicy(*system, policy.AccessViewLocalInformation)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	td, err := p.QueryTrustedDomain(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("LsaQueryTrustedDomainInfoByName: %v", err)
+	}
+	return out.object(td, func(w io.Writer) error {
+		fmt.Fprintf(w, "Name:       %s\n", td.Name)
+		fmt.Fprintf(w, "FlatName:   %s\n", td.FlatName)
+		fmt.Fprintf(w, "Sid:        %v\n", td.Sid)
+		fmt.Fprintf(w, "Direction:  %v\n", td.Direction)
+		fmt.Fprintf(w, "Type:       %v\n", td.Type)
+		fmt.Fprintf(w, "Attributes: %v (0x%x)\n", td.Attributes, uint32(td.Attributes))
+		return nil
+	})
+}
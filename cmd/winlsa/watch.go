@@ -0,0 +1,151 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/history"
+	"github.com/cobraqxx/winlsa/siem"
+)
+
+func runWatch(args []string) error {
+	fs := newFlagSet("winlsa watch", "")
+	out := addOutputFlag(fs, "ecs", "cef")
+	interval := fs.Duration("interval", time.Second, "poll the session list every `duration`")
+	existing := fs.Bool("existing", false, "report the sessions present at startup as logon events")
+	explicit := fs.Bool("explicit-credentials", false, "also report credentials used explicitly by processes of a session, from 4648 Security log events")
+	privileges := fs.Bool("privileges", false, "flag logons with special privileges from their 4672 Security log event")
+	eventSource := fs.String("eventlog", "", "also write events to the event log under `source`, see \"winlsa eventlog install\"")
+	webhook := fs.String("webhook", "", "also POST each event as JSON to `url`")
+	secret := fs.String("webhook-secret", "", "sign webhook requests with HMAC-SHA256 using `key`; defaults to $WINLSA_WEBHOOK_SECRET")
+	retries := fs.Int("webhook-retries", 5, "retry failed webhook and syslog deliveries `n` times")
+	syslogTarget := fs.String("syslog", "", "also send events to the syslog collector at `url`, tcp://host:port or tls://host:port")
+	syslogCA := fs.String("syslog-ca", "", "verify the collector's TLS certificate against the CA certificates in `file`")
+	historyDB := fs.String("history", "", "also record events in the history database `file`, see \"winlsa history\"")
+	syslogFormat := fs.String("syslog-format", "json", "syslog message `format`: json, ecs or cef")
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	var sinks []eventSink
+	defer func() {
+		for _, s := range sinks {
+			s.Close()
+		}
+	}()
+	if *eventSource != "" {
+		s, err := newEventlogSink(*eventSource)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, s)
+	}
+	if *webhook != "" {
+		if *secret == "" {
+			*secret = os.Getenv("WINLSA_WEBHOOK_SECRET")
+		}
+		sinks = append(sinks, newWebhookSink(*webhook, *secret, *retries))
+	}
+	if *syslogTarget != "" {
+		switch *syslogFormat {
+		case "json", "ecs", "cef":
+		default:
+			return usagef("-syslog-format must be one of json, ecs, cef")
+		}
+		s, err := newSyslogSink(*syslogTarget, *syslogCA, *syslogFormat, *retries)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, s)
+	}
+
+	if *historyDB != "" {
+		store, err := openHistory(*historyDB)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, historySink{store})
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	w, err := winlsa.WatchContext(ctx, winlsa.WatchOptions{Interval: *interval, Existing: *existing, SpecialPrivileges: *privileges, ExplicitCredentials: *explicit})
+	if err != nil {
+		return fmt.Errorf("Watch: %v", err)
+	}
+
+	for ev := range w.Events() {
+		err := writeWatchEvent(out, ev)
+		if err != nil {
+			w.Close()
+			return err
+		}
+		for _, s := range sinks {
+			err := s.send(ev)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "winlsa:", err)
+			}
+		}
+	}
+	return nil
+}
+
+// writeWatchEvent writes ev in the selected output format, which for the
+// watch command may also be an ECS document or a CEF record.
+func writeWatchEvent(out *output, ev winlsa.SessionEvent) error {
+	if out.tmpl == nil {
+		switch out.format {
+		case "ecs":
+			return out.encodeJSON(siem.ECS(ev), "")
+		case "cef":
+			_, err := fmt.Fprintln(out.w, siem.CEF(ev))
+			return err
+		}
+	}
+	return out.object(ev, func(w io.Writer) error {
+		return writeSessionEvent(w, ev)
+	})
+}
+
+// An eventSink forwards session events to a destination other than the
+// standard output.
+type eventSink interface {
+	send(ev winlsa.SessionEvent) error
+	Close() error
+}
+
+// historySink records session events in a history database.
+type historySink struct {
+	*history.Store
+}
+
+func (s historySink) send(ev winlsa.SessionEvent) error {
+	return s.Record(ev)
+}
+
+func writeSessionEvent(w io.Writer, ev winlsa.SessionEvent) error {
+	user, logonType, pkg := "-", "-", "-"
+	if ev.Data != nil {
+		user = accountName(ev.Data.LogonDomain, ev.Data.UserName)
+		logonType = ev.Data.LogonType.String()
+		pkg = ev.Data.AuthenticationPackage
+	}
+	extra := ""
+	if ev.AdminLogon {
+		extra = " admin"
+	}
+	if ec := ev.ExplicitCredentials; ec != nil {
+		extra = fmt.Sprintf(" -> %s@%s (%s)", accountName(ec.TargetDomainName, ec.TargetUserName), ec.TargetServerName, ec.ProcessName)
+	}
+	_, err := fmt.Fprintf(w, "%s %-6s %v %s %s %s%s\n", ev.Time.Local().Format(time.RFC3339), ev.Type, ev.LogonId, user, logonType, pkg, extra)
+	return err
+}
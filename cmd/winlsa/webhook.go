@@ -0,0 +1,112 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+// webhookSink POSTs session events as JSON. Events are delivered in order
+// by a background goroutine so that retries, which use exponential
+// backoff, do not hold up the watch output. With a secret, the body is
+// signed with HMAC-SHA256 in the X-Winlsa-Signature header as
+// "sha256=<hex>".
+type webhookSink struct {
+	url     string
+	secret  []byte
+	retries int
+	client  *http.Client
+	queue   chan []byte
+	done    chan struct{}
+}
+
+func newWebhookSink(url, secret string, retries int) *webhookSink {
+	s := &webhookSink{
+		url:     url,
+		secret:  []byte(secret),
+		retries: retries,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		queue:   make(chan []byte, 256),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *webhookSink) send(ev winlsa.SessionEvent) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	select {
+	case s.queue <- body:
+		return nil
+	default:
+		return fmt.Errorf("webhook: queue full, dropping %v event of session %v", ev.Type, ev.LogonId)
+	}
+}
+
+func (s *webhookSink) run() {
+	defer close(s.done)
+	for body := range s.queue {
+		err := s.deliver(body)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "winlsa:", err)
+		}
+	}
+}
+
+func (s *webhookSink) deliver(body []byte) error {
+	var err error
+	backoff := time.Second
+	for attempt := 0; ; attempt++ {
+		err = s.post(body)
+		if err == nil || attempt >= s.retries {
+			return err
+		}
+		time.Sleep(backoff)
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+func (s *webhookSink) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.secret) > 0 {
+		mac := hmac.New(sha256.New, s.secret)
+		mac.Write(body)
+		req.Header.Set("X-Winlsa-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook: %s", resp.Status)
+	}
+	return nil
+}
+
+// Close waits for the queued events to be delivered.
+func (s *webhookSink) Close() error {
+	close(s.queue)
+	<-s.done
+	return nil
+}
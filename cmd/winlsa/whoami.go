@@ -0,0 +1,161 @@
+//go:build windows
+// +build windows
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/policy"
+)
+
+// whoamiGroup is a token group with its resolved name. winlsa.Group is not
+// embedded as its MarshalJSON method would hide the other fields.
+type whoamiGroup struct {
+	Sid        string
+	Name       string `json:",omitempty"`
+	Type       policy.SidNameUse
+	Attributes uint32
+}
+
+// whoamiReport is the result of "winlsa whoami".
+type whoamiReport struct {
+	User           string
+	Session        *winlsa.LogonSessionData
+	IntegrityLevel winlsa.IntegrityLevel
+	Elevated       bool
+	ElevationType  winlsa.ElevationType
+	Groups         []whoamiGroup
+	Privileges     []winlsa.Privilege
+	// Origin is the logon session that created Session, if recorded.
+	Origin *winlsa.LUID `json:",omitempty"`
+}
+
+var groupAttributeNames = []struct {
+	attr uint32
+	name string
+}{
+	{windows.SE_GROUP_MANDATORY, "Mandatory group"},
+	{windows.SE_GROUP_ENABLED_BY_DEFAULT, "Enabled by default"},
+	{windows.SE_GROUP_ENABLED, "Enabled group"},
+	{windows.SE_GROUP_OWNER, "Group owner"},
+	{windows.SE_GROUP_USE_FOR_DENY_ONLY, "Group used for deny only"},
+	{windows.SE_GROUP_INTEGRITY, "Integrity"},
+	{windows.SE_GROUP_INTEGRITY_ENABLED, "Integrity enabled"},
+	{windows.SE_GROUP_RESOURCE, "Local group"},
+	{windows.SE_GROUP_LOGON_ID, "Logon ID"},
+}
+
+// groupAttributes describes attrs in the words of whoami.exe.
+func groupAttributes(attrs uint32) string {
+	var names []string
+	for _, a := range groupAttributeNames {
+		if attrs&a.attr == a.attr {
+			names = append(names, a.name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func runWhoami(args []string) error {
+	fs := newFlagSet("winlsa whoami", "")
+	out := addOutputFlag(fs)
+	luidFlag := fs.String("luid", "", "show logon session `luid`, using the token of one of its processes")
+	err := parseFlags(fs, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	var info *winlsa.TokenInfo
+	if *luidFlag != "" {
+		luid, err := parseSessionFlag(*luidFlag)
+		if err != nil {
+			return err
+		}
+		ensurePrivileges(winlsa.PrivilegeDebug)
+		info, err = winlsa.GetSessionTokenInfo(luid)
+		if err != nil {
+			return fmt.Errorf("GetSessionTokenInfo: %v", err)
+		}
+	} else {
+		info, err = winlsa.GetCurrentTokenInfo()
+		if err != nil {
+			return fmt.Errorf("GetCurrentTokenInfo: %v", err)
+		}
+	}
+	session, err := winlsa.GetLogonSessionData(&info.LogonId)
+	if err != nil {
+		return fmt.Errorf("GetLogonSessionData: %v", err)
+	}
+	report := whoamiReport{
+		Session:        session,
+		IntegrityLevel: info.IntegrityLevel,
+		Elevated:       info.Elevated,
+		ElevationType:  info.ElevationType,
+		Privileges:     info.Privileges,
+	}
+	if info.OriginLogonId != (winlsa.LUID{}) {
+		report.Origin = &info.OriginLogonId
+	}
+
+	p, err := openPolicy("", policy.AccessLookupNames)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+	sids := []*windows.SID{info.User}
+	for _, g := range info.Groups {
+		sids = append(sids, g.Sid)
+	}
+	names, err := p.LookupSids(sids, 0)
+	if err != nil {
+		return fmt.Errorf("LsaLookupSids2: %v", err)
+	}
+	report.User = info.User.String()
+	if names[0].Use.Mapped() {
+		report.User = names[0].AccountName()
+	}
+	for idx, g := range info.Groups {
+		group := whoamiGroup{Sid: g.Sid.String(), Type: names[idx+1].Use, Attributes: g.Attributes}
+		if group.Type.Mapped() {
+			group.Name = names[idx+1].AccountName()
+		}
+		report.Groups = append(report.Groups, group)
+	}
+
+	return out.object(report, func(w io.Writer) error {
+		fmt.Fprintf(w, "%-22s %s\n", "User:", report.User)
+		printSessionData(w, report.Session)
+		if report.Origin != nil {
+			fmt.Fprintf(w, "%-22s %v\n", "OriginLogonId:", *report.Origin)
+		}
+		fmt.Fprintf(w, "%-22s %v\n", "IntegrityLevel:", report.IntegrityLevel)
+		fmt.Fprintf(w, "%-22s %v (%v)\n", "Elevated:", report.Elevated, report.ElevationType)
+
+		fmt.Fprintln(w, "\nGroups:")
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tTYPE\tSID\tATTRIBUTES")
+		for _, g := range report.Groups {
+			fmt.Fprintf(tw, "%s\t%v\t%s\t%s\n", g.Name, g.Type, g.Sid, groupAttributes(g.Attributes))
+		}
+		tw.Flush()
+
+		fmt.Fprintln(w, "\nPrivileges:")
+		tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tSTATE")
+		for _, priv := range report.Privileges {
+			state := "Disabled"
+			if priv.Enabled() {
+				state = "Enabled"
+			}
+			fmt.Fprintf(tw, "%s\t%s\n", priv.Name, state)
+		}
+		return tw.Flush()
+	})
+}
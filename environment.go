@@ -0,0 +1,56 @@
+package winlsa
+
+import (
+	"strings"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// A UserEnvironment is the environment the processes of a user start
+// with, as Windows builds it at logon.
+type UserEnvironment struct {
+	// ProfileDirectory is the root of the user's profile, the
+	// %USERPROFILE% of Env.
+	ProfileDirectory string
+	// Env holds the variables as "name=value" strings, e.g. for
+	// exec.Cmd.Env.
+	Env []string
+}
+
+// Lookup returns the value of the variable name, which is matched without
+// regard to case like Windows does.
+func (e *UserEnvironment) Lookup(name string) (string, bool) {
+	for _, kv := range e.Env {
+		if kv == "" {
+			continue
+		}
+		// The names of the per-drive directories start with "=", e.g.
+		// "=C:=C:\".
+		if i := strings.IndexByte(kv[1:], '=') + 1; i > 0 && strings.EqualFold(kv[:i], name) {
+			return kv[i+1:], true
+		}
+	}
+	return "", false
+}
+
+// GetUserEnvironment builds the environment of the user of token with
+// CreateEnvironmentBlock, without inheriting the caller's variables, so
+// that a service starting a process for the user sets %USERPROFILE%,
+// %APPDATA% and the user's own variables correctly. token needs
+// TOKEN_QUERY and TOKEN_DUPLICATE access. The variables stored in the
+// user's registry hive, including %APPDATA%, are only included while the
+// user's profile is loaded, as it is during an interactive logon.
+func GetUserEnvironment(token Token) (*UserEnvironment, error) {
+	return userEnvironment(token)
+}
+
+// GetSessionEnvironment is like GetUserEnvironment for the token of a
+// process running in the logon session luid, see OpenSessionToken.
+func GetSessionEnvironment(luid LUID) (*UserEnvironment, error) {
+	token, err := openSessionToken(luid, lsa.TOKEN_QUERY|lsa.TOKEN_DUPLICATE)
+	if err != nil {
+		return nil, err
+	}
+	defer token.Close()
+	return userEnvironment(token)
+}
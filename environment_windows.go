@@ -0,0 +1,38 @@
+package winlsa
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+func userEnvironment(token windows.Token) (*UserEnvironment, error) {
+	profile, err := token.GetUserProfileDirectory()
+	if err != nil {
+		return nil, err
+	}
+	var block *uint16
+	err = windows.CreateEnvironmentBlock(&block, token, false)
+	if err != nil {
+		return nil, err
+	}
+	defer windows.DestroyEnvironmentBlock(block)
+	return &UserEnvironment{ProfileDirectory: profile, Env: environmentStrings(block)}, nil
+}
+
+// environmentStrings splits an environment block, a sequence of
+// NUL-terminated strings ended by an empty one. Token.Environ of x/sys
+// advances by the UTF-8 length of each string and so misreads blocks with
+// non-ASCII values, e.g. a profile directory named after the user.
+func environmentStrings(block *uint16) []string {
+	var env []string
+	for p := unsafe.Pointer(block); *(*uint16)(p) != 0; {
+		n := 0
+		for *(*uint16)(unsafe.Add(p, 2*n)) != 0 {
+			n++
+		}
+		env = append(env, windows.UTF16ToString(unsafe.Slice((*uint16)(p), n)))
+		p = unsafe.Add(p, 2*(n+1))
+	}
+	return env
+}
@@ -0,0 +1,51 @@
+package winlsa
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// A FreeBufferError is returned together with valid results when the LSA
+// buffer they were decoded from could not be freed, which leaks the buffer
+// but loses no data. Builds with the winlsadebug tag panic instead. The
+// bulk functions, watchers and caches treat it as success.
+type FreeBufferError = lsa.FreeBufferError
+
+// IsFreeBufferError reports whether err is a *FreeBufferError, i.e. the
+// results returned with it are valid. It also recognizes the errors of the
+// kerberos and policy packages.
+func IsFreeBufferError(err error) bool {
+	return lsa.IsFreeBufferError(err)
+}
+
+// SessionErrors maps logon sessions to the errors querying them failed
+// with. The bulk functions return it alongside their results when only
+// some sessions failed, so that one inaccessible session does not hide the
+// others; use a type assertion to tell it from a failure of the whole call.
+type SessionErrors map[LUID]error
+
+func (e SessionErrors) Error() string {
+	luids := make([]LUID, 0, len(e))
+	for luid := range e {
+		luids = append(luids, luid)
+	}
+	sort.Slice(luids, func(i, j int) bool {
+		return luidValue(luids[i]) < luidValue(luids[j])
+	})
+	msgs := make([]string, len(luids))
+	for idx, luid := range luids {
+		msgs[idx] = fmt.Sprintf("session %v: %v", luid, e[luid])
+	}
+	noun := "sessions"
+	if len(luids) == 1 {
+		noun = "session"
+	}
+	return fmt.Sprintf("querying %d %s failed: %s", len(luids), noun, strings.Join(msgs, "; "))
+}
+
+func luidValue(luid LUID) uint64 {
+	return uint64(uint32(luid.HighPart))<<32 | uint64(luid.LowPart)
+}
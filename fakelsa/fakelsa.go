@@ -0,0 +1,155 @@
+// Package fakelsa is an in-memory winlsa.Provider for testing code that
+// consumes logon sessions without a Windows host:
+//
+//	f := fakelsa.New(&winlsa.LogonSessionData{LogonId: luid, UserName: "alice"})
+//	winlsa.SetProvider(f)
+//	defer winlsa.SetProvider(nil)
+//
+// Sessions can be added and removed while a winlsa.Watcher polls, and
+// errors can be injected for the enumeration or for single sessions.
+package fakelsa
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+// LUID is the same type as winlsa.LUID.
+type LUID = winlsa.LUID
+
+// An LSA holds a list of logon sessions. It is safe for concurrent use.
+type LSA struct {
+	mu           sync.Mutex
+	sessions     []*winlsa.LogonSessionData
+	errs         map[LUID]error
+	enumerateErr error
+}
+
+// New returns an LSA with the given sessions, which are enumerated in
+// order.
+func New(sessions ...*winlsa.LogonSessionData) *LSA {
+	f := &LSA{errs: map[LUID]error{}}
+	for _, sd := range sessions {
+		f.Add(sd)
+	}
+	return f
+}
+
+// Add adds sd, replacing the session with the same LogonId, if any.
+func (f *LSA) Add(sd *winlsa.LogonSessionData) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for idx, old := range f.sessions {
+		if old.LogonId == sd.LogonId {
+			f.sessions[idx] = sd
+			return
+		}
+	}
+	f.sessions = append(f.sessions, sd)
+}
+
+// Remove removes the session luid, as if it logged off, and reports
+// whether it existed.
+func (f *LSA) Remove(luid LUID) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for idx, sd := range f.sessions {
+		if sd.LogonId == luid {
+			f.sessions = append(f.sessions[:idx], f.sessions[idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// SetError makes queries of the session luid fail with err; a nil err
+// clears the error. The session is still enumerated, and luid need not
+// be a session added to f.
+func (f *LSA) SetError(luid LUID, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err == nil {
+		delete(f.errs, luid)
+		return
+	}
+	f.errs[luid] = err
+}
+
+// SetEnumerateError makes AppendLogonSessions fail with err; a nil err
+// clears the error.
+func (f *LSA) SetEnumerateError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.enumerateErr = err
+}
+
+// AppendLogonSessions implements winlsa.Provider. The LUIDs of sessions
+// with an error set by SetError are included.
+func (f *LSA) AppendLogonSessions(dst []LUID) ([]LUID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.enumerateErr != nil {
+		return dst, f.enumerateErr
+	}
+	seen := make(map[LUID]bool, len(f.sessions))
+	for _, sd := range f.sessions {
+		dst = append(dst, sd.LogonId)
+		seen[sd.LogonId] = true
+	}
+	for luid := range f.errs {
+		if !seen[luid] {
+			dst = append(dst, luid)
+		}
+	}
+	return dst, nil
+}
+
+// GetLogonSessionData implements winlsa.Provider. It returns a copy of the
+// session with the fields not selected by opts cleared, as the LSA
+// provider leaves them zero, or winlsa.ErrNoSuchLogonSession.
+func (f *LSA) GetLogonSessionData(luid LUID, opts winlsa.GetLogonSessionDataOpts) (*winlsa.LogonSessionData, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err, ok := f.errs[luid]; ok {
+		return nil, err
+	}
+	for _, sd := range f.sessions {
+		if sd.LogonId == luid {
+			return selectFields(sd, opts.Fields), nil
+		}
+	}
+	return nil, winlsa.ErrNoSuchLogonSession
+}
+
+func selectFields(sd *winlsa.LogonSessionData, fields winlsa.SessionField) *winlsa.LogonSessionData {
+	c := *sd
+	if fields&winlsa.SessionFieldSid == 0 {
+		c.Sid = nil
+	}
+	if fields&winlsa.SessionFieldDomain == 0 {
+		c.LogonServer, c.DnsDomainName, c.Upn = "", "", ""
+	}
+	if fields&winlsa.SessionFieldProfile == 0 {
+		c.LogonScript, c.ProfilePath, c.HomeDirectory, c.HomeDirectoryDrive = "", "", "", ""
+	}
+	if fields&winlsa.SessionFieldPolicy == 0 {
+		var zero time.Time
+		c.UserFlags = 0
+		c.LastSuccessfulLogon, c.LastFailedLogon = zero, zero
+		c.FailedAttemptCountSinceLastSuccessfulLogon = 0
+		c.LogoffTime, c.KickOffTime = zero, zero
+		c.PasswordLastSet, c.PasswordCanChange, c.PasswordMustChange = zero, zero, zero
+	}
+	if fields&winlsa.SessionFieldRawTimes == 0 {
+		c.RawTimes = nil
+	}
+	if fields&winlsa.SessionFieldResolvedAccount == 0 {
+		c.ResolvedAccount = nil
+	}
+	if fields&winlsa.SessionFieldRemoteOrigin == 0 {
+		c.RemoteOrigin = nil
+	}
+	return &c
+}
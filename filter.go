@@ -0,0 +1,97 @@
+package winlsa
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// A SessionFilter selects logon sessions. Zero-valued fields match every
+// session; string comparisons are case-insensitive.
+type SessionFilter struct {
+	// LogonTypes matches sessions of any of the listed types.
+	LogonTypes            []LogonType
+	UserName              string
+	LogonDomain           string
+	AuthenticationPackage string
+	// Since matches sessions that logged on at or after the given time.
+	Since time.Time
+	// Anonymous matches only ANONYMOUS LOGON sessions, see IsAnonymous.
+	Anonymous bool
+}
+
+// Match reports whether sd is selected by f.
+func (f *SessionFilter) Match(sd *LogonSessionData) bool {
+	if len(f.LogonTypes) > 0 {
+		found := false
+		for _, lt := range f.LogonTypes {
+			if sd.LogonType == lt {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.UserName != "" && !strings.EqualFold(f.UserName, sd.UserName) {
+		return false
+	}
+	if f.LogonDomain != "" && !strings.EqualFold(f.LogonDomain, sd.LogonDomain) {
+		return false
+	}
+	if f.AuthenticationPackage != "" && !strings.EqualFold(f.AuthenticationPackage, sd.AuthenticationPackage) {
+		return false
+	}
+	if !f.Since.IsZero() && sd.LogonTime.Before(f.Since) {
+		return false
+	}
+	if f.Anonymous && !sd.IsAnonymous() {
+		return false
+	}
+	return true
+}
+
+// FindLogonSessions returns the data of all logon sessions matched by f.
+// Sessions that end between enumeration and query are skipped. If other
+// sessions cannot be queried, the matching sessions are returned with a
+// SessionErrors error.
+func FindLogonSessions(f SessionFilter) ([]*LogonSessionData, error) {
+	return FindLogonSessionsContext(context.Background(), f)
+}
+
+// FindLogonSessionsContext is like FindLogonSessions but returns ctx.Err()
+// if ctx is done before all sessions are queried.
+func FindLogonSessionsContext(ctx context.Context, f SessionFilter) (_ []*LogonSessionData, err error) {
+	ctx, op := lsa.StartOp(ctx, "winlsa.FindLogonSessions", nil)
+	defer func() { op.End(err) }()
+
+	luids, err := appendSessions(ctx, nil)
+	if err != nil && !IsFreeBufferError(err) {
+		return nil, err
+	}
+	var sessions []*LogonSessionData
+	errs := SessionErrors{}
+	for _, luid := range luids {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		sd, err := sessionData(ctx, luid, GetLogonSessionDataOpts{Fields: SessionFieldAll})
+		if err == ErrNoSuchLogonSession {
+			continue
+		}
+		if err != nil && !IsFreeBufferError(err) {
+			errs[luid] = err
+			continue
+		}
+		if f.Match(sd) {
+			sessions = append(sessions, sd)
+		}
+	}
+	if len(errs) > 0 {
+		return sessions, errs
+	}
+	return sessions, nil
+}
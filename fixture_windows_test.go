@@ -0,0 +1,172 @@
+package winlsa
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/fixture"
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func init() {
+	fixture.RegisterDecoder(fixture.KindLogonSessionData, func(p unsafe.Pointer) interface{} {
+		return newLogonSessionData((*lsa.SECURITY_LOGON_SESSION_DATA)(p), SessionFieldAll|SessionFieldRawTimes)
+	})
+}
+
+// TestCaptureFixtures writes a LogonSessionData fixture of every logon
+// session to testdata/fixtures when run with -capture.
+func TestCaptureFixtures(t *testing.T) {
+	if !fixture.Capturing() {
+		t.Skip("run with -capture to capture fixtures")
+	}
+	luids, err := GetLogonSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, luid := range luids {
+		var data *lsa.SECURITY_LOGON_SESSION_DATA
+		err := lsa.LsaGetLogonSessionData(&luid, &data)
+		if errors.Is(err, ErrNoSuchLogonSession) {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("session %v: %v", luid, err)
+		}
+		f, err := fixture.Capture(fixture.KindLogonSessionData, unsafe.Pointer(data))
+		lsa.LsaFreeReturnBuffer(uintptr(unsafe.Pointer(data)))
+		if err != nil {
+			t.Fatalf("session %v: %v", luid, err)
+		}
+		fixture.Write(t, f, luid.String())
+	}
+}
+
+// TestFixtures decodes the LogonSessionData fixtures of the running
+// architecture and compares them with their goldens.
+func TestFixtures(t *testing.T) {
+	fixture.CheckAll(t, fixture.KindLogonSessionData)
+}
+
+const (
+	testSid       = "S-1-5-21-1004336348-1177238915-682003330-1104"
+	testLogonTime = 133500000000000000
+	testPwdLast   = 133400000000000000
+)
+
+// newSessionBuffer returns a session buffer as Windows returns it, or with
+// the fields after Upn missing if preVista is set.
+func newSessionBuffer(t *testing.T, preVista bool) *fixture.Buffer {
+	b := fixture.NewBuffer(unsafe.Sizeof(lsa.SECURITY_LOGON_SESSION_DATA{}))
+	d := (*lsa.SECURITY_LOGON_SESSION_DATA)(b.Pointer())
+	d.Size = uint32(unsafe.Sizeof(*d))
+	if preVista {
+		d.Size = uint32(unsafe.Offsetof(d.UserFlags))
+	}
+	d.LogonId = LUID{LowPart: 0x3e7a1}
+	d.UserName = b.String("alice")
+	d.LogonDomain = b.String("CONTOSO")
+	d.AuthenticationPackage = b.String("Kerberos")
+	d.LogonType = uint32(LogonTypeInteractive)
+	d.Session = 1
+	sid, err := b.Sid(testSid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Sid = sid
+	d.LogonTime = testLogonTime
+	d.LogonServer = b.String("DC01")
+	d.DnsDomainName = b.String("CONTOSO.COM")
+	d.Upn = b.String("alice@contoso.com")
+	if !preVista {
+		d.UserFlags = 0x20
+		d.ProfilePath = b.String(`\\fs01\profiles\alice`)
+		d.HomeDirectoryDrive = b.String("H:")
+		d.PasswordLastSet = testPwdLast
+	}
+	return b
+}
+
+// TestSessionRoundTrip captures a session buffer, reloads it after the
+// original is cleared, and checks the decoded fields against the values the
+// buffer was built from.
+func TestSessionRoundTrip(t *testing.T) {
+	for _, preVista := range []bool{false, true} {
+		t.Run(fmt.Sprintf("preVista=%v", preVista), func(t *testing.T) {
+			b := newSessionBuffer(t, preVista)
+			f, err := fixture.Capture(fixture.KindLogonSessionData, b.Pointer())
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.Clear()
+			if err := f.Check(); err != nil {
+				t.Fatal(err)
+			}
+			p, err := f.Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			v, err := fixture.Decode(fixture.KindLogonSessionData, p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkSession(t, v.(*LogonSessionData), preVista)
+		})
+	}
+}
+
+func checkSession(t *testing.T, sd *LogonSessionData, preVista bool) {
+	t.Helper()
+	strs := []struct{ name, got, want string }{
+		{"UserName", sd.UserName, "alice"},
+		{"LogonDomain", sd.LogonDomain, "CONTOSO"},
+		{"AuthenticationPackage", sd.AuthenticationPackage, "Kerberos"},
+		{"LogonServer", sd.LogonServer, "DC01"},
+		{"DnsDomainName", sd.DnsDomainName, "CONTOSO.COM"},
+		{"Upn", sd.Upn, "alice@contoso.com"},
+	}
+	if preVista {
+		strs = append(strs, struct{ name, got, want string }{"ProfilePath", sd.ProfilePath, ""})
+	} else {
+		strs = append(strs,
+			struct{ name, got, want string }{"ProfilePath", sd.ProfilePath, `\\fs01\profiles\alice`},
+			struct{ name, got, want string }{"HomeDirectoryDrive", sd.HomeDirectoryDrive, "H:"})
+	}
+	for _, s := range strs {
+		if s.got != s.want {
+			t.Errorf("%s = %q, want %q", s.name, s.got, s.want)
+		}
+	}
+	if want := (LUID{LowPart: 0x3e7a1}); sd.LogonId != want {
+		t.Errorf("LogonId = %v, want %v", sd.LogonId, want)
+	}
+	if sd.LogonType != LogonTypeInteractive || sd.Session != 1 {
+		t.Errorf("LogonType, Session = %v, %d, want %v, 1", sd.LogonType, sd.Session, LogonTypeInteractive)
+	}
+	if sd.Sid == nil || sd.Sid.String() != testSid {
+		t.Errorf("Sid = %v, want %s", sd.Sid, testSid)
+	}
+	if sd.RawTimes == nil || sd.RawTimes.LogonTime != testLogonTime {
+		t.Errorf("RawTimes = %+v, want LogonTime %d", sd.RawTimes, uint64(testLogonTime))
+	}
+	if preVista {
+		if want := SessionFieldProfile | SessionFieldPolicy; sd.Unavailable != want {
+			t.Errorf("Unavailable = %v, want %v", sd.Unavailable, want)
+		}
+		if sd.UserFlags != 0 || sd.RawTimes != nil && sd.RawTimes.PasswordLastSet != 0 {
+			t.Errorf("policy fields of a pre-Vista session are set: UserFlags %#x, RawTimes %+v", sd.UserFlags, sd.RawTimes)
+		}
+		return
+	}
+	if sd.Unavailable != 0 {
+		t.Errorf("Unavailable = %v, want none", sd.Unavailable)
+	}
+	if sd.UserFlags != 0x20 {
+		t.Errorf("UserFlags = %#x, want 0x20", sd.UserFlags)
+	}
+	if sd.RawTimes != nil && sd.RawTimes.PasswordLastSet != testPwdLast {
+		t.Errorf("RawTimes.PasswordLastSet = %d, want %d", sd.RawTimes.PasswordLastSet, uint64(testPwdLast))
+	}
+}
@@ -0,0 +1,150 @@
+package winlsa
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cobraqxx/winlsa/kerberos"
+)
+
+// A WTSSession is a Terminal Services session.
+type WTSSession struct {
+	ID             uint32
+	State          WTSState
+	WinStation     string
+	LogonDomain    string
+	UserName       string
+	ConnectTime    time.Time
+	DisconnectTime time.Time
+	LastInputTime  time.Time
+	LogonTime      time.Time
+	// LogonIds are the logon sessions whose Session is ID.
+	LogonIds []LUID
+}
+
+// A SessionProcess is a process running with a token of a logon session.
+type SessionProcess struct {
+	PID       uint32
+	ParentPID uint32
+	// Name is the file name of the executable.
+	Name string
+}
+
+// Forensics is the triage document of ForensicSnapshot.
+type Forensics struct {
+	// Time is when the session list was enumerated.
+	Time     time.Time
+	Hostname string
+	// Consistent is false if sessions kept ending while the session list
+	// was taken, see TakeSnapshot.
+	Consistent  bool
+	Sessions    []*ForensicSession
+	WTSSessions []*WTSSession
+	// UnattributedProcesses are the processes whose token could not be
+	// opened, so that their logon session is unknown. Without
+	// SeDebugPrivilege, these include all processes of other users.
+	UnattributedProcesses []SessionProcess
+	// Errors describes the parts of the snapshot that could not be
+	// collected.
+	Errors []string `json:",omitempty"`
+}
+
+// A ForensicSession is a logon session with its tickets and processes.
+type ForensicSession struct {
+	Data *LogonSessionData
+	// Tickets is the ticket cache metadata of the session. The tickets of
+	// other users' sessions can only be listed with SeTcbPrivilege;
+	// TicketsError tells why they are missing.
+	Tickets      []kerberos.TicketCacheInfo
+	TicketsError string `json:",omitempty"`
+	Processes    []SessionProcess
+}
+
+// ForensicSnapshot collects, for incident response, the data of all logon
+// sessions with their Kerberos ticket metadata and processes, and the
+// Terminal Services sessions they belong to. The parts are taken right
+// after one another from a single session list, a single process list and
+// a single Terminal Services enumeration, so that they describe the same
+// moment as closely as the system allows. The result is meant to be
+// serialized with encoding/json.
+//
+// Only a failure to enumerate the logon sessions fails the snapshot; parts
+// that cannot be collected are described in Errors and TicketsError.
+func ForensicSnapshot() (*Forensics, error) {
+	snap, err := TakeSnapshot(3)
+	if err != nil {
+		return nil, err
+	}
+	f := &Forensics{Time: snap.Time, Consistent: snap.Consistent}
+	f.Hostname, _ = os.Hostname()
+	for luid, err := range snap.Errors {
+		f.Errors = append(f.Errors, fmt.Sprintf("session %v: %v", luid, err))
+	}
+
+	byLUID := make(map[LUID]*ForensicSession, len(snap.Sessions))
+	for _, sd := range snap.Sessions {
+		fs := &ForensicSession{Data: sd}
+		byLUID[sd.LogonId] = fs
+		f.Sessions = append(f.Sessions, fs)
+	}
+
+	procs, err := processList()
+	if err != nil {
+		f.Errors = append(f.Errors, fmt.Sprintf("processes: %v", err))
+	}
+	for _, p := range procs {
+		if fs := byLUID[p.luid]; p.attributed && fs != nil {
+			fs.Processes = append(fs.Processes, p.SessionProcess)
+		} else {
+			f.UnattributedProcesses = append(f.UnattributedProcesses, p.SessionProcess)
+		}
+	}
+
+	f.WTSSessions, err = wtsSessions()
+	if err != nil {
+		f.Errors = append(f.Errors, fmt.Sprintf("WTS sessions: %v", err))
+	}
+	for _, ws := range f.WTSSessions {
+		for _, fs := range f.Sessions {
+			if fs.Data.Session == ws.ID {
+				ws.LogonIds = append(ws.LogonIds, fs.Data.LogonId)
+			}
+		}
+	}
+
+	conn, err := connectKerberos()
+	if err != nil {
+		f.Errors = append(f.Errors, fmt.Sprintf("Kerberos: %v", err))
+		return f, nil
+	}
+	defer conn.Close()
+	for _, fs := range f.Sessions {
+		fs.Tickets, err = conn.QueryTicketCache(fs.Data.LogonId)
+		if err != nil {
+			fs.TicketsError = err.Error()
+		}
+	}
+	return f, nil
+}
+
+// connectKerberos opens a trusted Kerberos connection, which reaches the
+// ticket caches of all sessions, and falls back to an untrusted one
+// without SeTcbPrivilege.
+func connectKerberos() (*kerberos.Conn, error) {
+	conn, err := kerberos.ConnectTrusted("winlsa")
+	if err != nil {
+		conn, err = kerberos.Connect()
+	}
+	return conn, err
+}
+
+// A processEntry is a process and, if its token could be opened, its
+// logon session.
+type processEntry struct {
+	SessionProcess
+	luid LUID
+	// origin is the TokenOrigin of the process token, if readable.
+	origin     LUID
+	attributed bool
+}
@@ -0,0 +1,87 @@
+package winlsa
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// processList lists all processes with the logon sessions of the tokens
+// that can be opened.
+func processList() ([]processEntry, error) {
+	snap, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer windows.CloseHandle(snap)
+
+	var procs []processEntry
+	entry := windows.ProcessEntry32{Size: uint32(unsafe.Sizeof(windows.ProcessEntry32{}))}
+	for err = windows.Process32First(snap, &entry); err == nil; err = windows.Process32Next(snap, &entry) {
+		if entry.ProcessID == 0 {
+			continue
+		}
+		p := processEntry{SessionProcess: SessionProcess{
+			PID:       entry.ProcessID,
+			ParentPID: entry.ParentProcessID,
+			Name:      windows.UTF16ToString(entry.ExeFile[:]),
+		}}
+		p.luid, p.origin, p.attributed = processLogonId(entry.ProcessID)
+		procs = append(procs, p)
+	}
+	if err != windows.ERROR_NO_MORE_FILES {
+		return nil, err
+	}
+	return procs, nil
+}
+
+// processLogonId returns the logon session of the token of process pid and
+// the TokenOrigin of the token, the session that created it.
+func processLogonId(pid uint32) (luid, origin LUID, ok bool) {
+	process, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return luid, origin, false
+	}
+	defer windows.CloseHandle(process)
+	var token windows.Token
+	err = windows.OpenProcessToken(process, windows.TOKEN_QUERY, &token)
+	if err != nil {
+		return luid, origin, false
+	}
+	defer token.Close()
+	buf, err := tokenInformation(token, windows.TokenStatistics)
+	if err != nil {
+		return luid, origin, false
+	}
+	luid = (*lsa.TOKEN_STATISTICS)(unsafe.Pointer(&buf[0])).AuthenticationId
+	// The origin is optional; the session is known without it.
+	origin, _ = tokenOrigin(token)
+	return luid, origin, true
+}
+
+// wtsSessions lists the Terminal Services sessions, without their logon
+// sessions.
+func wtsSessions() ([]*WTSSession, error) {
+	var infos *windows.WTS_SESSION_INFO
+	var count uint32
+	err := windows.WTSEnumerateSessions(lsa.WTS_CURRENT_SERVER_HANDLE, 0, 1, &infos, &count)
+	if err != nil {
+		return nil, err
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(infos)))
+
+	var sessions []*WTSSession
+	for _, info := range unsafe.Slice(infos, count) {
+		s, _, err := wtsSession(info.SessionID)
+		if wtsSessionEnded(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, nil
+}
@@ -1,5 +1,21 @@
 module github.com/cobraqxx/winlsa
 
-go 1.14
+go 1.17
 
-require golang.org/x/sys v0.0.0-20201018230417-eeed37f84f13
+require (
+	github.com/Microsoft/go-winio v0.5.0
+	github.com/prometheus/client_golang v1.11.1
+	go.etcd.io/bbolt v1.3.6
+	golang.org/x/sys v0.0.0-20210603081109-ebe580a85c40
+	google.golang.org/protobuf v1.26.0
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.1.1 // indirect
+	github.com/golang/protobuf v1.5.0 // indirect
+	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
+	github.com/prometheus/client_model v0.2.0 // indirect
+	github.com/prometheus/common v0.26.0 // indirect
+	github.com/prometheus/procfs v0.6.0 // indirect
+)
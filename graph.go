@@ -0,0 +1,194 @@
+package winlsa
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// GraphNodeKind is the kind of a GraphNode.
+type GraphNodeKind string
+
+const (
+	GraphNodeUser    GraphNodeKind = "user"
+	GraphNodeSession GraphNodeKind = "session"
+	GraphNodeProcess GraphNodeKind = "process"
+)
+
+// GraphEdgeKind is the relationship a GraphEdge stands for.
+type GraphEdgeKind string
+
+const (
+	// GraphEdgeLogon links a user to its logon sessions.
+	GraphEdgeLogon GraphEdgeKind = "logon"
+	// GraphEdgeRuns links a logon session to its processes.
+	GraphEdgeRuns GraphEdgeKind = "runs"
+	// GraphEdgeCreated links a logon session to the sessions it created,
+	// from their TokenOrigin.
+	GraphEdgeCreated GraphEdgeKind = "created"
+	// GraphEdgeSpawned links a process to another logon session one of its
+	// child processes runs in, e.g. runas to the session it logged on.
+	GraphEdgeSpawned GraphEdgeKind = "spawned"
+)
+
+// A GraphNode is a user, logon session or process of a Graph. Session is
+// set for session nodes and Process for process nodes.
+type GraphNode struct {
+	ID      string
+	Kind    GraphNodeKind
+	Label   string
+	Session *LogonSessionData `json:",omitempty"`
+	Process *SessionProcess   `json:",omitempty"`
+}
+
+// A GraphEdge is a relationship of a Graph, from the node with ID From to
+// the node with ID To.
+type GraphEdge struct {
+	From string
+	To   string
+	Kind GraphEdgeKind
+}
+
+// A Graph maps the identity activity on the host: users, their logon
+// sessions, the processes running in them and the sessions those created.
+type Graph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+// SessionGraph builds the Graph of the current logon sessions. Processes
+// are attributed to sessions as by ForensicSnapshot, so processes of other
+// users need SeDebugPrivilege; sessions and their origins without an
+// accessible process are still included. Spawned edges follow the parent
+// process IDs, which Windows reuses, so a parent that exited may be
+// confused with a later process of the same ID. If some sessions cannot
+// be queried, the graph of the others is returned with a SessionErrors
+// error.
+func SessionGraph() (*Graph, error) {
+	sessions, err := GetLogonSessionsDataParallel(0)
+	if _, partial := err.(SessionErrors); err != nil && !partial {
+		return nil, err
+	}
+	procs, procErr := processList()
+	if procErr != nil {
+		return nil, procErr
+	}
+
+	g := &Graph{}
+	known := map[string]bool{}
+	addNode := func(n GraphNode) {
+		if !known[n.ID] {
+			known[n.ID] = true
+			g.Nodes = append(g.Nodes, n)
+		}
+	}
+	edges := map[GraphEdge]bool{}
+	addEdge := func(from, to string, kind GraphEdgeKind) {
+		e := GraphEdge{From: from, To: to, Kind: kind}
+		if !edges[e] && known[from] && known[to] {
+			edges[e] = true
+			g.Edges = append(g.Edges, e)
+		}
+	}
+
+	for _, sd := range sessions {
+		user := GraphNode{Kind: GraphNodeUser, Label: sd.LogonDomain + `\` + sd.UserName}
+		if sd.LogonDomain == "" {
+			user.Label = sd.UserName
+		}
+		if sd.Sid != nil {
+			user.ID = "user:" + sd.Sid.String()
+		} else {
+			user.ID = "user:" + strings.ToLower(user.Label)
+		}
+		addNode(user)
+		addNode(GraphNode{
+			ID:      sessionNodeID(sd.LogonId),
+			Kind:    GraphNodeSession,
+			Label:   fmt.Sprintf("%v %v", sd.LogonId, sd.LogonType),
+			Session: sd,
+		})
+		addEdge(user.ID, sessionNodeID(sd.LogonId), GraphEdgeLogon)
+	}
+
+	byPID := make(map[uint32]*processEntry, len(procs))
+	for i := range procs {
+		p := &procs[i]
+		if !p.attributed || !known[sessionNodeID(p.luid)] {
+			continue
+		}
+		byPID[p.PID] = p
+		proc := p.SessionProcess
+		addNode(GraphNode{
+			ID:      processNodeID(p.PID),
+			Kind:    GraphNodeProcess,
+			Label:   fmt.Sprintf("%s (%d)", p.Name, p.PID),
+			Process: &proc,
+		})
+		addEdge(sessionNodeID(p.luid), processNodeID(p.PID), GraphEdgeRuns)
+	}
+	for _, p := range byPID {
+		if p.origin != (LUID{}) && p.origin != p.luid {
+			addEdge(sessionNodeID(p.origin), sessionNodeID(p.luid), GraphEdgeCreated)
+		}
+		if parent := byPID[p.ParentPID]; parent != nil && parent.luid != p.luid {
+			addEdge(processNodeID(parent.PID), sessionNodeID(p.luid), GraphEdgeSpawned)
+		}
+	}
+	// byPID left the spawned and created edges in map order.
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Kind < b.Kind
+	})
+	return g, err
+}
+
+func sessionNodeID(luid LUID) string {
+	return "session:" + luid.String()
+}
+
+func processNodeID(pid uint32) string {
+	return fmt.Sprintf("process:%d", pid)
+}
+
+// WriteDOT writes g in the Graphviz DOT language, e.g. for
+// "dot -Tsvg".
+func (g *Graph) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph sessions {")
+	fmt.Fprintln(bw, "\trankdir=LR;")
+	for _, n := range g.Nodes {
+		shape := "box"
+		switch n.Kind {
+		case GraphNodeSession:
+			shape = "ellipse"
+		case GraphNodeProcess:
+			shape = "note"
+		}
+		fmt.Fprintf(bw, "\t%s [shape=%s label=%s];\n", dotQuote(n.ID), shape, dotQuote(n.Label))
+	}
+	for _, e := range g.Edges {
+		style := "solid"
+		if e.Kind == GraphEdgeCreated || e.Kind == GraphEdgeSpawned {
+			style = "bold"
+		}
+		fmt.Fprintf(bw, "\t%s -> %s [label=%s style=%s];\n", dotQuote(e.From), dotQuote(e.To), dotQuote(string(e.Kind)), style)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// dotQuote returns s as a DOT quoted string.
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,226 @@
+// Package history records the events of a winlsa.Watcher in an embedded
+// bbolt database and answers timeline queries about them.
+//
+//	store, err := history.Open(`C:\ProgramData\winlsa\history.db`)
+//	...
+//	for ev := range watcher.Events() {
+//		err := store.Record(ev)
+//		...
+//	}
+package history
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+var (
+	// events maps time and sequence number to a winlsa.SessionEvent.
+	eventsBucket = []byte("events")
+	// sessions maps start time and LUID to a Session.
+	sessionsBucket = []byte("sessions")
+	// open maps the LUIDs of sessions without a logoff to their key in
+	// sessions.
+	openBucket = []byte("open")
+)
+
+// A Session is the lifetime of a logon session as observed by the watcher.
+type Session struct {
+	LogonId winlsa.LUID
+	// Start is the logon time reported by the LSA, or when the logon was
+	// observed if the LSA did not report one.
+	Start time.Time
+	// End is when the logoff was observed. It is zero for sessions that
+	// had not ended when last recorded. A session whose logoff was missed
+	// ends when another session with its LUID starts, as after a reboot.
+	End time.Time
+	// Data is the session data from the logon event. It is nil if the
+	// session could not be queried.
+	Data *winlsa.LogonSessionData
+}
+
+// A Store is a session history database. It is safe for concurrent use, but
+// bbolt allows only one process to open a database at a time.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens the history database at path, creating it if needed.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{eventsBucket, sessionsBucket, openBucket} {
+			_, err := tx.CreateBucketIfNotExists(name)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Record stores ev and updates the session it concerns.
+func (s *Store) Record(ev winlsa.SessionEvent) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		events := tx.Bucket(eventsBucket)
+		seq, err := events.NextSequence()
+		if err != nil {
+			return err
+		}
+		err = events.Put(timeKey(ev.Time, seq), data)
+		if err != nil || ev.Type == winlsa.SessionExplicitCredentials {
+			return err
+		}
+
+		sessions, open := tx.Bucket(sessionsBucket), tx.Bucket(openBucket)
+		luid := luidKey(ev.LogonId)
+		start := ev.Time
+		if ev.Data != nil && !ev.Data.LogonTime.IsZero() {
+			start = ev.Data.LogonTime
+		}
+		var sess Session
+		key := open.Get(luid)
+		if key != nil {
+			err := json.Unmarshal(sessions.Get(key), &sess)
+			if err != nil {
+				return err
+			}
+			if ev.Type == winlsa.SessionLogon && reused(&sess, ev.Data) {
+				// The open session ended unobserved, e.g. before a
+				// reboot, and its LUID now names a new session.
+				sess.End = start
+				value, err := json.Marshal(sess)
+				if err != nil {
+					return err
+				}
+				err = sessions.Put(key, value)
+				if err != nil {
+					return err
+				}
+				key = nil
+			}
+		}
+		if key == nil {
+			sess = Session{LogonId: ev.LogonId, Start: start, Data: ev.Data}
+			key = timeKey(sess.Start, luidValue(ev.LogonId))
+		}
+		if ev.Type == winlsa.SessionLogoff {
+			sess.End = ev.Time
+			if sess.Data == nil {
+				sess.Data = ev.Data
+			}
+			err = open.Delete(luid)
+		} else {
+			err = open.Put(luid, key)
+		}
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(sess)
+		if err != nil {
+			return err
+		}
+		return sessions.Put(key, value)
+	})
+}
+
+// HistoryForUser returns the recorded events of the account domain\user in
+// chronological order. Names are compared case-insensitively; an empty
+// domain matches every domain. Events without session data cannot be
+// attributed and are never returned.
+func (s *Store) HistoryForUser(domain, user string) ([]winlsa.SessionEvent, error) {
+	var result []winlsa.SessionEvent
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(eventsBucket).ForEach(func(_, v []byte) error {
+			var ev winlsa.SessionEvent
+			err := json.Unmarshal(v, &ev)
+			if err != nil {
+				return err
+			}
+			if ev.Data != nil && strings.EqualFold(ev.Data.UserName, user) &&
+				(domain == "" || strings.EqualFold(ev.Data.LogonDomain, domain)) {
+				result = append(result, ev)
+			}
+			return nil
+		})
+	})
+	return result, err
+}
+
+// SessionsBetween returns the sessions that existed at some point between
+// t1 and t2, ordered by start time.
+func (s *Store) SessionsBetween(t1, t2 time.Time) ([]Session, error) {
+	var result []Session
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(sessionsBucket).Cursor()
+		end := timeKey(t2, ^uint64(0))
+		for k, v := c.First(); k != nil && bytes.Compare(k, end) <= 0; k, v = c.Next() {
+			var sess Session
+			err := json.Unmarshal(v, &sess)
+			if err != nil {
+				return err
+			}
+			if sess.End.IsZero() || !sess.End.Before(t1) {
+				result = append(result, sess)
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+// reused reports whether the logon of a session with data sd is not that of
+// the open session sess, but of a new session with the same LUID. Without a
+// logon time, the two cannot be told apart.
+func reused(sess *Session, sd *winlsa.LogonSessionData) bool {
+	if sd == nil || sd.LogonTime.IsZero() {
+		return false
+	}
+	if sess.Data != nil && !sess.Data.LogonTime.IsZero() {
+		return !sd.LogonTime.Equal(sess.Data.LogonTime)
+	}
+	// sess.Start is when the logon was observed, and so no earlier than
+	// the logon itself.
+	return sd.LogonTime.After(sess.Start)
+}
+
+// timeKey returns a key that sorts by t, then by seq.
+func timeKey(t time.Time, seq uint64) []byte {
+	key := make([]byte, 16)
+	binary.BigEndian.PutUint64(key, uint64(t.UnixNano())^(1<<63))
+	binary.BigEndian.PutUint64(key[8:], seq)
+	return key
+}
+
+func luidValue(luid winlsa.LUID) uint64 {
+	return uint64(uint32(luid.HighPart))<<32 | uint64(luid.LowPart)
+}
+
+func luidKey(luid winlsa.LUID) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, luidValue(luid))
+	return key
+}
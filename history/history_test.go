@@ -0,0 +1,246 @@
+package history_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/history"
+)
+
+var t0 = time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+
+func open(t *testing.T) *history.Store {
+	t.Helper()
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func record(t *testing.T, store *history.Store, events ...winlsa.SessionEvent) {
+	t.Helper()
+	for _, ev := range events {
+		if err := store.Record(ev); err != nil {
+			t.Fatalf("Record(%v %v): %v", ev.Type, ev.LogonId, err)
+		}
+	}
+}
+
+func session(luid uint32, domain, user string, logon time.Time) *winlsa.LogonSessionData {
+	return &winlsa.LogonSessionData{
+		LogonId:     winlsa.LUID{LowPart: luid},
+		UserName:    user,
+		LogonDomain: domain,
+		LogonTime:   logon,
+	}
+}
+
+func logon(at time.Time, sd *winlsa.LogonSessionData) winlsa.SessionEvent {
+	return winlsa.SessionEvent{Type: winlsa.SessionLogon, Time: at, LogonId: sd.LogonId, Data: sd}
+}
+
+func logoff(at time.Time, sd *winlsa.LogonSessionData) winlsa.SessionEvent {
+	return winlsa.SessionEvent{Type: winlsa.SessionLogoff, Time: at, LogonId: sd.LogonId, Data: sd}
+}
+
+func TestRecord(t *testing.T) {
+	store := open(t)
+	alice := session(0x1000, "CONTOSO", "alice", t0)
+	bob := session(0x2000, "CONTOSO", "bob", t0.Add(time.Hour))
+	record(t, store,
+		logon(t0.Add(time.Second), alice),
+		logon(t0.Add(time.Hour+time.Second), bob),
+		logoff(t0.Add(2*time.Hour), alice),
+	)
+
+	sessions, err := store.SessionsBetween(t0, t0.Add(3*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("SessionsBetween returned %d sessions, want 2", len(sessions))
+	}
+	if s := sessions[0]; s.LogonId != alice.LogonId || !s.Start.Equal(t0) ||
+		!s.End.Equal(t0.Add(2*time.Hour)) || s.Data == nil || s.Data.UserName != "alice" {
+		t.Errorf("first session is %+v, want alice's from %v to %v", s, t0, t0.Add(2*time.Hour))
+	}
+	if s := sessions[1]; s.LogonId != bob.LogonId || !s.Start.Equal(bob.LogonTime) || !s.End.IsZero() {
+		t.Errorf("second session is %+v, want bob's open session from %v", s, bob.LogonTime)
+	}
+}
+
+func TestRecordSameSession(t *testing.T) {
+	// A restarted watcher reports the sessions that are still open again.
+	store := open(t)
+	alice := session(0x1000, "CONTOSO", "alice", t0)
+	record(t, store,
+		logon(t0.Add(time.Second), alice),
+		logon(t0.Add(time.Hour), alice),
+		logoff(t0.Add(2*time.Hour), alice),
+	)
+
+	sessions, err := store.SessionsBetween(t0, t0.Add(3*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || !sessions[0].Start.Equal(t0) || !sessions[0].End.Equal(t0.Add(2*time.Hour)) {
+		t.Errorf("SessionsBetween returned %+v, want one session from %v to %v", sessions, t0, t0.Add(2*time.Hour))
+	}
+}
+
+func TestRecordReusedLUID(t *testing.T) {
+	// The logoff of 0x3e4 is never observed before the reboot, after which
+	// the new boot's 0x3e4 session logs on with another logon time.
+	store := open(t)
+	before := session(0x3e4, "CONTOSO", "OLDHOST$", t0)
+	after := session(0x3e4, "CONTOSO", "NEWHOST$", t0.Add(24*time.Hour))
+	record(t, store,
+		logon(t0.Add(time.Second), before),
+		logon(t0.Add(24*time.Hour+time.Second), after),
+	)
+
+	sessions, err := store.SessionsBetween(t0, t0.Add(48*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("SessionsBetween returned %d sessions, want 2", len(sessions))
+	}
+	if s := sessions[0]; !s.Start.Equal(t0) || !s.End.Equal(after.LogonTime) || s.Data.UserName != "OLDHOST$" {
+		t.Errorf("first session is %+v, want OLDHOST$'s from %v to %v", s, t0, after.LogonTime)
+	}
+	if s := sessions[1]; !s.Start.Equal(after.LogonTime) || !s.End.IsZero() || s.Data.UserName != "NEWHOST$" {
+		t.Errorf("second session is %+v, want NEWHOST$'s open session from %v", s, after.LogonTime)
+	}
+
+	// Only the new session is still open, and so ended by a logoff.
+	record(t, store, logoff(t0.Add(25*time.Hour), after))
+	sessions, err = store.SessionsBetween(t0.Add(24*time.Hour+time.Minute), t0.Add(48*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || sessions[0].Data.UserName != "NEWHOST$" || !sessions[0].End.Equal(t0.Add(25*time.Hour)) {
+		t.Errorf("SessionsBetween returned %+v, want NEWHOST$'s session ended at %v", sessions, t0.Add(25*time.Hour))
+	}
+}
+
+func TestRecordLogoffWithoutLogon(t *testing.T) {
+	store := open(t)
+	alice := session(0x1000, "CONTOSO", "alice", t0)
+	record(t, store, logoff(t0.Add(time.Hour), alice))
+
+	sessions, err := store.SessionsBetween(t0, t0.Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || !sessions[0].Start.Equal(t0) || !sessions[0].End.Equal(t0.Add(time.Hour)) {
+		t.Fatalf("SessionsBetween returned %+v, want one session from %v to %v", sessions, t0, t0.Add(time.Hour))
+	}
+
+	// The session is closed, so a later logon of the LUID starts another.
+	record(t, store, logon(t0.Add(2*time.Hour), session(0x1000, "CONTOSO", "bob", t0.Add(2*time.Hour))))
+	sessions, err = store.SessionsBetween(t0, t0.Add(3*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 || sessions[1].Data.UserName != "bob" || !sessions[1].End.IsZero() {
+		t.Errorf("SessionsBetween returned %+v, want alice's and bob's sessions", sessions)
+	}
+}
+
+func TestSessionsBetween(t *testing.T) {
+	store := open(t)
+	early := session(0x1000, "CONTOSO", "alice", t0)
+	late := session(0x2000, "CONTOSO", "bob", t0.Add(3*time.Hour))
+	running := session(0x3000, "CONTOSO", "carol", t0.Add(time.Hour))
+	record(t, store,
+		logon(t0, early),
+		logon(t0.Add(time.Hour), running),
+		logoff(t0.Add(time.Hour), early),
+		logon(t0.Add(3*time.Hour), late),
+	)
+
+	for _, tt := range []struct {
+		t1, t2 time.Time
+		want   []string
+	}{
+		{t0, t0.Add(4 * time.Hour), []string{"alice", "carol", "bob"}},
+		{t0.Add(2 * time.Hour), t0.Add(2 * time.Hour), []string{"carol"}},
+		{t0.Add(time.Hour), t0.Add(time.Hour), []string{"alice", "carol"}},
+		{t0.Add(-time.Hour), t0.Add(-time.Minute), nil},
+	} {
+		sessions, err := store.SessionsBetween(tt.t1, tt.t2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, s := range sessions {
+			got = append(got, s.Data.UserName)
+		}
+		if !equal(got, tt.want) {
+			t.Errorf("SessionsBetween(%v, %v) = %v, want %v", tt.t1, tt.t2, got, tt.want)
+		}
+	}
+}
+
+func TestHistoryForUser(t *testing.T) {
+	store := open(t)
+	alice := session(0x1000, "CONTOSO", "alice", t0)
+	other := session(0x2000, "FABRIKAM", "Alice", t0.Add(time.Hour))
+	bob := session(0x3000, "CONTOSO", "bob", t0.Add(time.Hour))
+	record(t, store,
+		logon(t0, alice),
+		logon(t0.Add(time.Hour), other),
+		logon(t0.Add(time.Hour), bob),
+		winlsa.SessionEvent{Type: winlsa.SessionLogon, Time: t0.Add(time.Hour), LogonId: winlsa.LUID{LowPart: 0x4000}},
+		logoff(t0.Add(2*time.Hour), alice),
+	)
+
+	for _, tt := range []struct {
+		domain, user string
+		want         []winlsa.SessionEventType
+	}{
+		{"contoso", "ALICE", []winlsa.SessionEventType{winlsa.SessionLogon, winlsa.SessionLogoff}},
+		{"", "alice", []winlsa.SessionEventType{winlsa.SessionLogon, winlsa.SessionLogon, winlsa.SessionLogoff}},
+		{"CONTOSO", "bob", []winlsa.SessionEventType{winlsa.SessionLogon}},
+		{"CONTOSO", "carol", nil},
+	} {
+		events, err := store.HistoryForUser(tt.domain, tt.user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []winlsa.SessionEventType
+		for i, ev := range events {
+			if i > 0 && ev.Time.Before(events[i-1].Time) {
+				t.Errorf("HistoryForUser(%q, %q) is not in chronological order", tt.domain, tt.user)
+			}
+			got = append(got, ev.Type)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("HistoryForUser(%q, %q) returned %v, want %v", tt.domain, tt.user, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("HistoryForUser(%q, %q) returned %v, want %v", tt.domain, tt.user, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
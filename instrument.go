@@ -0,0 +1,48 @@
+package winlsa
+
+import (
+	"context"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// A Span is an operation observed by an Instrumentation. It must provide
+// RecordError, which records that the operation failed with err, and End,
+// which marks the end of the operation.
+type Span = lsa.Span
+
+// An Attribute annotates a Span.
+type Attribute = lsa.Attribute
+
+// Instrumentation observes the operations of this module, e.g. to trace
+// them with OpenTelemetry. StartSpan is called for every high-level
+// operation, such as "winlsa.GetLogonSessions", "kerberos.QueryTicketCache"
+// or "securitylog.Query", and for every LSA call the operation makes, such
+// as "LsaEnumerateLogonSessions", with the operation's span as parent.
+//
+// The parent of an operation is the span carried by the context.Context it
+// was called with: that of an enclosing operation, such as the
+// "winlsa.Watcher.poll" of a Watcher started with WatchContext, or a span
+// stored with ContextWithSpan to join the caller's trace. Functions without
+// a context.Context, and contexts without a span, start operations with a
+// nil parent.
+//
+// Implementations must be safe for concurrent use.
+type Instrumentation = lsa.Instrumentation
+
+// SetInstrumentation installs i for all subsequent operations of this
+// module, including those of the kerberos, policy and securitylog
+// packages. A nil i disables instrumentation, which is the default.
+//
+// SetInstrumentation must not be called concurrently with other functions
+// of this module.
+func SetInstrumentation(i Instrumentation) {
+	lsa.SetInstrumentation(i)
+}
+
+// ContextWithSpan returns a copy of ctx that makes parent the parent of
+// the operations started with it, e.g. the span of the request being
+// served, as returned by the installed Instrumentation's StartSpan.
+func ContextWithSpan(ctx context.Context, parent Span) context.Context {
+	return lsa.ContextWithSpan(ctx, parent)
+}
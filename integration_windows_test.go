@@ -0,0 +1,213 @@
+//go:build windows
+// +build windows
+
+package winlsa_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/internal/lsa"
+	"github.com/cobraqxx/winlsa/s4u"
+)
+
+// The integration tests create real logon sessions and check that they are
+// enumerated and decoded, to catch struct layout regressions that fixtures
+// of a single machine miss. They only run with WINLSA_INTEGRATION=1 and
+// are configured by
+//
+//	WINLSA_INTEGRATION_USER      test account, e.g. "winlsatest" or a UPN
+//	WINLSA_INTEGRATION_PASSWORD  its password
+//	WINLSA_INTEGRATION_DOMAIN    its NetBIOS domain; "." or unset for a local account
+//	WINLSA_INTEGRATION_S4U_USER  UPN for the S4U test, on domain-joined hosts
+//
+// The interactive logon needs the account to have the right to log on
+// locally.
+
+// sessionEndTimeout is how long a session may outlive its last token.
+const sessionEndTimeout = 10 * time.Second
+
+// An expectation is what the session of a logon must look like. Empty
+// fields are not checked.
+type expectation struct {
+	user, domain, upn string
+	logonType         winlsa.LogonType
+	pkg               string
+}
+
+func integration(t *testing.T, env string) string {
+	t.Helper()
+	if os.Getenv("WINLSA_INTEGRATION") != "1" {
+		t.Skip("set WINLSA_INTEGRATION=1 to create real logon sessions")
+	}
+	v := os.Getenv(env)
+	if v == "" {
+		t.Skipf("%s is not set", env)
+	}
+	return v
+}
+
+func TestIntegrationLogonUser(t *testing.T) {
+	user := integration(t, "WINLSA_INTEGRATION_USER")
+	password := os.Getenv("WINLSA_INTEGRATION_PASSWORD")
+	domain := os.Getenv("WINLSA_INTEGRATION_DOMAIN")
+	if domain == "" && !strings.Contains(user, "@") {
+		domain = "."
+	}
+	want := expectation{user: user, domain: domain}
+	if strings.Contains(user, "@") {
+		want = expectation{upn: user}
+	} else if domain == "." {
+		want.domain, _ = windows.ComputerName()
+	}
+	for _, logon := range []struct {
+		name      string
+		logonType uint32
+		want      winlsa.LogonType
+	}{
+		{"interactive", lsa.LOGON32_LOGON_INTERACTIVE, winlsa.LogonTypeInteractive},
+		{"network", lsa.LOGON32_LOGON_NETWORK, winlsa.LogonTypeNetwork},
+	} {
+		t.Run(logon.name, func(t *testing.T) {
+			token, err := logonUser(user, domain, password, logon.logonType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := want
+			want.logonType = logon.want
+			checkSession(t, token, want)
+		})
+	}
+}
+
+func TestIntegrationS4U(t *testing.T) {
+	upn := integration(t, "WINLSA_INTEGRATION_S4U_USER")
+	res, err := s4u.Logon(upn, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSession(t, res.Token, expectation{
+		upn:       upn,
+		logonType: winlsa.LogonTypeNetwork,
+		pkg:       "Kerberos",
+	})
+}
+
+func logonUser(user, domain, password string, logonType uint32) (windows.Token, error) {
+	u, err := windows.UTF16PtrFromString(user)
+	if err != nil {
+		return 0, err
+	}
+	var d *uint16
+	if domain != "" {
+		if d, err = windows.UTF16PtrFromString(domain); err != nil {
+			return 0, err
+		}
+	}
+	p, err := windows.UTF16PtrFromString(password)
+	if err != nil {
+		return 0, err
+	}
+	var token windows.Token
+	err = lsa.LogonUser(u, d, p, logonType, lsa.LOGON32_PROVIDER_DEFAULT, &token)
+	return token, err
+}
+
+// checkSession checks the session of token against want, then closes
+// token and waits for the session to end.
+func checkSession(t *testing.T, token windows.Token, want expectation) {
+	t.Helper()
+	start := time.Now()
+	info, err := winlsa.GetTokenInfo(winlsa.Token(token))
+	if err != nil {
+		token.Close()
+		t.Fatalf("token: %v", err)
+	}
+	luid := info.LogonId
+
+	luids, err := winlsa.GetLogonSessions()
+	if err == nil && !contains(luids, luid) {
+		err = fmt.Errorf("%v is not enumerated", luid)
+	}
+	if err != nil {
+		t.Errorf("enumeration: %v", err)
+	}
+
+	sd, err := winlsa.GetLogonSessionDataWithOpts(&luid, winlsa.GetLogonSessionDataOpts{
+		Fields: winlsa.SessionFieldAll | winlsa.SessionFieldRawTimes,
+	})
+	if err == nil {
+		err = compare(sd, info, want, start)
+	}
+	if err != nil {
+		t.Errorf("session data: %v", err)
+	}
+
+	token.Close()
+	if err := waitForEnd(luid); err != nil {
+		t.Errorf("cleanup: %v", err)
+	}
+}
+
+func contains(luids []winlsa.LUID, luid winlsa.LUID) bool {
+	for _, l := range luids {
+		if l == luid {
+			return true
+		}
+	}
+	return false
+}
+
+// compare reports the first field of sd that does not match the token or
+// want. start is when the logon completed; the LSA records the logon time
+// shortly before.
+func compare(sd *winlsa.LogonSessionData, info *winlsa.TokenInfo, want expectation, start time.Time) error {
+	switch {
+	case sd.LogonId != info.LogonId:
+		return fmt.Errorf("LogonId is %v, the token's %v", sd.LogonId, info.LogonId)
+	case want.user != "" && !strings.EqualFold(sd.UserName, want.user):
+		return fmt.Errorf("UserName is %q, want %q", sd.UserName, want.user)
+	case want.domain != "" && !strings.EqualFold(sd.LogonDomain, want.domain):
+		return fmt.Errorf("LogonDomain is %q, want %q", sd.LogonDomain, want.domain)
+	case want.upn != "" && sd.Upn != "" && !strings.EqualFold(sd.Upn, want.upn):
+		return fmt.Errorf("Upn is %q, want %q", sd.Upn, want.upn)
+	case sd.UserName == "":
+		return errors.New("UserName is empty")
+	case sd.LogonType != want.logonType:
+		return fmt.Errorf("LogonType is %v, want %v", sd.LogonType, want.logonType)
+	case want.pkg != "" && !strings.EqualFold(sd.AuthenticationPackage, want.pkg):
+		return fmt.Errorf("AuthenticationPackage is %q, want %q", sd.AuthenticationPackage, want.pkg)
+	case sd.AuthenticationPackage == "":
+		return errors.New("AuthenticationPackage is empty")
+	case sd.Sid == nil || info.User == nil || sd.Sid.String() != info.User.String():
+		return fmt.Errorf("Sid is %v, the token's %v", sd.Sid, info.User)
+	case sd.LogonTime.Before(start.Add(-time.Minute)) || sd.LogonTime.After(time.Now().Add(time.Minute)):
+		return fmt.Errorf("LogonTime %v is not around %v", sd.LogonTime, start)
+	case sd.Unavailable != 0:
+		return fmt.Errorf("fields %v are unavailable on build %d", sd.Unavailable, winlsa.RuntimeCapabilities().OSBuild)
+	}
+	return nil
+}
+
+// waitForEnd waits for the session luid to end after its last token was
+// closed.
+func waitForEnd(luid winlsa.LUID) error {
+	deadline := time.Now().Add(sessionEndTimeout)
+	for {
+		_, err := winlsa.GetLogonSessionData(&luid)
+		if err == winlsa.ErrNoSuchLogonSession {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%v still exists %v after its token was closed", luid, sessionEndTimeout)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
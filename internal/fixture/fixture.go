@@ -0,0 +1,247 @@
+//go:build windows
+// +build windows
+
+// Package fixture stores raw LSA return buffers together with their decoded
+// form, so that the decoders can be checked against captured data without
+// calling the LSA. Fixtures are captured and checked by the package tests.
+//
+// A fixture holds the buffer bytes and the address the LSA returned them at.
+// All pointers of the supported structures point into the same allocation,
+// so Load copies the bytes and rebases every pointer onto the copy.
+//
+// The decoders live in the packages that use them, so their tests register
+// them with RegisterDecoder; only tests import this package.
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// The kinds of buffers a fixture can hold.
+const (
+	// KindLogonSessionData is a SECURITY_LOGON_SESSION_DATA returned by
+	// LsaGetLogonSessionData.
+	KindLogonSessionData = "LogonSessionData"
+	// KindTicketCache is a KERB_QUERY_TKT_CACHE_EX2_RESPONSE.
+	KindTicketCache = "TicketCache"
+	// KindReferencedDomains is an LSA_REFERENCED_DOMAIN_LIST returned by
+	// LsaLookupSids2 or LsaLookupNames2.
+	KindReferencedDomains = "ReferencedDomains"
+)
+
+// maxSize bounds the extent of a captured buffer, so that a pointer outside
+// the allocation is reported rather than copying unrelated memory.
+const maxSize = 1 << 20
+
+type Fixture struct {
+	Kind string
+	// Arch is the GOARCH of the capturing process. Layouts differ between
+	// architectures, so a fixture only loads on the one it was taken on.
+	Arch string
+	Base uint64
+	Data []byte
+	// Want is the JSON encoding of the decoded buffer.
+	Want json.RawMessage
+}
+
+var decoders = map[string]func(unsafe.Pointer) interface{}{}
+
+// RegisterDecoder sets the decoder for kind. The tests of the decoding
+// packages register their unexported decoders from init.
+func RegisterDecoder(kind string, decode func(unsafe.Pointer) interface{}) {
+	decoders[kind] = decode
+}
+
+// Decode decodes the buffer at p with the decoder registered for kind.
+func Decode(kind string, p unsafe.Pointer) (interface{}, error) {
+	decode, ok := decoders[kind]
+	if !ok {
+		return nil, fmt.Errorf("fixture: no decoder for %q", kind)
+	}
+	return decode(p), nil
+}
+
+// A pointer is a pointer field inside a buffer and the size of its target.
+// If the target holds pointers itself, targets returns them; it is only
+// called once field points to the target.
+type pointer struct {
+	field   *uintptr
+	size    func() uintptr
+	targets func() []pointer
+}
+
+func stringPointer(s *lsa.LSA_UNICODE_STRING) pointer {
+	return pointer{field: (*uintptr)(unsafe.Pointer(&s.Buffer)), size: func() uintptr { return uintptr(s.Length) }}
+}
+
+func sidPointer(sid **windows.SID) pointer {
+	return pointer{
+		field: (*uintptr)(unsafe.Pointer(sid)),
+		size:  func() uintptr { return 8 + 4*uintptr((*sid).SubAuthorityCount()) },
+	}
+}
+
+// layout returns the size of the fixed part of the buffer at p and its
+// pointer fields.
+func layout(kind string, p unsafe.Pointer) (uintptr, []pointer, error) {
+	switch kind {
+	case KindLogonSessionData:
+		d := (*lsa.SECURITY_LOGON_SESSION_DATA)(p)
+		ptrs := []pointer{sidPointer(&d.Sid)}
+		// Strings past the end of a pre-Vista structure are not part of it.
+		for _, s := range []*lsa.LSA_UNICODE_STRING{
+			&d.UserName, &d.LogonDomain, &d.AuthenticationPackage,
+			&d.LogonServer, &d.DnsDomainName, &d.Upn,
+			&d.LogonScript, &d.ProfilePath, &d.HomeDirectory, &d.HomeDirectoryDrive,
+		} {
+			if d.Has(unsafe.Pointer(s), unsafe.Sizeof(*s)) {
+				ptrs = append(ptrs, stringPointer(s))
+			}
+		}
+		return d.Len(), ptrs, nil
+	case KindTicketCache:
+		h := (*lsa.KERB_QUERY_TKT_CACHE_EX2_RESPONSE)(p)
+		tickets := unsafe.Slice((*lsa.KERB_TICKET_CACHE_INFO_EX2)(unsafe.Pointer(&h.Tickets)), h.CountOfTickets)
+		var ptrs []pointer
+		for i := range tickets {
+			t := &tickets[i]
+			ptrs = append(ptrs,
+				stringPointer(&t.ClientName),
+				stringPointer(&t.ClientRealm),
+				stringPointer(&t.ServerName),
+				stringPointer(&t.ServerRealm))
+		}
+		return unsafe.Sizeof(*h) + uintptr(len(tickets))*unsafe.Sizeof(lsa.KERB_TICKET_CACHE_INFO_EX2{}), ptrs, nil
+	case KindReferencedDomains:
+		l := (*lsa.LSA_REFERENCED_DOMAIN_LIST)(p)
+		domains := pointer{
+			field: (*uintptr)(unsafe.Pointer(&l.Domains)),
+			size:  func() uintptr { return uintptr(l.Entries) * unsafe.Sizeof(lsa.LSA_TRUST_INFORMATION{}) },
+			targets: func() []pointer {
+				var ptrs []pointer
+				entries := unsafe.Slice(l.Domains, l.Entries)
+				for i := range entries {
+					ptrs = append(ptrs, stringPointer(&entries[i].Name), sidPointer(&entries[i].Sid))
+				}
+				return ptrs
+			},
+		}
+		return unsafe.Sizeof(*l), []pointer{domains}, nil
+	default:
+		return 0, nil, fmt.Errorf("fixture: unknown kind %q", kind)
+	}
+}
+
+// Capture copies the buffer of the given kind at p, including everything its
+// pointers refer to, and sets Want to its decoded form. p must stay valid
+// until Capture returns.
+func Capture(kind string, p unsafe.Pointer) (*Fixture, error) {
+	size, ptrs, err := layout(kind, p)
+	if err != nil {
+		return nil, err
+	}
+	base := uintptr(p)
+	end := size
+	for i := 0; i < len(ptrs); i++ {
+		ptr := ptrs[i]
+		target := *ptr.field
+		if target == 0 {
+			continue
+		}
+		if target < base || target-base+ptr.size() > maxSize {
+			return nil, fmt.Errorf("fixture: %s pointer %#x is outside the buffer at %#x", kind, target, base)
+		}
+		if e := target - base + ptr.size(); e > end {
+			end = e
+		}
+		if ptr.targets != nil {
+			ptrs = append(ptrs, ptr.targets()...)
+		}
+	}
+	v, err := Decode(kind, p)
+	if err != nil {
+		return nil, err
+	}
+	want, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &Fixture{
+		Kind: kind,
+		Arch: runtime.GOARCH,
+		Base: uint64(base),
+		Data: append([]byte(nil), unsafe.Slice((*byte)(p), end)...),
+		Want: want,
+	}, nil
+}
+
+// Load copies the buffer into memory aligned like an LSA allocation and
+// rebases its pointers. The buffer lives as long as the returned pointer.
+func (f *Fixture) Load() (unsafe.Pointer, error) {
+	if f.Arch != runtime.GOARCH {
+		return nil, fmt.Errorf("fixture: %s fixture captured on %s cannot be loaded on %s", f.Kind, f.Arch, runtime.GOARCH)
+	}
+	if len(f.Data) == 0 {
+		return nil, fmt.Errorf("fixture: %s fixture has no data", f.Kind)
+	}
+	buf := make([]uint64, (len(f.Data)+7)/8)
+	p := unsafe.Pointer(&buf[0])
+	copy(unsafe.Slice((*byte)(p), len(f.Data)), f.Data)
+	size, ptrs, err := layout(f.Kind, p)
+	if err != nil {
+		return nil, err
+	}
+	if size > uintptr(len(f.Data)) {
+		return nil, fmt.Errorf("fixture: %s fixture is truncated", f.Kind)
+	}
+	for i := 0; i < len(ptrs); i++ {
+		ptr := ptrs[i]
+		old := uint64(*ptr.field)
+		if old == 0 {
+			continue
+		}
+		if old < f.Base || old-f.Base >= uint64(len(f.Data)) {
+			return nil, fmt.Errorf("fixture: %s pointer %#x is outside the captured buffer", f.Kind, old)
+		}
+		*ptr.field = uintptr(p) + uintptr(old-f.Base)
+		if old-f.Base+uint64(ptr.size()) > uint64(len(f.Data)) {
+			return nil, fmt.Errorf("fixture: %s pointer %#x refers past the captured buffer", f.Kind, old)
+		}
+		if ptr.targets != nil {
+			ptrs = append(ptrs, ptr.targets()...)
+		}
+	}
+	return p, nil
+}
+
+// Check loads the buffer, decodes it and compares the result with Want.
+func (f *Fixture) Check() error {
+	p, err := f.Load()
+	if err != nil {
+		return err
+	}
+	v, err := Decode(f.Kind, p)
+	if err != nil {
+		return err
+	}
+	got, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var want bytes.Buffer
+	if err := json.Compact(&want, f.Want); err != nil {
+		return err
+	}
+	if !bytes.Equal(got, want.Bytes()) {
+		return fmt.Errorf("fixture: %s decodes to\n%s\nwant\n%s", f.Kind, got, want.Bytes())
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+//go:build windows
+// +build windows
+
+package fixture_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/fixture"
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// The decoding packages register the real decoders from their tests; these
+// tests only need the server names of a ticket cache.
+func init() {
+	fixture.RegisterDecoder(fixture.KindTicketCache, func(p unsafe.Pointer) interface{} {
+		h := (*lsa.KERB_QUERY_TKT_CACHE_EX2_RESPONSE)(p)
+		var names []string
+		for _, t := range unsafe.Slice((*lsa.KERB_TICKET_CACHE_INFO_EX2)(unsafe.Pointer(&h.Tickets)), h.CountOfTickets) {
+			names = append(names, t.ServerName.String())
+		}
+		return names
+	})
+}
+
+func newTicketCache(servers ...string) *fixture.Buffer {
+	size := unsafe.Sizeof(lsa.KERB_QUERY_TKT_CACHE_EX2_RESPONSE{}) + uintptr(len(servers))*unsafe.Sizeof(lsa.KERB_TICKET_CACHE_INFO_EX2{})
+	b := fixture.NewBuffer(size)
+	h := (*lsa.KERB_QUERY_TKT_CACHE_EX2_RESPONSE)(b.Pointer())
+	h.MessageType = lsa.KerbQueryTicketCacheEx2Message
+	h.CountOfTickets = uint32(len(servers))
+	tickets := unsafe.Slice((*lsa.KERB_TICKET_CACHE_INFO_EX2)(unsafe.Pointer(&h.Tickets)), len(servers))
+	for i, server := range servers {
+		tickets[i].ClientName = b.String("alice")
+		tickets[i].ClientRealm = b.String("CONTOSO.COM")
+		tickets[i].ServerName = b.String(server)
+		tickets[i].ServerRealm = b.String("CONTOSO.COM")
+	}
+	return b
+}
+
+// TestRoundTrip captures a buffer, reloads it from its JSON encoding after
+// the original is cleared, and decodes the copy.
+func TestRoundTrip(t *testing.T) {
+	b := newTicketCache("krbtgt/CONTOSO.COM", "cifs/fs01.contoso.com")
+	f, err := fixture.Capture(fixture.KindTicketCache, b.Pointer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := json.Marshal(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Clear()
+	var loaded fixture.Fixture
+	if err := json.Unmarshal(enc, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.Check(); err != nil {
+		t.Fatal(err)
+	}
+	p, err := loaded.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := fixture.Decode(fixture.KindTicketCache, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(v.([]string), ","); got != "krbtgt/CONTOSO.COM,cifs/fs01.contoso.com" {
+		t.Errorf("decoded server names %s", got)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	f, err := fixture.Capture(fixture.KindTicketCache, newTicketCache("krbtgt/CONTOSO.COM").Pointer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name   string
+		modify func(f *fixture.Fixture)
+		want   string
+	}{
+		{"arch", func(f *fixture.Fixture) { f.Arch = "mips" }, "cannot be loaded"},
+		{"empty", func(f *fixture.Fixture) { f.Data = nil }, "no data"},
+		{"truncated", func(f *fixture.Fixture) { f.Data = f.Data[:8] }, "truncated"},
+		{"rebased", func(f *fixture.Fixture) { f.Base += 1 << 20 }, "outside the captured buffer"},
+		{"kind", func(f *fixture.Fixture) { f.Kind = "Unknown" }, "unknown kind"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			g := *f
+			g.Data = append([]byte(nil), f.Data...)
+			tt.modify(&g)
+			if _, err := g.Load(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load returned %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMismatch(t *testing.T) {
+	f, err := fixture.Capture(fixture.KindTicketCache, newTicketCache("krbtgt/CONTOSO.COM").Pointer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Want = json.RawMessage(`["krbtgt/FABRIKAM.COM"]`)
+	if err := f.Check(); err == nil {
+		t.Error("Check of a fixture with another golden succeeded")
+	}
+}
+
+func TestDecodeUnregistered(t *testing.T) {
+	if _, err := fixture.Decode(fixture.KindReferencedDomains, nil); err == nil {
+		t.Error("Decode of a kind without a decoder succeeded")
+	}
+}
@@ -0,0 +1,155 @@
+//go:build windows
+// +build windows
+
+package fixture
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+	"unicode/utf16"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// Dir is where the tests of a decoding package keep its fixtures.
+var Dir = filepath.Join("testdata", "fixtures")
+
+var capture = flag.Bool("capture", false, "capture fixtures of the running machine into testdata/fixtures")
+
+func init() {
+	// The goldens hold times; decode them in one zone wherever the
+	// fixtures are captured or checked.
+	time.Local = time.UTC
+}
+
+// Capturing reports whether the tests run with -capture, and so should
+// capture new fixtures of the running machine.
+func Capturing() bool {
+	return *capture
+}
+
+// Write writes f to Dir, named after its kind, its architecture and name.
+// The fixtures hold the account and ticket names of the capturing machine;
+// review them before committing them.
+func Write(t testing.TB, f *Fixture, name string) {
+	t.Helper()
+	if err := os.MkdirAll(Dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.MarshalIndent(f, "", "\t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(Dir, fmt.Sprintf("%s-%s-%s.json", f.Kind, f.Arch, name))
+	if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// CheckAll decodes every fixture of kind in Dir that was captured on the
+// running architecture and compares the result with its golden. It fails if
+// there is none for amd64, 386 or arm64, the architectures fixtures are
+// committed for.
+func CheckAll(t *testing.T, kind string) {
+	names, err := filepath.Glob(filepath.Join(Dir, kind+"-*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checked := 0
+	for _, name := range names {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var f Fixture
+		if err := json.Unmarshal(b, &f); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if f.Kind != kind || f.Arch != runtime.GOARCH {
+			continue
+		}
+		checked++
+		t.Run(filepath.Base(name), func(t *testing.T) {
+			if err := f.Check(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	if checked == 0 {
+		switch runtime.GOARCH {
+		case "amd64", "386", "arm64":
+			t.Fatalf("no %s fixtures for %s in %s", kind, runtime.GOARCH, Dir)
+		default:
+			t.Skipf("no %s fixtures for %s in %s", kind, runtime.GOARCH, Dir)
+		}
+	}
+}
+
+// A Buffer lays out a structure like the LSA does: the structure first,
+// followed by the strings and SIDs it points to, in one allocation.
+type Buffer struct {
+	mem []uint64
+	off uintptr
+}
+
+// NewBuffer returns a buffer for a structure of size bytes followed by up
+// to 4 KB of data.
+func NewBuffer(size uintptr) *Buffer {
+	return &Buffer{
+		mem: make([]uint64, (size+7)/8+512),
+		off: (size + 7) &^ 7,
+	}
+}
+
+// Pointer returns the start of the structure.
+func (b *Buffer) Pointer() unsafe.Pointer {
+	return unsafe.Pointer(&b.mem[0])
+}
+
+// Alloc returns n bytes of the buffer past the structure.
+func (b *Buffer) Alloc(n uintptr) unsafe.Pointer {
+	if b.off+n > uintptr(8*len(b.mem)) {
+		panic("fixture: buffer is full")
+	}
+	p := unsafe.Add(b.Pointer(), b.off)
+	b.off += (n + 7) &^ 7
+	return p
+}
+
+// String copies v into the buffer.
+func (b *Buffer) String(v string) lsa.LSA_UNICODE_STRING {
+	u := utf16.Encode([]rune(v))
+	n := uintptr(2 * len(u))
+	p := b.Alloc(n)
+	copy(unsafe.Slice((*uint16)(p), len(u)), u)
+	return lsa.LSA_UNICODE_STRING{Length: uint16(n), MaximumLength: uint16(n), Buffer: (*uint16)(p)}
+}
+
+// Sid copies the SID in string form s into the buffer.
+func (b *Buffer) Sid(s string) (*windows.SID, error) {
+	sid, err := windows.StringToSid(s)
+	if err != nil {
+		return nil, err
+	}
+	n := uintptr(windows.GetLengthSid(sid))
+	p := b.Alloc(n)
+	copy(unsafe.Slice((*byte)(p), n), unsafe.Slice((*byte)(unsafe.Pointer(sid)), n))
+	return (*windows.SID)(p), nil
+}
+
+// Clear zeroes the buffer, so that a fixture captured from it can be shown
+// to no longer refer to it.
+func (b *Buffer) Clear() {
+	for i := range b.mem {
+		b.mem[i] = 0
+	}
+}
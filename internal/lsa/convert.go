@@ -0,0 +1,78 @@
+package lsa
+
+import (
+	"errors"
+	"strings"
+	"syscall"
+	"unicode/utf16"
+	"unsafe"
+)
+
+// String decodes the UTF-16 buffer referenced by s.
+func (s LSA_UNICODE_STRING) String() string {
+	return UTF16String(s.Buffer, int(s.Length))
+}
+
+// UTF16String decodes the first size bytes of the UTF-16 buffer at p. LSA
+// strings are counted, so the buffer need not be NUL terminated and
+// embedded NULs are preserved; an odd trailing byte is ignored and
+// unpaired surrogates decode to U+FFFD.
+func UTF16String(p *uint16, size int) string {
+	if p == nil || size < 2 {
+		return ""
+	}
+	return string(utf16.Decode(unsafe.Slice(p, size/2)))
+}
+
+// NewUnicodeString returns an LSA_UNICODE_STRING referencing a freshly
+// allocated UTF-16 copy of s.
+func NewUnicodeString(s string) (LSA_UNICODE_STRING, error) {
+	buf, err := UTF16FromString(s)
+	if err != nil {
+		return LSA_UNICODE_STRING{}, err
+	}
+	size := len(buf) * 2
+	if size > 0xffff {
+		return LSA_UNICODE_STRING{}, errors.New("string too long for LSA_UNICODE_STRING")
+	}
+	return LSA_UNICODE_STRING{
+		Length:        uint16(size - 2),
+		MaximumLength: uint16(size),
+		Buffer:        &buf[0],
+	}, nil
+}
+
+// NewString returns an LSA_STRING referencing a NUL terminated copy of the
+// ANSI string s.
+func NewString(s string) (LSA_STRING, error) {
+	buf, err := byteSliceFromString(s)
+	if err != nil {
+		return LSA_STRING{}, err
+	}
+	if len(buf) > 0xffff {
+		return LSA_STRING{}, errors.New("string too long for LSA_STRING")
+	}
+	return LSA_STRING{
+		Length:        uint16(len(buf) - 1),
+		MaximumLength: uint16(len(buf)),
+		Buffer:        &buf[0],
+	}, nil
+}
+
+// UTF16FromString is windows.UTF16FromString, which is not available on
+// other platforms: it returns the NUL terminated UTF-16 encoding of s, or
+// syscall.EINVAL if s contains a NUL.
+func UTF16FromString(s string) ([]uint16, error) {
+	if strings.IndexByte(s, 0) != -1 {
+		return nil, syscall.EINVAL
+	}
+	return utf16.Encode([]rune(s + "\x00")), nil
+}
+
+// byteSliceFromString is windows.ByteSliceFromString.
+func byteSliceFromString(s string) ([]byte, error) {
+	if strings.IndexByte(s, 0) != -1 {
+		return nil, syscall.EINVAL
+	}
+	return append([]byte(s), 0), nil
+}
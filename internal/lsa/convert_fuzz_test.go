@@ -0,0 +1,62 @@
+//go:build go1.18
+// +build go1.18
+
+package lsa
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// FuzzUTF16String decodes arbitrary buffers with arbitrary byte counts. The
+// result must be valid UTF-8, keep every NUL of the counted units, and not
+// depend on anything past the count, so that buffers need not be NUL
+// terminated.
+func FuzzUTF16String(f *testing.F) {
+	f.Add([]byte("a\x00b\x00"), 4)
+	f.Add([]byte("a\x00b\x00"), 3)
+	f.Add([]byte("a\x00\x00\x00b\x00"), 6)
+	f.Add([]byte("\x3d\xd8\x00\xde"), 4)
+	f.Add([]byte("\x3d\xd8a\x00"), 4)
+	f.Add([]byte("\x00\xdc"), 1)
+	f.Fuzz(func(t *testing.T, data []byte, size int) {
+		units := make([]uint16, len(data)/2)
+		for i := range units {
+			units[i] = uint16(data[2*i]) | uint16(data[2*i+1])<<8
+		}
+		if size < 0 || size > 2*len(units) {
+			size = 2 * len(units)
+		}
+		// An exact copy of the counted units, so that reading past them
+		// is out of bounds.
+		counted := append([]uint16(nil), units[:size/2]...)
+		var p *uint16
+		if len(counted) > 0 {
+			p = &counted[0]
+		}
+		got := UTF16String(p, size)
+
+		if !utf8.ValidString(got) {
+			t.Fatalf("UTF16String(%v, %d) = %q, which is not valid UTF-8", counted, size, got)
+		}
+		nuls := 0
+		for _, u := range counted {
+			if u == 0 {
+				nuls++
+			}
+		}
+		if n := strings.Count(got, "\x00"); n != nuls {
+			t.Errorf("UTF16String(%v, %d) = %q with %d NULs, want %d", counted, size, got, n, nuls)
+		}
+		if want := string(utf16.Decode(counted)); got != want {
+			t.Errorf("UTF16String(%v, %d) = %q, want %q", counted, size, got, want)
+		}
+		if len(units) > 0 {
+			if rest := UTF16String(&units[0], size); rest != got {
+				t.Errorf("UTF16String with the uncounted units %v = %q, want %q", units[size/2:], rest, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package lsa
+
+import (
+	"testing"
+	"unicode/utf16"
+)
+
+func TestUTF16String(t *testing.T) {
+	buf := utf16.Encode([]rune("ab\x00c\U0001F600"))
+	for _, tt := range []struct {
+		name string
+		buf  []uint16
+		size int
+		want string
+	}{
+		{"nil", nil, 4, ""},
+		{"zero", buf, 0, ""},
+		{"one byte", buf, 1, ""},
+		{"odd", buf, 3, "a"},
+		{"embedded NUL", buf, 8, "ab\x00c"},
+		{"surrogate pair", buf, 12, "ab\x00c\U0001F600"},
+		{"unpaired surrogate", buf, 10, "ab\x00c\uFFFD"},
+		{"not NUL terminated", buf[:2], 4, "ab"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var p *uint16
+			if tt.buf != nil {
+				p = &tt.buf[0]
+			}
+			if got := UTF16String(p, tt.size); got != tt.want {
+				t.Errorf("UTF16String(%v, %d) = %q, want %q", tt.buf, tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnicodeStringRoundTrip(t *testing.T) {
+	for _, s := range []string{"", "Kerberos", "CONTOSO\\alice", "äö\U0001F600"} {
+		u, err := NewUnicodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := u.String(); got != s {
+			t.Errorf("NewUnicodeString(%q).String() = %q", s, got)
+		}
+	}
+}
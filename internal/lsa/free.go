@@ -0,0 +1,37 @@
+package lsa
+
+import "errors"
+
+// A FreeBufferError reports that an LSA buffer could not be freed after
+// the data in it was copied out. The data returned with it is valid; the
+// buffer is leaked.
+type FreeBufferError struct {
+	// Func is the function that failed to free the buffer,
+	// LsaFreeReturnBuffer or LsaFreeMemory.
+	Func string
+	Err  error
+}
+
+func (e *FreeBufferError) Error() string {
+	return e.Func + ": " + e.Err.Error()
+}
+
+func (e *FreeBufferError) Unwrap() error {
+	return e.Err
+}
+
+// IsFreeBufferError reports whether err is a *FreeBufferError, i.e. the
+// results returned with it are valid.
+func IsFreeBufferError(err error) bool {
+	var fe *FreeBufferError
+	return errors.As(err, &fe)
+}
+
+// freeFailed wraps the error of a failed free by fn, or panics in builds
+// with the winlsadebug tag so that the failure is not lost among warnings.
+func freeFailed(fn string, err error) error {
+	if panicOnFreeError {
+		panic(&FreeBufferError{fn, err})
+	}
+	return &FreeBufferError{fn, err}
+}
@@ -0,0 +1,6 @@
+//go:build winlsadebug
+// +build winlsadebug
+
+package lsa
+
+const panicOnFreeError = true
@@ -0,0 +1,34 @@
+//go:build !winlsadebug
+// +build !winlsadebug
+
+package lsa
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIsFreeBufferError(t *testing.T) {
+	errFree := errors.New("invalid handle")
+	fe := freeFailed("LsaFreeMemory", errFree)
+	if got, want := fe.Error(), "LsaFreeMemory: invalid handle"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(fe, errFree) {
+		t.Error("FreeBufferError does not unwrap to the failure of the free")
+	}
+	for _, tt := range []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errFree, false},
+		{fe, true},
+		{fmt.Errorf("session 0-3e7: %w", fe), true},
+	} {
+		if got := IsFreeBufferError(tt.err); got != tt.want {
+			t.Errorf("IsFreeBufferError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package lsa
+
+import "context"
+
+// Span, Attribute and Instrumentation are exported by the winlsa package,
+// which documents them; they are defined here so that the kerberos, policy
+// and securitylog packages report their operations as well.
+
+type Span interface {
+	RecordError(err error)
+	End()
+}
+
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+type Instrumentation interface {
+	StartSpan(parent Span, name string, attrs []Attribute) Span
+}
+
+var instrumentation Instrumentation
+
+// SetInstrumentation installs i for all subsequent operations; nil
+// disables instrumentation.
+func SetInstrumentation(i Instrumentation) {
+	instrumentation = i
+}
+
+// failureHook, if set, is called with every failed LSA call. luid is the
+// session the call concerned, if any.
+var failureHook func(call string, luid *LUID, err error)
+
+// SetFailureHook installs hook for all subsequent LSA calls; nil removes
+// it.
+func SetFailureHook(hook func(call string, luid *LUID, err error)) {
+	failureHook = hook
+}
+
+// An Op tracks an operation or an LSA call for the installed
+// Instrumentation and failure hook. The zero Op is a top-level operation
+// without instrumentation.
+type Op struct {
+	s    Span
+	name string
+	luid *LUID
+	call bool
+}
+
+type opKey struct{}
+
+// ContextWithSpan returns a copy of ctx in which s is the parent of the
+// operations started with it.
+func ContextWithSpan(ctx context.Context, s Span) context.Context {
+	return context.WithValue(ctx, opKey{}, Op{s: s})
+}
+
+// StartOp starts an operation, optionally concerning luid, as a child of
+// the span carried by ctx. The returned context carries the operation, so
+// that the operations it starts in turn are its children.
+func StartOp(ctx context.Context, name string, luid *LUID) (context.Context, Op) {
+	parent, _ := ctx.Value(opKey{}).(Op)
+	op := startSpan(parent.s, name, luid, false)
+	if op.s == nil {
+		return ctx, op
+	}
+	return context.WithValue(ctx, opKey{}, op), op
+}
+
+// StartCall starts an LSA call made by op.
+func (op Op) StartCall(name string) Op {
+	return startSpan(op.s, name, op.luid, true)
+}
+
+func startSpan(parent Span, name string, luid *LUID, call bool) Op {
+	op := Op{name: name, luid: luid, call: call}
+	if i := instrumentation; i != nil {
+		var attrs []Attribute
+		if luid != nil {
+			attrs = append(attrs, Attribute{Key: "winlsa.logon_id", Value: luid.String()})
+		}
+		op.s = i.StartSpan(parent, name, attrs)
+	}
+	return op
+}
+
+// End ends op with its result.
+func (op Op) End(err error) {
+	if err != nil {
+		if op.s != nil {
+			op.s.RecordError(err)
+		}
+		if hook := failureHook; op.call && hook != nil {
+			hook(op.name, op.luid, err)
+		}
+	}
+	if op.s != nil {
+		op.s.End()
+	}
+}
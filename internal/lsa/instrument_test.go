@@ -0,0 +1,92 @@
+package lsa
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type testSpan struct {
+	name   string
+	parent *testSpan
+	err    error
+	ended  bool
+}
+
+func (s *testSpan) RecordError(err error) { s.err = err }
+func (s *testSpan) End()                  { s.ended = true }
+
+type testInstrumentation struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+func (i *testInstrumentation) StartSpan(parent Span, name string, attrs []Attribute) Span {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	s := &testSpan{name: name}
+	if parent != nil {
+		s.parent = parent.(*testSpan)
+	}
+	i.spans = append(i.spans, s)
+	return s
+}
+
+func TestStartOpParent(t *testing.T) {
+	var i testInstrumentation
+	SetInstrumentation(&i)
+	defer SetInstrumentation(nil)
+	var failed []string
+	SetFailureHook(func(call string, luid *LUID, err error) { failed = append(failed, call) })
+	defer SetFailureHook(nil)
+
+	root := &testSpan{name: "request"}
+	ctx := ContextWithSpan(context.Background(), root)
+	ctx, outer := StartOp(ctx, "outer", nil)
+	_, inner := StartOp(ctx, "inner", &LUID{LowPart: 0x3e7})
+	call := inner.StartCall("LsaCall")
+	errCall := errors.New("call failed")
+	call.End(errCall)
+	inner.End(errCall)
+	outer.End(nil)
+	_, orphan := StartOp(context.Background(), "orphan", nil)
+	orphan.End(nil)
+
+	want := []struct{ name, parent string }{
+		{"outer", "request"},
+		{"inner", "outer"},
+		{"LsaCall", "inner"},
+		{"orphan", ""},
+	}
+	if len(i.spans) != len(want) {
+		t.Fatalf("started %d spans, want %d", len(i.spans), len(want))
+	}
+	for idx, w := range want {
+		s := i.spans[idx]
+		parent := ""
+		if s.parent != nil {
+			parent = s.parent.name
+		}
+		if s.name != w.name || parent != w.parent || !s.ended {
+			t.Errorf("span %d is %q with parent %q, ended %v; want %q with parent %q, ended", idx, s.name, parent, s.ended, w.name, w.parent)
+		}
+	}
+	if i.spans[2].err != errCall || i.spans[0].err != nil {
+		t.Errorf("recorded errors %v and %v, want %v and none", i.spans[2].err, i.spans[0].err, errCall)
+	}
+	// Only LSA calls are reported to the failure hook.
+	if len(failed) != 1 || failed[0] != "LsaCall" {
+		t.Errorf("failure hook called for %q, want [LsaCall]", failed)
+	}
+}
+
+func TestStartOpDisabled(t *testing.T) {
+	ctx := context.Background()
+	got, op := StartOp(ctx, "op", nil)
+	if got != ctx {
+		t.Error("StartOp without instrumentation derived a new context")
+	}
+	op.StartCall("LsaCall").End(errors.New("call failed"))
+	op.End(nil)
+}
@@ -0,0 +1,144 @@
+package lsa
+
+const (
+	KerbQueryTicketCacheMessage      = 1
+	KerbRetrieveTicketMessage        = 4
+	KerbPurgeTicketCacheMessage      = 6
+	KerbRetrieveEncodedTicketMessage = 8
+	KerbQueryTicketCacheExMessage    = 14
+	KerbPurgeTicketCacheExMessage    = 15
+	KerbQueryTicketCacheEx2Message   = 20
+	KerbSubmitTicketMessage          = 21
+	KerbQueryTicketCacheEx3Message   = 25
+	KerbRetrieveKeyTabMessage        = 34
+)
+
+type KERB_QUERY_TKT_CACHE_REQUEST struct {
+	MessageType uint32
+	LogonId     LUID
+}
+
+type KERB_TICKET_CACHE_INFO_EX2 struct {
+	ClientName     LSA_UNICODE_STRING
+	ClientRealm    LSA_UNICODE_STRING
+	ServerName     LSA_UNICODE_STRING
+	ServerRealm    LSA_UNICODE_STRING
+	StartTime      uint64
+	EndTime        uint64
+	RenewTime      uint64
+	EncryptionType int32
+	TicketFlags    uint32
+	SessionKeyType uint32
+	BranchId       uint32
+}
+
+// KERB_QUERY_TKT_CACHE_EX2_RESPONSE is followed by CountOfTickets
+// KERB_TICKET_CACHE_INFO_EX2 entries starting at Tickets.
+type KERB_QUERY_TKT_CACHE_EX2_RESPONSE struct {
+	MessageType    uint32
+	CountOfTickets uint32
+	Tickets        [0]KERB_TICKET_CACHE_INFO_EX2
+}
+
+type KERB_PURGE_TKT_CACHE_REQUEST struct {
+	MessageType uint32
+	LogonId     LUID
+	ServerName  LSA_UNICODE_STRING
+	RealmName   LSA_UNICODE_STRING
+}
+
+const (
+	KERB_RETRIEVE_TICKET_DEFAULT        = 0x0
+	KERB_RETRIEVE_TICKET_DONT_USE_CACHE = 0x1
+	KERB_RETRIEVE_TICKET_USE_CACHE_ONLY = 0x2
+	KERB_RETRIEVE_TICKET_USE_CREDHANDLE = 0x4
+	KERB_RETRIEVE_TICKET_AS_KERB_CRED   = 0x8
+	KERB_RETRIEVE_TICKET_WITH_SEC_CRED  = 0x10
+	KERB_RETRIEVE_TICKET_CACHE_TICKET   = 0x20
+	KERB_RETRIEVE_TICKET_MAX_LIFETIME   = 0x40
+)
+
+type SecHandle struct {
+	Lower uintptr
+	Upper uintptr
+}
+
+type KERB_RETRIEVE_TKT_REQUEST struct {
+	MessageType       uint32
+	LogonId           LUID
+	TargetName        LSA_UNICODE_STRING
+	TicketFlags       uint32
+	CacheOptions      uint32
+	EncryptionType    int32
+	CredentialsHandle SecHandle
+}
+
+// KERB_EXTERNAL_NAME is followed by NameCount LSA_UNICODE_STRING entries
+// starting at Names.
+type KERB_EXTERNAL_NAME struct {
+	NameType  int16
+	NameCount uint16
+	Names     [0]LSA_UNICODE_STRING
+}
+
+type KERB_CRYPTO_KEY struct {
+	KeyType int32
+	Length  uint32
+	Value   *byte
+}
+
+type KERB_EXTERNAL_TICKET struct {
+	ServiceName         *KERB_EXTERNAL_NAME
+	TargetName          *KERB_EXTERNAL_NAME
+	ClientName          *KERB_EXTERNAL_NAME
+	DomainName          LSA_UNICODE_STRING
+	TargetDomainName    LSA_UNICODE_STRING
+	AltTargetDomainName LSA_UNICODE_STRING
+	SessionKey          KERB_CRYPTO_KEY
+	TicketFlags         uint32
+	Flags               uint32
+	KeyExpirationTime   uint64
+	StartTime           uint64
+	EndTime             uint64
+	RenewUntil          uint64
+	TimeSkew            int64
+	EncodedTicketSize   uint32
+	EncodedTicket       *byte
+}
+
+type KERB_RETRIEVE_TKT_RESPONSE struct {
+	Ticket KERB_EXTERNAL_TICKET
+}
+
+// KERB_CRYPTO_KEY32 gives the key of a KERB_SUBMIT_TKT_REQUEST as an offset
+// into the request.
+type KERB_CRYPTO_KEY32 struct {
+	KeyType int32
+	Length  uint32
+	Offset  uint32
+}
+
+// KERB_SUBMIT_TKT_REQUEST is followed by the key and the KRB-CRED message
+// it refers to by offset.
+type KERB_SUBMIT_TKT_REQUEST struct {
+	MessageType    uint32
+	LogonId        LUID
+	Flags          uint32
+	Key            KERB_CRYPTO_KEY32
+	KerbCredSize   uint32
+	KerbCredOffset uint32
+}
+
+type KERB_RETRIEVE_KEY_TAB_REQUEST struct {
+	MessageType uint32
+	Flags       uint32
+	UserName    LSA_UNICODE_STRING
+	DomainName  LSA_UNICODE_STRING
+	Password    LSA_UNICODE_STRING
+}
+
+type KERB_RETRIEVE_KEY_TAB_RESPONSE struct {
+	MessageType  uint32
+	KeyTabLength uint32
+	KeyTab       *byte
+}
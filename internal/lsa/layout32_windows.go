@@ -0,0 +1,36 @@
+//go:build windows && (386 || arm)
+// +build windows
+// +build 386 arm
+
+package lsa
+
+import "unsafe"
+
+const align64Pad = 4
+
+func _() {
+	var x [1]struct{}
+	_ = x[unsafe.Offsetof(LSA_UNICODE_STRING{}.Buffer)-4]
+	_ = x[unsafe.Sizeof(LSA_UNICODE_STRING{})-8]
+
+	_ = x[unsafe.Offsetof(SECURITY_LOGON_SESSION_DATA{}.Sid)-44]
+	_ = x[unsafe.Offsetof(SECURITY_LOGON_SESSION_DATA{}.LogonTime)-48]
+	_ = x[unsafe.Offsetof(SECURITY_LOGON_SESSION_DATA{}.LastLogonInfo)-88]
+	_ = x[unsafe.Offsetof(SECURITY_LOGON_SESSION_DATA{}.LogoffTime)-144]
+	_ = x[unsafe.Sizeof(SECURITY_LOGON_SESSION_DATA{})-184]
+
+	_ = x[unsafe.Offsetof(KERB_TICKET_CACHE_INFO_EX2{}.StartTime)-32]
+	_ = x[unsafe.Offsetof(KERB_TICKET_CACHE_INFO_EX2{}.EncryptionType)-56]
+	_ = x[unsafe.Sizeof(KERB_TICKET_CACHE_INFO_EX2{})-72]
+
+	_ = x[unsafe.Offsetof(KERB_EXTERNAL_TICKET{}.KeyExpirationTime)-56]
+	_ = x[unsafe.Offsetof(KERB_EXTERNAL_TICKET{}.EncodedTicket)-100]
+	_ = x[unsafe.Sizeof(KERB_EXTERNAL_TICKET{})-104]
+
+	_ = x[unsafe.Offsetof(KERB_RETRIEVE_KEY_TAB_REQUEST{}.Password)-24]
+	_ = x[unsafe.Sizeof(KERB_RETRIEVE_KEY_TAB_REQUEST{})-32]
+	_ = x[unsafe.Sizeof(KERB_RETRIEVE_KEY_TAB_RESPONSE{})-12]
+
+	_ = x[unsafe.Offsetof(QUOTA_LIMITS{}.TimeLimit)-24]
+	_ = x[unsafe.Sizeof(QUOTA_LIMITS{})-32]
+}
@@ -0,0 +1,35 @@
+//go:build windows && !386 && !arm
+// +build windows,!386,!arm
+
+package lsa
+
+import "unsafe"
+
+const align64Pad = 0
+
+func _() {
+	var x [1]struct{}
+	_ = x[unsafe.Offsetof(LSA_UNICODE_STRING{}.Buffer)-8]
+	_ = x[unsafe.Sizeof(LSA_UNICODE_STRING{})-16]
+
+	_ = x[unsafe.Offsetof(SECURITY_LOGON_SESSION_DATA{}.Sid)-72]
+	_ = x[unsafe.Offsetof(SECURITY_LOGON_SESSION_DATA{}.LogonTime)-80]
+	_ = x[unsafe.Offsetof(SECURITY_LOGON_SESSION_DATA{}.LastLogonInfo)-144]
+	_ = x[unsafe.Offsetof(SECURITY_LOGON_SESSION_DATA{}.LogoffTime)-232]
+	_ = x[unsafe.Sizeof(SECURITY_LOGON_SESSION_DATA{})-272]
+
+	_ = x[unsafe.Offsetof(KERB_TICKET_CACHE_INFO_EX2{}.StartTime)-64]
+	_ = x[unsafe.Offsetof(KERB_TICKET_CACHE_INFO_EX2{}.EncryptionType)-88]
+	_ = x[unsafe.Sizeof(KERB_TICKET_CACHE_INFO_EX2{})-104]
+
+	_ = x[unsafe.Offsetof(KERB_EXTERNAL_TICKET{}.KeyExpirationTime)-96]
+	_ = x[unsafe.Offsetof(KERB_EXTERNAL_TICKET{}.EncodedTicket)-144]
+	_ = x[unsafe.Sizeof(KERB_EXTERNAL_TICKET{})-152]
+
+	_ = x[unsafe.Offsetof(KERB_RETRIEVE_KEY_TAB_REQUEST{}.Password)-40]
+	_ = x[unsafe.Sizeof(KERB_RETRIEVE_KEY_TAB_REQUEST{})-56]
+	_ = x[unsafe.Sizeof(KERB_RETRIEVE_KEY_TAB_RESPONSE{})-16]
+
+	_ = x[unsafe.Offsetof(QUOTA_LIMITS{}.TimeLimit)-40]
+	_ = x[unsafe.Sizeof(QUOTA_LIMITS{})-48]
+}
@@ -0,0 +1,35 @@
+package lsa
+
+import "unsafe"
+
+// The structures in this package mirror the C layouts of the Windows SDK on
+// 386, amd64 and arm64. Pointers and ULONG_PTRs follow the pointer size, but
+// Go aligns 64-bit fields to 4 bytes on 386 where C aligns LARGE_INTEGER and
+// ULONGLONG to 8, so structures with such fields carry explicit padding:
+// a uint32 where the padding is needed on all platforms anyway, or
+// align64Pad bytes where it depends on the pointer size.
+//
+// The assertions below and in layout32_windows.go and layout64_windows.go
+// fail to compile if a layout drifts from the SDK: x[n-want] is out of
+// range or overflows for any n but want.
+func _() {
+	var x [1]struct{}
+	_ = x[unsafe.Sizeof(LSA_LAST_INTER_LOGON_INFO{})-24]
+
+	_ = x[unsafe.Offsetof(KERB_QUERY_TKT_CACHE_EX2_RESPONSE{}.Tickets)-8]
+	_ = x[unsafe.Offsetof(KERB_SUBMIT_TKT_REQUEST{}.KerbCredOffset)-32]
+	_ = x[unsafe.Sizeof(KERB_SUBMIT_TKT_REQUEST{})-36]
+
+	_ = x[unsafe.Offsetof(POLICY_DOMAIN_KERBEROS_TICKET_INFO{}.MaxServiceTicketAge)-8]
+	_ = x[unsafe.Sizeof(POLICY_DOMAIN_KERBEROS_TICKET_INFO{})-48]
+
+	_ = x[unsafe.Offsetof(TOKEN_STATISTICS{}.ExpirationTime)-16]
+	_ = x[unsafe.Offsetof(TOKEN_STATISTICS{}.ModifiedId)-48]
+	_ = x[unsafe.Sizeof(TOKEN_STATISTICS{})-56]
+
+	_ = x[unsafe.Offsetof(WTSINFO{}.ConnectTime)-176]
+	_ = x[unsafe.Sizeof(WTSINFO{})-216]
+
+	_ = x[unsafe.Offsetof(LSA_FOREST_TRUST_RECORD{}.Time)-8]
+	_ = x[unsafe.Offsetof(LSA_FOREST_TRUST_RECORD{}.ForestTrustData)-16]
+}
@@ -0,0 +1,32 @@
+package lsa
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+var leakHandler func(resource string, stack []byte)
+
+// SetLeakHandler installs h for the resources tracked from now on. A nil h
+// disables tracking.
+func SetLeakHandler(h func(resource string, stack []byte)) {
+	leakHandler = h
+}
+
+// TrackLeak arranges for the leak handler, if one is installed, to be called
+// with resource and the current stack if obj is garbage collected before
+// UntrackLeak is called for it. obj must be a pointer to an allocated
+// object.
+func TrackLeak(obj interface{}, resource string) {
+	h := leakHandler
+	if h == nil {
+		return
+	}
+	stack := debug.Stack()
+	runtime.SetFinalizer(obj, func(interface{}) { h(resource, stack) })
+}
+
+// UntrackLeak marks obj as released.
+func UntrackLeak(obj interface{}) {
+	runtime.SetFinalizer(obj, nil)
+}
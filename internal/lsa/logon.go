@@ -0,0 +1,24 @@
+package lsa
+
+const (
+	KerbS4ULogon   = 12
+	MsV1_0S4ULogon = 12
+)
+
+// MSV1_0_PACKAGE_NAME is the name of the NTLM authentication package.
+const MSV1_0_PACKAGE_NAME = "MICROSOFT_AUTHENTICATION_PACKAGE_V1_0"
+
+// KERB_S4U_LOGON also describes MSV1_0_S4U_LOGON, which has the same
+// layout with ClientUpn and ClientRealm named UserPrincipalName and
+// DomainName.
+type KERB_S4U_LOGON struct {
+	MessageType uint32
+	Flags       uint32
+	ClientUpn   LSA_UNICODE_STRING
+	ClientRealm LSA_UNICODE_STRING
+}
+
+type TOKEN_SOURCE struct {
+	SourceName       [8]byte
+	SourceIdentifier LUID
+}
@@ -0,0 +1,11 @@
+package lsa
+
+type QUOTA_LIMITS struct {
+	PagedPoolLimit        uintptr
+	NonPagedPoolLimit     uintptr
+	MinimumWorkingSetSize uintptr
+	MaximumWorkingSetSize uintptr
+	PagefileLimit         uintptr
+	_                     [align64Pad]byte // aligns TimeLimit on 32-bit platforms
+	TimeLimit             int64
+}
@@ -0,0 +1,50 @@
+package lsa
+
+// The network management API, used to correlate network logon sessions
+// with the SMB sessions of the server service.
+
+const (
+	MAX_PREFERRED_LENGTH = 0xFFFFFFFF
+	ERROR_MORE_DATA      = 234
+)
+
+type SESSION_INFO_502 struct {
+	Cname      *uint16
+	Username   *uint16
+	NumOpens   uint32
+	Time       uint32
+	IdleTime   uint32
+	UserFlags  uint32
+	CltypeName *uint16
+	Transport  *uint16
+}
+
+// NetSessionEnum returns ERROR_MORE_DATA, with a valid buffer, if not all
+// entries fit.
+//sys	NetSessionEnum(server *uint16, client *uint16, user *uint16, level uint32, buf **byte, prefmaxlen uint32, entriesRead *uint32, totalEntries *uint32, resumeHandle *uint32) (neterr error) = netapi32.NetSessionEnum
+
+type DSREG_JOIN_INFO struct {
+	JoinType           uint32
+	JoinCertificate    uintptr
+	DeviceId           *uint16
+	IdpDomain          *uint16
+	TenantId           *uint16
+	JoinUserEmail      *uint16
+	TenantDisplayName  *uint16
+	MdmEnrollmentUrl   *uint16
+	MdmTermsOfUseUrl   *uint16
+	MdmComplianceUrl   *uint16
+	UserSettingSyncUrl *uint16
+	UserInfo           *DSREG_USER_INFO
+}
+
+type DSREG_USER_INFO struct {
+	UserEmail   *uint16
+	UserKeyId   *uint16
+	UserKeyName *uint16
+}
+
+// NetGetAadJoinInformation sets info to nil if the device is not joined.
+//sys	NetGetAadJoinInformation(tenantID *uint16, info **DSREG_JOIN_INFO) (hr error) = netapi32.NetGetAadJoinInformation
+
+//sys	NetFreeAadJoinInformation(info *DSREG_JOIN_INFO) = netapi32.NetFreeAadJoinInformation
@@ -0,0 +1,85 @@
+//go:build !windows
+// +build !windows
+
+package lsa
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// A SID only keeps the string form of a SID off Windows, so that sessions
+// decoded from JSON keep their SIDs.
+type SID struct {
+	s string
+}
+
+func (sid *SID) String() string {
+	return sid.s
+}
+
+// A GUID has the layout of windows.GUID.
+type GUID struct {
+	Data1 uint32
+	Data2 uint16
+	Data3 uint16
+	Data4 [8]byte
+}
+
+// String formats guid in braces, as windows.GUID does.
+func (guid GUID) String() string {
+	return fmt.Sprintf("{%08X-%04X-%04X-%02X%02X-%02X%02X%02X%02X%02X%02X}",
+		guid.Data1, guid.Data2, guid.Data3,
+		guid.Data4[0], guid.Data4[1], guid.Data4[2], guid.Data4[3],
+		guid.Data4[4], guid.Data4[5], guid.Data4[6], guid.Data4[7])
+}
+
+// GUIDFromString parses a GUID in the form returned by GUID.String.
+func GUIDFromString(s string) (GUID, error) {
+	var guid GUID
+	var d [8]byte
+	hex := strings.Trim(s, "{}")
+	if len(hex) != 36 || len(s) != 38 {
+		return GUID{}, fmt.Errorf("invalid GUID %q", s)
+	}
+	n, err := fmt.Sscanf(hex, "%08X-%04X-%04X-%02X%02X-%02X%02X%02X%02X%02X%02X",
+		&guid.Data1, &guid.Data2, &guid.Data3,
+		&d[0], &d[1], &d[2], &d[3], &d[4], &d[5], &d[6], &d[7])
+	if err != nil || n != 11 {
+		return GUID{}, fmt.Errorf("invalid GUID %q", s)
+	}
+	guid.Data4 = d
+	return guid, nil
+}
+
+// A SecurityDescriptor is never obtained off Windows.
+type SecurityDescriptor struct{}
+
+func (sd *SecurityDescriptor) String() string {
+	return ""
+}
+
+type SecurityInformation uint32
+
+// A Token is never valid off Windows.
+type Token uintptr
+
+func (t Token) Close() error {
+	return ErrUnsupportedPlatform
+}
+
+var ErrNoSuchLogonSession = errors.New("a specified logon session does not exist")
+
+func StringToSid(s string) (*SID, error) {
+	if !strings.HasPrefix(s, "S-") {
+		return nil, fmt.Errorf("invalid SID %q", s)
+	}
+	return &SID{s: s}, nil
+}
+
+// statusError returns nil; the LSA calls only fail with
+// ErrUnsupportedPlatform off Windows.
+func statusError(status uint32) error {
+	return nil
+}
@@ -0,0 +1,24 @@
+package lsa
+
+import "golang.org/x/sys/windows"
+
+// Types and values that differ between Windows and the stubs of other
+// platforms.
+
+type (
+	SID                 = windows.SID
+	Token               = windows.Token
+	GUID                = windows.GUID
+	SecurityDescriptor  = windows.SECURITY_DESCRIPTOR
+	SecurityInformation = windows.SECURITY_INFORMATION
+)
+
+var ErrNoSuchLogonSession error = windows.ERROR_NO_SUCH_LOGON_SESSION
+
+func StringToSid(s string) (*SID, error) {
+	return windows.StringToSid(s)
+}
+
+func GUIDFromString(s string) (GUID, error) {
+	return windows.GUIDFromString(s)
+}
@@ -0,0 +1,17 @@
+package lsa
+
+import "golang.org/x/sys/windows"
+
+//sys	isProcessCritical(process windows.Handle, critical *uint32) (err error) = kernel32.IsProcessCritical
+
+// IsProcessCritical reports whether terminating process stops the system,
+// as for csrss.exe, wininit.exe and lsass.exe. The call exists from
+// Windows 8.1 on; earlier versions report no process as critical.
+func IsProcessCritical(process windows.Handle) (bool, error) {
+	if procIsProcessCritical.Find() != nil {
+		return false, nil
+	}
+	var critical uint32
+	err := isProcessCritical(process, &critical)
+	return critical != 0, err
+}
@@ -0,0 +1,39 @@
+package lsa
+
+import "unsafe"
+
+// NewRequest allocates a request buffer of size bytes followed by the UTF-16
+// encodings of strs. Authentication packages require the strings referenced
+// by a request to lie within the submitted buffer, so the returned strings
+// point into it.
+func NewRequest(size uintptr, strs ...string) ([]byte, []LSA_UNICODE_STRING, error) {
+	encoded := make([][]uint16, len(strs))
+	total := size
+	for idx, s := range strs {
+		buf, err := UTF16FromString(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Drop the terminating NUL; lengths are explicit.
+		encoded[idx] = buf[:len(buf)-1]
+		total += uintptr(len(encoded[idx]) * 2)
+	}
+
+	req := make([]byte, total)
+	names := make([]LSA_UNICODE_STRING, len(strs))
+	off := size
+	for idx, buf := range encoded {
+		if len(buf) == 0 {
+			continue
+		}
+		n := uintptr(len(buf) * 2)
+		copy(req[off:off+n], (*[1 << 30]byte)(unsafe.Pointer(&buf[0]))[:n:n])
+		names[idx] = LSA_UNICODE_STRING{
+			Length:        uint16(n),
+			MaximumLength: uint16(n),
+			Buffer:        (*uint16)(unsafe.Pointer(&req[off])),
+		}
+		off += n
+	}
+	return req, names, nil
+}
@@ -0,0 +1,74 @@
+package lsa
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// A retryPolicy is the policy installed by SetRetryPolicy. It is not
+// modified once stored. errs holds the Windows errors the retryable
+// statuses convert to.
+type retryPolicy struct {
+	attempts   int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	errs       []error
+}
+
+// retry holds the current *retryPolicy, or nil to call functions once.
+var retry atomic.Value
+
+// SetRetryPolicy makes Retry call a function up to attempts times while it
+// fails with one of statuses, sleeping backoff before the first retry and
+// twice as long before each further one, up to maxBackoff if positive.
+// Calls of Retry in progress keep the policy they started with.
+func SetRetryPolicy(attempts int, backoff, maxBackoff time.Duration, statuses []uint32) {
+	p := &retryPolicy{
+		attempts:   attempts,
+		backoff:    backoff,
+		maxBackoff: maxBackoff,
+	}
+	for _, status := range statuses {
+		if err := statusError(status); err != nil {
+			p.errs = append(p.errs, err)
+		}
+	}
+	retry.Store(p)
+}
+
+// Retry calls f according to the retry policy and returns its last error,
+// or ctx.Err() if ctx is done while it waits for a retry.
+func Retry(ctx context.Context, f func() error) error {
+	p, _ := retry.Load().(*retryPolicy)
+	err := f()
+	if p == nil {
+		return err
+	}
+	delay := p.backoff
+	for n := 1; n < p.attempts && err != nil && retryable(p.errs, err); n++ {
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+		delay *= 2
+		if p.maxBackoff > 0 && delay > p.maxBackoff {
+			delay = p.maxBackoff
+		}
+		err = f()
+	}
+	return err
+}
+
+func retryable(errs []error, err error) bool {
+	for _, target := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
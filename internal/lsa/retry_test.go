@@ -0,0 +1,83 @@
+package lsa
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// setPolicy installs a policy retrying errs until the test ends.
+func setPolicy(t *testing.T, attempts int, backoff time.Duration, errs ...error) {
+	retry.Store(&retryPolicy{attempts: attempts, backoff: backoff, errs: errs})
+	t.Cleanup(func() { SetRetryPolicy(0, 0, 0, nil) })
+}
+
+func TestRetry(t *testing.T) {
+	errBusy, errFail := errors.New("busy"), errors.New("fail")
+	setPolicy(t, 3, 0, errBusy)
+	for _, tt := range []struct {
+		name  string
+		errs  []error
+		want  error
+		calls int
+	}{
+		{"success", []error{nil}, nil, 1},
+		{"retried", []error{errBusy, nil}, nil, 2},
+		{"attempts", []error{errBusy, errBusy, errBusy}, errBusy, 3},
+		{"not retryable", []error{errBusy, errFail}, errFail, 2},
+	} {
+		calls := 0
+		err := Retry(context.Background(), func() error {
+			calls++
+			return tt.errs[calls-1]
+		})
+		if err != tt.want || calls != tt.calls {
+			t.Errorf("%s: Retry returned %v after %d calls, want %v after %d", tt.name, err, calls, tt.want, tt.calls)
+		}
+	}
+}
+
+// TestRetryContext cancels the context while Retry waits out a backoff far
+// longer than the test.
+func TestRetryContext(t *testing.T) {
+	errBusy := errors.New("busy")
+	setPolicy(t, 3, time.Hour, errBusy)
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := Retry(ctx, func() error {
+		calls++
+		time.AfterFunc(10*time.Millisecond, cancel)
+		return errBusy
+	})
+	if err != context.Canceled || calls != 1 {
+		t.Errorf("Retry returned %v after %d calls, want %v after 1", err, calls, context.Canceled)
+	}
+}
+
+// TestRetryConcurrentPolicy installs retry policies while other goroutines
+// retry, for the race detector.
+func TestRetryConcurrentPolicy(t *testing.T) {
+	defer SetRetryPolicy(0, 0, 0, nil)
+	statuses := []uint32{0xC000009A}
+	errFail := errors.New("fail")
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				if err := Retry(context.Background(), func() error { return errFail }); err != errFail {
+					t.Errorf("Retry returned %v, want %v", err, errFail)
+					return
+				}
+			}
+		}()
+	}
+	for n := 0; n < 100; n++ {
+		SetRetryPolicy(3, 0, 0, statuses)
+		statuses[0]++
+	}
+	wg.Wait()
+}
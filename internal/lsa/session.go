@@ -0,0 +1,47 @@
+package lsa
+
+type LSA_LAST_INTER_LOGON_INFO struct {
+	LastSuccessfulLogon                        uint64
+	LastFailedLogon                            uint64
+	FailedAttemptCountSinceLastSuccessfulLogon uint32
+	_                                          uint32 // size padding on 386, see layout_windows.go
+}
+
+type SECURITY_LOGON_SESSION_DATA struct {
+	Size                  uint32
+	LogonId               LUID
+	UserName              LSA_UNICODE_STRING
+	LogonDomain           LSA_UNICODE_STRING
+	AuthenticationPackage LSA_UNICODE_STRING
+	LogonType             uint32
+	Session               uint32
+	Sid                   *SID
+	LogonTime             uint64
+	LogonServer           LSA_UNICODE_STRING
+	DnsDomainName         LSA_UNICODE_STRING
+	Upn                   LSA_UNICODE_STRING
+	UserFlags             uint32
+	_                     uint32 // aligns LastLogonInfo on 386
+	LastLogonInfo         LSA_LAST_INTER_LOGON_INFO
+	LogonScript           LSA_UNICODE_STRING
+	ProfilePath           LSA_UNICODE_STRING
+	HomeDirectory         LSA_UNICODE_STRING
+	HomeDirectoryDrive    LSA_UNICODE_STRING
+	LogoffTime            uint64
+	KickOffTime           uint64
+	PasswordLastSet       uint64
+	PasswordCanChange     uint64
+	PasswordMustChange    uint64
+}
+
+type LSA_UNICODE_STRING struct {
+	Length        uint16
+	MaximumLength uint16
+	Buffer        *uint16
+}
+
+type LSA_STRING struct {
+	Length        uint16
+	MaximumLength uint16
+	Buffer        *byte
+}
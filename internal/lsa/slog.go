@@ -0,0 +1,11 @@
+//go:build go1.21
+// +build go1.21
+
+package lsa
+
+import "log/slog"
+
+// LogValue logs the LUID in the form returned by String.
+func (luid LUID) LogValue() slog.Value {
+	return slog.StringValue(luid.String())
+}
@@ -0,0 +1,55 @@
+//go:build !windows
+// +build !windows
+
+package lsa
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// The calls the lsaraw package exports fail with ErrUnsupportedPlatform off
+// Windows.
+
+func LsaNtStatusToWinError(ntstatus uintptr) error {
+	if ntstatus == 0 {
+		return nil
+	}
+	return fmt.Errorf("NTSTATUS 0x%x", ntstatus)
+}
+
+func LsaEnumerateLogonSessions(sessionCount *uint32, sessions **LUID) error {
+	return ErrUnsupportedPlatform
+}
+
+func LsaGetLogonSessionData(luid *LUID, sessionData **SECURITY_LOGON_SESSION_DATA) error {
+	return ErrUnsupportedPlatform
+}
+
+func LsaFreeReturnBuffer(buffer uintptr) error {
+	return ErrUnsupportedPlatform
+}
+
+func LsaConnectUntrusted(lsaHandle *LSA_HANDLE) error {
+	return ErrUnsupportedPlatform
+}
+
+func LsaRegisterLogonProcess(logonProcessName *LSA_STRING, lsaHandle *LSA_HANDLE, securityMode *uint32) error {
+	return ErrUnsupportedPlatform
+}
+
+func LsaDeregisterLogonProcess(lsaHandle LSA_HANDLE) error {
+	return ErrUnsupportedPlatform
+}
+
+func LsaLookupAuthenticationPackage(lsaHandle LSA_HANDLE, packageName *LSA_STRING, authenticationPackage *uint32) error {
+	return ErrUnsupportedPlatform
+}
+
+func LsaCallAuthenticationPackage(lsaHandle LSA_HANDLE, authenticationPackage uint32, protocolSubmitBuffer unsafe.Pointer, submitBufferLength uint32, protocolReturnBuffer *unsafe.Pointer, returnBufferLength *uint32, protocolStatus *uint32) error {
+	return ErrUnsupportedPlatform
+}
+
+func CallPackage(lsaHandle LSA_HANDLE, authenticationPackage uint32, req unsafe.Pointer, reqLen uintptr) (unsafe.Pointer, uint32, error) {
+	return nil, 0, ErrUnsupportedPlatform
+}
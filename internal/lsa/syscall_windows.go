@@ -0,0 +1,305 @@
+package lsa
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+//go:generate go run golang.org/x/sys/windows/mkwinsyscall -output zsyscall_windows.go syscall_windows.go netapi_windows.go process_windows.go wtsapi_windows.go wevtapi_windows.go
+
+// The LSA functions return an NTSTATUS. Their generated lowercase bindings
+// return it as is, and the exported wrappers convert it with
+// LsaNtStatusToWinError, so that callers can compare errors with the
+// Windows error codes.
+
+//sys	lsaEnumerateLogonSessions(sessionCount *uint32, sessions **LUID) (status uintptr) = secur32.LsaEnumerateLogonSessions
+//sys	lsaGetLogonSessionData(luid *LUID, sessionData **SECURITY_LOGON_SESSION_DATA) (status uintptr) = secur32.LsaGetLogonSessionData
+//sys	lsaFreeReturnBuffer(buffer uintptr) (status uintptr) = secur32.LsaFreeReturnBuffer
+//sys	lsaConnectUntrusted(lsaHandle *LSA_HANDLE) (status uintptr) = secur32.LsaConnectUntrusted
+//sys	lsaRegisterLogonProcess(logonProcessName *LSA_STRING, lsaHandle *LSA_HANDLE, securityMode *uint32) (status uintptr) = secur32.LsaRegisterLogonProcess
+//sys	lsaDeregisterLogonProcess(lsaHandle LSA_HANDLE) (status uintptr) = secur32.LsaDeregisterLogonProcess
+//sys	lsaLookupAuthenticationPackage(lsaHandle LSA_HANDLE, packageName *LSA_STRING, authenticationPackage *uint32) (status uintptr) = secur32.LsaLookupAuthenticationPackage
+//sys	lsaCallAuthenticationPackage(lsaHandle LSA_HANDLE, authenticationPackage uint32, protocolSubmitBuffer unsafe.Pointer, submitBufferLength uint32, protocolReturnBuffer *unsafe.Pointer, returnBufferLength *uint32, protocolStatus *uint32) (status uintptr) = secur32.LsaCallAuthenticationPackage
+//sys	lsaLogonUser(lsaHandle LSA_HANDLE, originName *LSA_STRING, logonType uint32, authenticationPackage uint32, authenticationInformation unsafe.Pointer, authenticationInformationLength uint32, localGroups *windows.Tokengroups, sourceContext *TOKEN_SOURCE, profileBuffer *unsafe.Pointer, profileBufferLength *uint32, logonId *LUID, token *windows.Token, quotas *QUOTA_LIMITS, subStatus *uint32) (status uintptr) = secur32.LsaLogonUser
+//sys	lsaOpenPolicy(systemName *LSA_UNICODE_STRING, objectAttributes *LSA_OBJECT_ATTRIBUTES, desiredAccess uint32, policyHandle *LSA_HANDLE) (status uintptr) = advapi32.LsaOpenPolicy
+//sys	lsaClose(objectHandle LSA_HANDLE) (status uintptr) = advapi32.LsaClose
+//sys	lsaFreeMemory(buffer uintptr) (status uintptr) = advapi32.LsaFreeMemory
+//sys	lsaQueryForestTrustInformation(policyHandle LSA_HANDLE, trustedDomainName *LSA_UNICODE_STRING, forestTrustInfo **LSA_FOREST_TRUST_INFORMATION) (status uintptr) = advapi32.LsaQueryForestTrustInformation
+//sys	lsaSetForestTrustInformation(policyHandle LSA_HANDLE, trustedDomainName *LSA_UNICODE_STRING, forestTrustInfo *LSA_FOREST_TRUST_INFORMATION, checkOnly bool, collisionInfo **LSA_FOREST_TRUST_COLLISION_INFORMATION) (status uintptr) = advapi32.LsaSetForestTrustInformation
+//sys	lsaCreateAccount(policyHandle LSA_HANDLE, accountSid *windows.SID, desiredAccess uint32, accountHandle *LSA_HANDLE) (status uintptr) = advapi32.LsaCreateAccount
+//sys	lsaOpenAccount(policyHandle LSA_HANDLE, accountSid *windows.SID, desiredAccess uint32, accountHandle *LSA_HANDLE) (status uintptr) = advapi32.LsaOpenAccount
+//sys	lsaEnumerateAccounts(policyHandle LSA_HANDLE, enumerationContext *uint32, buffer **LSA_ENUMERATION_INFORMATION, preferedMaximumLength uint32, countReturned *uint32) (status uintptr) = advapi32.LsaEnumerateAccounts
+//sys	lsaStorePrivateData(policyHandle LSA_HANDLE, keyName *LSA_UNICODE_STRING, privateData *LSA_UNICODE_STRING) (status uintptr) = advapi32.LsaStorePrivateData
+//sys	lsaRetrievePrivateData(policyHandle LSA_HANDLE, keyName *LSA_UNICODE_STRING, privateData **LSA_UNICODE_STRING) (status uintptr) = advapi32.LsaRetrievePrivateData
+//sys	lsaEnumerateTrustedDomainsEx(policyHandle LSA_HANDLE, enumerationContext *uint32, buffer **TRUSTED_DOMAIN_INFORMATION_EX, preferedMaximumLength uint32, countReturned *uint32) (status uintptr) = advapi32.LsaEnumerateTrustedDomainsEx
+//sys	lsaQueryTrustedDomainInfoByName(policyHandle LSA_HANDLE, trustedDomainName *LSA_UNICODE_STRING, informationClass uint32, buffer *unsafe.Pointer) (status uintptr) = advapi32.LsaQueryTrustedDomainInfoByName
+//sys	lsaGetSystemAccessAccount(accountHandle LSA_HANDLE, systemAccess *uint32) (status uintptr) = advapi32.LsaGetSystemAccessAccount
+//sys	lsaSetSystemAccessAccount(accountHandle LSA_HANDLE, systemAccess uint32) (status uintptr) = advapi32.LsaSetSystemAccessAccount
+//sys	lsaDelete(objectHandle LSA_HANDLE) (status uintptr) = advapi32.LsaDelete
+//sys	lsaQueryInformationPolicy(policyHandle LSA_HANDLE, informationClass uint32, buffer *unsafe.Pointer) (status uintptr) = advapi32.LsaQueryInformationPolicy
+//sys	lsaQueryDomainInformationPolicy(policyHandle LSA_HANDLE, informationClass uint32, buffer *unsafe.Pointer) (status uintptr) = advapi32.LsaQueryDomainInformationPolicy
+//sys	lsaSetDomainInformationPolicy(policyHandle LSA_HANDLE, informationClass uint32, buffer unsafe.Pointer) (status uintptr) = advapi32.LsaSetDomainInformationPolicy
+//sys	lsaGetAppliedCAPIDs(systemName *LSA_UNICODE_STRING, capids ***windows.SID, capidCount *uint32) (status uintptr) = advapi32.LsaGetAppliedCAPIDs
+//sys	lsaQueryCAPs(capids **windows.SID, capidCount uint32, caps **CENTRAL_ACCESS_POLICY, capCount *uint32) (status uintptr) = advapi32.LsaQueryCAPs
+//sys	lsaLookupNames2(policyHandle LSA_HANDLE, flags uint32, count uint32, names *LSA_UNICODE_STRING, referencedDomains **LSA_REFERENCED_DOMAIN_LIST, sids **LSA_TRANSLATED_SID2) (status uintptr) = advapi32.LsaLookupNames2
+//sys	lsaLookupSids2(policyHandle LSA_HANDLE, lookupOptions uint32, count uint32, sids **windows.SID, referencedDomains **LSA_REFERENCED_DOMAIN_LIST, names **LSA_TRANSLATED_NAME) (status uintptr) = advapi32.LsaLookupSids2
+//sys	lsaLookupPrivilegeValue(policyHandle LSA_HANDLE, name *LSA_UNICODE_STRING, value *LUID) (status uintptr) = advapi32.LsaLookupPrivilegeValue
+//sys	lsaLookupPrivilegeName(policyHandle LSA_HANDLE, value *LUID, name **LSA_UNICODE_STRING) (status uintptr) = advapi32.LsaLookupPrivilegeName
+//sys	lsaLookupPrivilegeDisplayName(policyHandle LSA_HANDLE, name *LSA_UNICODE_STRING, displayName **LSA_UNICODE_STRING, languageReturned *int16) (status uintptr) = advapi32.LsaLookupPrivilegeDisplayName
+//sys	lsaEnumerateAccountRights(policyHandle LSA_HANDLE, accountSid *windows.SID, userRights **LSA_UNICODE_STRING, countOfRights *uint32) (status uintptr) = advapi32.LsaEnumerateAccountRights
+//sys	lsaAddAccountRights(policyHandle LSA_HANDLE, accountSid *windows.SID, userRights *LSA_UNICODE_STRING, countOfRights uint32) (status uintptr) = advapi32.LsaAddAccountRights
+//sys	lsaRemoveAccountRights(policyHandle LSA_HANDLE, accountSid *windows.SID, allRights bool, userRights *LSA_UNICODE_STRING, countOfRights uint32) (status uintptr) = advapi32.LsaRemoveAccountRights
+//sys	lsaEnumerateAccountsWithUserRight(policyHandle LSA_HANDLE, userRight *LSA_UNICODE_STRING, buffer **LSA_ENUMERATION_INFORMATION, countReturned *uint32) (status uintptr) = advapi32.LsaEnumerateAccountsWithUserRight
+//sys	lsaNtStatusToWinError(status uintptr) (winerr syscall.Errno) = advapi32.LsaNtStatusToWinError
+
+func LsaNtStatusToWinError(ntstatus uintptr) error {
+	if ntstatus == 0 {
+		return nil
+	}
+	switch winerr := lsaNtStatusToWinError(ntstatus); winerr {
+	case windows.ERROR_SUCCESS:
+		return nil
+	case windows.ERROR_MR_MID_NOT_FOUND:
+		return fmt.Errorf("Unknown LSA NTSTATUS code %x", ntstatus)
+	default:
+		return winerr
+	}
+}
+
+func LsaEnumerateLogonSessions(sessionCount *uint32, sessions **LUID) error {
+	return LsaNtStatusToWinError(lsaEnumerateLogonSessions(sessionCount, sessions))
+}
+
+func LsaGetLogonSessionData(luid *LUID, sessionData **SECURITY_LOGON_SESSION_DATA) error {
+	return LsaNtStatusToWinError(lsaGetLogonSessionData(luid, sessionData))
+}
+
+func LsaFreeReturnBuffer(buffer uintptr) error {
+	return LsaNtStatusToWinError(lsaFreeReturnBuffer(buffer))
+}
+
+// FreeReturnBuffer is LsaFreeReturnBuffer for buffers whose data was
+// already decoded; it fails with a *FreeBufferError.
+func FreeReturnBuffer(buffer uintptr) error {
+	if err := LsaFreeReturnBuffer(buffer); err != nil {
+		return freeFailed("LsaFreeReturnBuffer", err)
+	}
+	return nil
+}
+
+func LsaConnectUntrusted(lsaHandle *LSA_HANDLE) error {
+	return LsaNtStatusToWinError(lsaConnectUntrusted(lsaHandle))
+}
+
+func LsaRegisterLogonProcess(logonProcessName *LSA_STRING, lsaHandle *LSA_HANDLE, securityMode *uint32) error {
+	return LsaNtStatusToWinError(lsaRegisterLogonProcess(logonProcessName, lsaHandle, securityMode))
+}
+
+func LsaDeregisterLogonProcess(lsaHandle LSA_HANDLE) error {
+	return LsaNtStatusToWinError(lsaDeregisterLogonProcess(lsaHandle))
+}
+
+func LsaLookupAuthenticationPackage(lsaHandle LSA_HANDLE, packageName *LSA_STRING, authenticationPackage *uint32) error {
+	return LsaNtStatusToWinError(lsaLookupAuthenticationPackage(lsaHandle, packageName, authenticationPackage))
+}
+
+// LsaCallAuthenticationPackage returns the error of the call itself; the
+// status reported by the package is stored in protocolStatus as an NTSTATUS.
+func LsaCallAuthenticationPackage(lsaHandle LSA_HANDLE, authenticationPackage uint32, protocolSubmitBuffer unsafe.Pointer, submitBufferLength uint32, protocolReturnBuffer *unsafe.Pointer, returnBufferLength *uint32, protocolStatus *uint32) error {
+	return LsaNtStatusToWinError(lsaCallAuthenticationPackage(lsaHandle, authenticationPackage, protocolSubmitBuffer, submitBufferLength, protocolReturnBuffer, returnBufferLength, protocolStatus))
+}
+
+// CallPackage submits req to an authentication package and reports a failed
+// protocol status as an error. The returned buffer, if any, must be released
+// with LsaFreeReturnBuffer.
+func CallPackage(lsaHandle LSA_HANDLE, authenticationPackage uint32, req unsafe.Pointer, reqLen uintptr) (unsafe.Pointer, uint32, error) {
+	var resp unsafe.Pointer
+	var respLen uint32
+	var status uint32
+	err := LsaCallAuthenticationPackage(lsaHandle, authenticationPackage, req, uint32(reqLen), &resp, &respLen, &status)
+	if err != nil {
+		return nil, 0, err
+	}
+	if status != 0 {
+		if resp != nil {
+			LsaFreeReturnBuffer(uintptr(resp))
+		}
+		return nil, 0, LsaNtStatusToWinError(uintptr(status))
+	}
+	return resp, respLen, nil
+}
+
+// LsaLogonUser returns the error of the call itself; subStatus holds
+// additional information about failed logons.
+func LsaLogonUser(lsaHandle LSA_HANDLE, originName *LSA_STRING, logonType uint32, authenticationPackage uint32, authenticationInformation unsafe.Pointer, authenticationInformationLength uint32, localGroups *windows.Tokengroups, sourceContext *TOKEN_SOURCE, profileBuffer *unsafe.Pointer, profileBufferLength *uint32, logonId *LUID, token *windows.Token, quotas *QUOTA_LIMITS, subStatus *uint32) error {
+	return LsaNtStatusToWinError(lsaLogonUser(lsaHandle, originName, logonType, authenticationPackage, authenticationInformation, authenticationInformationLength, localGroups, sourceContext, profileBuffer, profileBufferLength, logonId, token, quotas, subStatus))
+}
+
+func LsaOpenPolicy(systemName *LSA_UNICODE_STRING, objectAttributes *LSA_OBJECT_ATTRIBUTES, desiredAccess uint32, policyHandle *LSA_HANDLE) error {
+	return LsaNtStatusToWinError(lsaOpenPolicy(systemName, objectAttributes, desiredAccess, policyHandle))
+}
+
+func LsaClose(objectHandle LSA_HANDLE) error {
+	return LsaNtStatusToWinError(lsaClose(objectHandle))
+}
+
+func LsaFreeMemory(buffer uintptr) error {
+	return LsaNtStatusToWinError(lsaFreeMemory(buffer))
+}
+
+// FreeMemory is LsaFreeMemory for buffers whose data was already decoded;
+// it fails with a *FreeBufferError.
+func FreeMemory(buffer uintptr) error {
+	if err := LsaFreeMemory(buffer); err != nil {
+		return freeFailed("LsaFreeMemory", err)
+	}
+	return nil
+}
+
+func LsaQueryForestTrustInformation(policyHandle LSA_HANDLE, trustedDomainName *LSA_UNICODE_STRING, forestTrustInfo **LSA_FOREST_TRUST_INFORMATION) error {
+	return LsaNtStatusToWinError(lsaQueryForestTrustInformation(policyHandle, trustedDomainName, forestTrustInfo))
+}
+
+func LsaSetForestTrustInformation(policyHandle LSA_HANDLE, trustedDomainName *LSA_UNICODE_STRING, forestTrustInfo *LSA_FOREST_TRUST_INFORMATION, checkOnly bool, collisionInfo **LSA_FOREST_TRUST_COLLISION_INFORMATION) error {
+	return LsaNtStatusToWinError(lsaSetForestTrustInformation(policyHandle, trustedDomainName, forestTrustInfo, checkOnly, collisionInfo))
+}
+
+func LsaCreateAccount(policyHandle LSA_HANDLE, accountSid *windows.SID, desiredAccess uint32, accountHandle *LSA_HANDLE) error {
+	return LsaNtStatusToWinError(lsaCreateAccount(policyHandle, accountSid, desiredAccess, accountHandle))
+}
+
+func LsaOpenAccount(policyHandle LSA_HANDLE, accountSid *windows.SID, desiredAccess uint32, accountHandle *LSA_HANDLE) error {
+	return LsaNtStatusToWinError(lsaOpenAccount(policyHandle, accountSid, desiredAccess, accountHandle))
+}
+
+func LsaEnumerateAccounts(policyHandle LSA_HANDLE, enumerationContext *uint32, buffer **LSA_ENUMERATION_INFORMATION, preferedMaximumLength uint32, countReturned *uint32) error {
+	return LsaNtStatusToWinError(lsaEnumerateAccounts(policyHandle, enumerationContext, buffer, preferedMaximumLength, countReturned))
+}
+
+func LsaStorePrivateData(policyHandle LSA_HANDLE, keyName *LSA_UNICODE_STRING, privateData *LSA_UNICODE_STRING) error {
+	return LsaNtStatusToWinError(lsaStorePrivateData(policyHandle, keyName, privateData))
+}
+
+func LsaRetrievePrivateData(policyHandle LSA_HANDLE, keyName *LSA_UNICODE_STRING, privateData **LSA_UNICODE_STRING) error {
+	return LsaNtStatusToWinError(lsaRetrievePrivateData(policyHandle, keyName, privateData))
+}
+
+func LsaEnumerateTrustedDomainsEx(policyHandle LSA_HANDLE, enumerationContext *uint32, buffer **TRUSTED_DOMAIN_INFORMATION_EX, preferedMaximumLength uint32, countReturned *uint32) error {
+	return LsaNtStatusToWinError(lsaEnumerateTrustedDomainsEx(policyHandle, enumerationContext, buffer, preferedMaximumLength, countReturned))
+}
+
+func LsaQueryTrustedDomainInfoByName(policyHandle LSA_HANDLE, trustedDomainName *LSA_UNICODE_STRING, informationClass uint32, buffer *unsafe.Pointer) error {
+	return LsaNtStatusToWinError(lsaQueryTrustedDomainInfoByName(policyHandle, trustedDomainName, informationClass, buffer))
+}
+
+func LsaGetSystemAccessAccount(accountHandle LSA_HANDLE, systemAccess *uint32) error {
+	return LsaNtStatusToWinError(lsaGetSystemAccessAccount(accountHandle, systemAccess))
+}
+
+func LsaSetSystemAccessAccount(accountHandle LSA_HANDLE, systemAccess uint32) error {
+	return LsaNtStatusToWinError(lsaSetSystemAccessAccount(accountHandle, systemAccess))
+}
+
+func LsaDelete(objectHandle LSA_HANDLE) error {
+	return LsaNtStatusToWinError(lsaDelete(objectHandle))
+}
+
+func LsaQueryInformationPolicy(policyHandle LSA_HANDLE, informationClass uint32, buffer *unsafe.Pointer) error {
+	return LsaNtStatusToWinError(lsaQueryInformationPolicy(policyHandle, informationClass, buffer))
+}
+
+func LsaQueryDomainInformationPolicy(policyHandle LSA_HANDLE, informationClass uint32, buffer *unsafe.Pointer) error {
+	return LsaNtStatusToWinError(lsaQueryDomainInformationPolicy(policyHandle, informationClass, buffer))
+}
+
+func LsaSetDomainInformationPolicy(policyHandle LSA_HANDLE, informationClass uint32, buffer unsafe.Pointer) error {
+	return LsaNtStatusToWinError(lsaSetDomainInformationPolicy(policyHandle, informationClass, buffer))
+}
+
+func LsaGetAppliedCAPIDs(systemName *LSA_UNICODE_STRING, capids ***windows.SID, capidCount *uint32) error {
+	return LsaNtStatusToWinError(lsaGetAppliedCAPIDs(systemName, capids, capidCount))
+}
+
+func LsaQueryCAPs(capids **windows.SID, capidCount uint32, caps **CENTRAL_ACCESS_POLICY, capCount *uint32) error {
+	return LsaNtStatusToWinError(lsaQueryCAPs(capids, capidCount, caps, capCount))
+}
+
+func LsaLookupNames2(policyHandle LSA_HANDLE, flags uint32, count uint32, names *LSA_UNICODE_STRING, referencedDomains **LSA_REFERENCED_DOMAIN_LIST, sids **LSA_TRANSLATED_SID2) error {
+	return LsaNtStatusToWinError(lsaLookupNames2(policyHandle, flags, count, names, referencedDomains, sids))
+}
+
+func LsaLookupSids2(policyHandle LSA_HANDLE, lookupOptions uint32, count uint32, sids **windows.SID, referencedDomains **LSA_REFERENCED_DOMAIN_LIST, names **LSA_TRANSLATED_NAME) error {
+	return LsaNtStatusToWinError(lsaLookupSids2(policyHandle, lookupOptions, count, sids, referencedDomains, names))
+}
+
+func LsaLookupPrivilegeValue(policyHandle LSA_HANDLE, name *LSA_UNICODE_STRING, value *LUID) error {
+	return LsaNtStatusToWinError(lsaLookupPrivilegeValue(policyHandle, name, value))
+}
+
+func LsaLookupPrivilegeName(policyHandle LSA_HANDLE, value *LUID, name **LSA_UNICODE_STRING) error {
+	return LsaNtStatusToWinError(lsaLookupPrivilegeName(policyHandle, value, name))
+}
+
+func LsaLookupPrivilegeDisplayName(policyHandle LSA_HANDLE, name *LSA_UNICODE_STRING, displayName **LSA_UNICODE_STRING, languageReturned *int16) error {
+	return LsaNtStatusToWinError(lsaLookupPrivilegeDisplayName(policyHandle, name, displayName, languageReturned))
+}
+
+func LsaEnumerateAccountRights(policyHandle LSA_HANDLE, accountSid *windows.SID, userRights **LSA_UNICODE_STRING, countOfRights *uint32) error {
+	return LsaNtStatusToWinError(lsaEnumerateAccountRights(policyHandle, accountSid, userRights, countOfRights))
+}
+
+func LsaAddAccountRights(policyHandle LSA_HANDLE, accountSid *windows.SID, userRights *LSA_UNICODE_STRING, countOfRights uint32) error {
+	return LsaNtStatusToWinError(lsaAddAccountRights(policyHandle, accountSid, userRights, countOfRights))
+}
+
+func LsaRemoveAccountRights(policyHandle LSA_HANDLE, accountSid *windows.SID, allRights bool, userRights *LSA_UNICODE_STRING, countOfRights uint32) error {
+	return LsaNtStatusToWinError(lsaRemoveAccountRights(policyHandle, accountSid, allRights, userRights, countOfRights))
+}
+
+func LsaEnumerateAccountsWithUserRight(policyHandle LSA_HANDLE, userRight *LSA_UNICODE_STRING, buffer **LSA_ENUMERATION_INFORMATION, countReturned *uint32) error {
+	return LsaNtStatusToWinError(lsaEnumerateAccountsWithUserRight(policyHandle, userRight, buffer, countReturned))
+}
+
+// The audit functions return a BOOLEAN, of which only the low byte is
+// defined, and set the last error on failure.
+
+//sys	AuditEnumerateCategories(categories **windows.GUID, countReturned *uint32) (err error) [failretval&0xff==0] = advapi32.AuditEnumerateCategories
+//sys	AuditEnumerateSubCategories(category *windows.GUID, retrieveAll bool, subCategories **windows.GUID, countReturned *uint32) (err error) [failretval&0xff==0] = advapi32.AuditEnumerateSubCategories
+//sys	AuditLookupCategoryName(category *windows.GUID, name **uint16) (err error) [failretval&0xff==0] = advapi32.AuditLookupCategoryNameW
+//sys	AuditLookupSubCategoryName(subCategory *windows.GUID, name **uint16) (err error) [failretval&0xff==0] = advapi32.AuditLookupSubCategoryNameW
+//sys	AuditQuerySystemPolicy(subCategories *windows.GUID, policyCount uint32, policy **AUDIT_POLICY_INFORMATION) (err error) [failretval&0xff==0] = advapi32.AuditQuerySystemPolicy
+//sys	AuditSetSystemPolicy(policy *AUDIT_POLICY_INFORMATION, policyCount uint32) (err error) [failretval&0xff==0] = advapi32.AuditSetSystemPolicy
+//sys	AuditQueryPerUserPolicy(sid *windows.SID, subCategories *windows.GUID, policyCount uint32, policy **AUDIT_POLICY_INFORMATION) (err error) [failretval&0xff==0] = advapi32.AuditQueryPerUserPolicy
+//sys	AuditSetPerUserPolicy(sid *windows.SID, policy *AUDIT_POLICY_INFORMATION, policyCount uint32) (err error) [failretval&0xff==0] = advapi32.AuditSetPerUserPolicy
+//sys	AuditEnumeratePerUserPolicy(auditSidArray **POLICY_AUDIT_SID_ARRAY) (err error) [failretval&0xff==0] = advapi32.AuditEnumeratePerUserPolicy
+//sys	AuditQuerySecurity(securityInformation windows.SECURITY_INFORMATION, securityDescriptor **windows.SECURITY_DESCRIPTOR) (err error) [failretval&0xff==0] = advapi32.AuditQuerySecurity
+//sys	AuditSetSecurity(securityInformation windows.SECURITY_INFORMATION, securityDescriptor *windows.SECURITY_DESCRIPTOR) (err error) [failretval&0xff==0] = advapi32.AuditSetSecurity
+//sys	AuditFree(buffer unsafe.Pointer) = advapi32.AuditFree
+
+//sys	LookupPrivilegeName(systemName *uint16, luid *LUID, name *uint16, nameLen *uint32) (err error) = advapi32.LookupPrivilegeNameW
+
+// LOGON_WITH_PROFILE makes CreateProcessWithToken load the user's profile.
+const LOGON_WITH_PROFILE = 0x1
+
+//sys	CreateProcessWithToken(token windows.Token, logonFlags uint32, appName *uint16, commandLine *uint16, creationFlags uint32, env *uint16, currentDir *uint16, startupInfo *windows.StartupInfo, outProcInfo *windows.ProcessInformation) (err error) = advapi32.CreateProcessWithTokenW
+
+// statusError returns the Windows error status converts to, or nil if it
+// has none.
+func statusError(status uint32) error {
+	err := LsaNtStatusToWinError(uintptr(status))
+	if _, ok := err.(windows.Errno); !ok {
+		return nil
+	}
+	return err
+}
+
+// Logon types and the default provider of LogonUser.
+const (
+	LOGON32_LOGON_INTERACTIVE = 2
+	LOGON32_LOGON_NETWORK     = 3
+	LOGON32_PROVIDER_DEFAULT  = 0
+)
+
+//sys	LogonUser(username *uint16, domain *uint16, password *uint16, logonType uint32, logonProvider uint32, token *windows.Token) (err error) = advapi32.LogonUserW
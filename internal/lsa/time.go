@@ -0,0 +1,22 @@
+package lsa
+
+import "time"
+
+const windowsEpoch = 116444736000000000
+
+// TimeFromUint64 converts a FILETIME-style timestamp to a time.Time. Zero and
+// the "never" sentinel (0x7FFFFFFFFFFFFFFF) both map to the zero time.
+func TimeFromUint64(nsec uint64) time.Time {
+	if nsec == 0 || nsec == ^uint64(0)>>1 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(nsec-windowsEpoch)*100)
+}
+
+// Uint64FromTime is the inverse of TimeFromUint64.
+func Uint64FromTime(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano()/100) + windowsEpoch
+}
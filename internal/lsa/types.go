@@ -1,48 +1,58 @@
 package lsa
 
 import (
-	"golang.org/x/sys/windows"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 )
 
+// ErrUnsupportedPlatform is returned by all LSA calls on platforms other
+// than Windows.
+var ErrUnsupportedPlatform = errors.New("winlsa: the LSA is only available on Windows")
+
 type LUID struct {
 	LowPart  uint32
 	HighPart int32
 }
 
-type LSA_LAST_INTER_LOGON_INFO struct {
-	LastSuccessfulLogon                        uint64
-	LastFailedLogon                            uint64
-	FailedAttemptCountSinceLastSuccessfulLogon uint32
+// String formats l as a single hexadecimal number, as klist.exe and the
+// Security event log do.
+func (l LUID) String() string {
+	return fmt.Sprintf("0x%x", uint64(uint32(l.HighPart))<<32|uint64(l.LowPart))
+}
+
+func (l LUID) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
 }
 
-type SECURITY_LOGON_SESSION_DATA struct {
-	Size                  uint32
-	LogonId               LUID
-	UserName              LSA_UNICODE_STRING
-	LogonDomain           LSA_UNICODE_STRING
-	AuthenticationPackage LSA_UNICODE_STRING
-	LogonType             uint32
-	Session               uint32
-	Sid                   *windows.SID
-	LogonTime             uint64
-	LogonServer           LSA_UNICODE_STRING
-	DnsDomainName         LSA_UNICODE_STRING
-	Upn                   LSA_UNICODE_STRING
-	UserFlags             uint32
-	LastLogonInfo         LSA_LAST_INTER_LOGON_INFO
-	LogonScript           LSA_UNICODE_STRING
-	ProfilePath           LSA_UNICODE_STRING
-	HomeDirectory         LSA_UNICODE_STRING
-	HomeDirectoryDrive    LSA_UNICODE_STRING
-	LogoffTime            uint64
-	KickOffTime           uint64
-	PasswordLastSet       uint64
-	PasswordCanChange     uint64
-	PasswordMustChange    uint64
+// UnmarshalText parses a LUID formatted by String, i.e. a hexadecimal number
+// with an optional 0x prefix.
+func (l *LUID) UnmarshalText(text []byte) error {
+	v, err := strconv.ParseUint(strings.TrimPrefix(strings.ToLower(string(text)), "0x"), 16, 64)
+	if err != nil {
+		return fmt.Errorf("invalid LUID %q", text)
+	}
+	*l = LUID{LowPart: uint32(v), HighPart: int32(v >> 32)}
+	return nil
 }
 
-type LSA_UNICODE_STRING struct {
-	Length        uint16
-	MaximumLength uint16
-	Buffer        uintptr
+type LSA_HANDLE uintptr
+
+const (
+	SE_GROUP_ENABLED           = 0x00000004
+	SE_GROUP_USE_FOR_DENY_ONLY = 0x00000010
+	SE_PRIVILEGE_ENABLED       = 0x00000002
+
+	TOKEN_ASSIGN_PRIMARY = 0x0001
+	TOKEN_DUPLICATE      = 0x0002
+	TOKEN_QUERY          = 0x0008
+)
+
+// SidString formats sid, returning "" for a nil SID.
+func SidString(sid *SID) string {
+	if sid == nil {
+		return ""
+	}
+	return sid.String()
 }
@@ -0,0 +1,227 @@
+package lsa
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// windowsVistaBuild is the first build whose SECURITY_LOGON_SESSION_DATA
+// includes UserFlags and the fields after it.
+const windowsVistaBuild = 6000
+
+// Len returns the size of the structure the LSA returned. Windows XP and
+// Server 2003 end it after Upn; newer versions may extend it, so fields
+// past Len must not be read. If Size does not hold a plausible length,
+// Len assumes the size of the running version of Windows.
+func (d *SECURITY_LOGON_SESSION_DATA) Len() uintptr {
+	size := uintptr(d.Size)
+	switch {
+	case size > unsafe.Sizeof(*d):
+		return unsafe.Sizeof(*d)
+	case size >= unsafe.Offsetof(d.LogonServer):
+		return size
+	}
+	if _, _, build := windows.RtlGetNtVersionNumbers(); build&0xFFFF < windowsVistaBuild {
+		return unsafe.Offsetof(d.UserFlags)
+	}
+	return unsafe.Sizeof(*d)
+}
+
+// Has reports whether the structure the LSA returned includes the field of
+// d at p of n bytes.
+func (d *SECURITY_LOGON_SESSION_DATA) Has(p unsafe.Pointer, n uintptr) bool {
+	return uintptr(p)-uintptr(unsafe.Pointer(d))+n <= d.Len()
+}
+
+type LSA_OBJECT_ATTRIBUTES struct {
+	Length                   uint32
+	RootDirectory            windows.Handle
+	ObjectName               *LSA_UNICODE_STRING
+	Attributes               uint32
+	SecurityDescriptor       uintptr
+	SecurityQualityOfService uintptr
+}
+
+const (
+	ForestTrustTopLevelName   = 0
+	ForestTrustTopLevelNameEx = 1
+	ForestTrustDomainInfo     = 2
+)
+
+type LSA_FOREST_TRUST_DOMAIN_INFO struct {
+	Sid         *windows.SID
+	DnsName     LSA_UNICODE_STRING
+	NetbiosName LSA_UNICODE_STRING
+}
+
+type LSA_FOREST_TRUST_BINARY_DATA struct {
+	Length uint32
+	Buffer *byte
+}
+
+type LSA_FOREST_TRUST_RECORD struct {
+	Flags           uint32
+	ForestTrustType uint32
+	Time            uint64
+	// ForestTrustData is a union of TopLevelName, DomainInfo and Data;
+	// DomainInfo is its largest member.
+	ForestTrustData LSA_FOREST_TRUST_DOMAIN_INFO
+}
+
+func (r *LSA_FOREST_TRUST_RECORD) TopLevelName() *LSA_UNICODE_STRING {
+	return (*LSA_UNICODE_STRING)(unsafe.Pointer(&r.ForestTrustData))
+}
+func (r *LSA_FOREST_TRUST_RECORD) DomainInfo() *LSA_FOREST_TRUST_DOMAIN_INFO {
+	return &r.ForestTrustData
+}
+func (r *LSA_FOREST_TRUST_RECORD) Data() *LSA_FOREST_TRUST_BINARY_DATA {
+	return (*LSA_FOREST_TRUST_BINARY_DATA)(unsafe.Pointer(&r.ForestTrustData))
+}
+
+type LSA_FOREST_TRUST_INFORMATION struct {
+	RecordCount uint32
+	Entries     **LSA_FOREST_TRUST_RECORD
+}
+
+type LSA_FOREST_TRUST_COLLISION_RECORD struct {
+	Index uint32
+	Type  uint32
+	Flags uint32
+	Name  LSA_UNICODE_STRING
+}
+
+type LSA_FOREST_TRUST_COLLISION_INFORMATION struct {
+	RecordCount uint32
+	Entries     **LSA_FOREST_TRUST_COLLISION_RECORD
+}
+
+type LSA_ENUMERATION_INFORMATION struct {
+	Sid *windows.SID
+}
+
+const (
+	PolicyAuditEventsInformation    = 2
+	PolicyPrimaryDomainInformation  = 3
+	PolicyAccountDomainInformation  = 5
+	PolicyLsaServerRoleInformation  = 6
+	PolicyDnsDomainInformation      = 12
+	PolicyMachineAccountInformation = 15
+)
+
+type POLICY_AUDIT_EVENTS_INFO struct {
+	AuditingMode           byte
+	EventAuditingOptions   *uint32
+	MaximumAuditEventCount uint32
+}
+
+// POLICY_ACCOUNT_DOMAIN_INFO also describes POLICY_PRIMARY_DOMAIN_INFO,
+// which has the same layout.
+type POLICY_ACCOUNT_DOMAIN_INFO struct {
+	DomainName LSA_UNICODE_STRING
+	DomainSid  *windows.SID
+}
+
+type POLICY_LSA_SERVER_ROLE_INFO struct {
+	LsaServerRole uint32
+}
+
+type POLICY_DNS_DOMAIN_INFO struct {
+	Name          LSA_UNICODE_STRING
+	DnsDomainName LSA_UNICODE_STRING
+	DnsForestName LSA_UNICODE_STRING
+	DomainGuid    windows.GUID
+	Sid           *windows.SID
+}
+
+type POLICY_MACHINE_ACCT_INFO struct {
+	Rid uint32
+	Sid *windows.SID
+}
+
+const (
+	PolicyDomainEfsInformation            = 2
+	PolicyDomainKerberosTicketInformation = 3
+)
+
+type POLICY_DOMAIN_KERBEROS_TICKET_INFO struct {
+	AuthenticationOptions uint32
+	_                     uint32 // aligns the LARGE_INTEGER fields on 386
+	MaxServiceTicketAge   int64
+	MaxTicketAge          int64
+	MaxRenewAge           int64
+	MaxClockSkew          int64
+	Reserved              int64
+}
+
+type CENTRAL_ACCESS_POLICY struct {
+	CAPID       *windows.SID
+	Name        LSA_UNICODE_STRING
+	Description LSA_UNICODE_STRING
+	ChangeId    LSA_UNICODE_STRING
+	Flags       uint32
+	CAPECount   uint32
+	CAPEs       uintptr
+}
+
+type LSA_TRUST_INFORMATION struct {
+	Name LSA_UNICODE_STRING
+	Sid  *windows.SID
+}
+
+type LSA_REFERENCED_DOMAIN_LIST struct {
+	Entries uint32
+	Domains *LSA_TRUST_INFORMATION
+}
+
+type LSA_TRANSLATED_SID2 struct {
+	Use         uint32
+	Sid         *windows.SID
+	DomainIndex int32
+	Flags       uint32
+}
+
+type LSA_TRANSLATED_NAME struct {
+	Use         uint32
+	Name        LSA_UNICODE_STRING
+	DomainIndex int32
+}
+
+type AUDIT_POLICY_INFORMATION struct {
+	AuditSubCategoryGuid windows.GUID
+	AuditingInformation  uint32
+	AuditCategoryGuid    windows.GUID
+}
+
+type POLICY_AUDIT_SID_ARRAY struct {
+	UsersCount   uint32
+	UserSidArray **windows.SID
+}
+
+type TOKEN_STATISTICS struct {
+	TokenId            LUID
+	AuthenticationId   LUID
+	ExpirationTime     int64
+	TokenType          uint32
+	ImpersonationLevel uint32
+	DynamicCharged     uint32
+	DynamicAvailable   uint32
+	GroupCount         uint32
+	PrivilegeCount     uint32
+	ModifiedId         LUID
+}
+
+type TOKEN_ORIGIN struct {
+	OriginatingLogonSession LUID
+}
+
+const TrustedDomainInformationEx = 6
+
+type TRUSTED_DOMAIN_INFORMATION_EX struct {
+	Name            LSA_UNICODE_STRING
+	FlatName        LSA_UNICODE_STRING
+	Sid             *windows.SID
+	TrustDirection  uint32
+	TrustType       uint32
+	TrustAttributes uint32
+}
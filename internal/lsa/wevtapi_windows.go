@@ -0,0 +1,19 @@
+package lsa
+
+// The Windows Event Log API, used to correlate logon sessions with the
+// Security log events the LSA writes for them.
+
+type EVT_HANDLE uintptr
+
+const (
+	EvtQueryChannelPath      = 0x1
+	EvtQueryReverseDirection = 0x200
+
+	EvtRenderEventXml = 1
+)
+
+//sys	EvtQuery(session EVT_HANDLE, path *uint16, query *uint16, flags uint32) (handle EVT_HANDLE, err error) = wevtapi.EvtQuery
+//sys	EvtNext(resultSet EVT_HANDLE, eventsSize uint32, events *EVT_HANDLE, timeout uint32, flags uint32, returned *uint32) (err error) = wevtapi.EvtNext
+//sys	EvtRender(context EVT_HANDLE, fragment EVT_HANDLE, flags uint32, bufferSize uint32, buffer *byte, bufferUsed *uint32, propertyCount *uint32) (err error) = wevtapi.EvtRender
+//sys	EvtClose(object EVT_HANDLE) (err error) = wevtapi.EvtClose
+//sys	EvtCancel(object EVT_HANDLE) (err error) = wevtapi.EvtCancel
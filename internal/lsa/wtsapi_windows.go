@@ -0,0 +1,33 @@
+package lsa
+
+const (
+	WTS_CURRENT_SERVER_HANDLE = 0
+
+	WTSSessionInfo = 24
+)
+
+type WTSINFO struct {
+	State                   uint32
+	SessionId               uint32
+	IncomingBytes           uint32
+	OutgoingBytes           uint32
+	IncomingFrames          uint32
+	OutgoingFrames          uint32
+	IncomingCompressedBytes uint32
+	OutgoingCompressedBytes uint32
+	WinStationName          [32]uint16
+	Domain                  [17]uint16
+	UserName                [21]uint16
+	// Padding to the 8 byte alignment of LARGE_INTEGER, which Go does
+	// not apply to int64 on 386, see layout_windows.go.
+	_              uint32
+	ConnectTime    int64
+	DisconnectTime int64
+	LastInputTime  int64
+	LogonTime      int64
+	CurrentTime    int64
+}
+
+//sys	WTSLogoffSession(server windows.Handle, sessionID uint32, wait bool) (err error) = wtsapi32.WTSLogoffSession
+//sys	WTSDisconnectSession(server windows.Handle, sessionID uint32, wait bool) (err error) = wtsapi32.WTSDisconnectSession
+//sys	WTSQuerySessionInformation(server windows.Handle, sessionID uint32, infoClass uint32, buf **byte, bytesReturned *uint32) (err error) = wtsapi32.WTSQuerySessionInformationW
@@ -0,0 +1,579 @@
+// Code generated by 'go generate'; DO NOT EDIT.
+
+package lsa
+
+import (
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var _ unsafe.Pointer
+
+// Do the interface allocations only once for common
+// Errno values.
+const (
+	errnoERROR_IO_PENDING = 997
+)
+
+var (
+	errERROR_IO_PENDING error = syscall.Errno(errnoERROR_IO_PENDING)
+	errERROR_EINVAL     error = syscall.EINVAL
+)
+
+// errnoErr returns common boxed Errno values, to prevent
+// allocations at runtime.
+func errnoErr(e syscall.Errno) error {
+	switch e {
+	case 0:
+		return errERROR_EINVAL
+	case errnoERROR_IO_PENDING:
+		return errERROR_IO_PENDING
+	}
+	// TODO: add more here, after collecting data on the common
+	// error values see on Windows. (perhaps when running
+	// all.bat?)
+	return e
+}
+
+var (
+	modadvapi32 = windows.NewLazySystemDLL("advapi32.dll")
+	modkernel32 = windows.NewLazySystemDLL("kernel32.dll")
+	modnetapi32 = windows.NewLazySystemDLL("netapi32.dll")
+	modsecur32  = windows.NewLazySystemDLL("secur32.dll")
+	modwevtapi  = windows.NewLazySystemDLL("wevtapi.dll")
+	modwtsapi32 = windows.NewLazySystemDLL("wtsapi32.dll")
+
+	procAuditEnumerateCategories          = modadvapi32.NewProc("AuditEnumerateCategories")
+	procAuditEnumeratePerUserPolicy       = modadvapi32.NewProc("AuditEnumeratePerUserPolicy")
+	procAuditEnumerateSubCategories       = modadvapi32.NewProc("AuditEnumerateSubCategories")
+	procAuditFree                         = modadvapi32.NewProc("AuditFree")
+	procAuditLookupCategoryNameW          = modadvapi32.NewProc("AuditLookupCategoryNameW")
+	procAuditLookupSubCategoryNameW       = modadvapi32.NewProc("AuditLookupSubCategoryNameW")
+	procAuditQueryPerUserPolicy           = modadvapi32.NewProc("AuditQueryPerUserPolicy")
+	procAuditQuerySecurity                = modadvapi32.NewProc("AuditQuerySecurity")
+	procAuditQuerySystemPolicy            = modadvapi32.NewProc("AuditQuerySystemPolicy")
+	procAuditSetPerUserPolicy             = modadvapi32.NewProc("AuditSetPerUserPolicy")
+	procAuditSetSecurity                  = modadvapi32.NewProc("AuditSetSecurity")
+	procAuditSetSystemPolicy              = modadvapi32.NewProc("AuditSetSystemPolicy")
+	procCreateProcessWithTokenW           = modadvapi32.NewProc("CreateProcessWithTokenW")
+	procLogonUserW                        = modadvapi32.NewProc("LogonUserW")
+	procLookupPrivilegeNameW              = modadvapi32.NewProc("LookupPrivilegeNameW")
+	procLsaAddAccountRights               = modadvapi32.NewProc("LsaAddAccountRights")
+	procLsaClose                          = modadvapi32.NewProc("LsaClose")
+	procLsaCreateAccount                  = modadvapi32.NewProc("LsaCreateAccount")
+	procLsaDelete                         = modadvapi32.NewProc("LsaDelete")
+	procLsaEnumerateAccountRights         = modadvapi32.NewProc("LsaEnumerateAccountRights")
+	procLsaEnumerateAccounts              = modadvapi32.NewProc("LsaEnumerateAccounts")
+	procLsaEnumerateAccountsWithUserRight = modadvapi32.NewProc("LsaEnumerateAccountsWithUserRight")
+	procLsaEnumerateTrustedDomainsEx      = modadvapi32.NewProc("LsaEnumerateTrustedDomainsEx")
+	procLsaFreeMemory                     = modadvapi32.NewProc("LsaFreeMemory")
+	procLsaGetAppliedCAPIDs               = modadvapi32.NewProc("LsaGetAppliedCAPIDs")
+	procLsaGetSystemAccessAccount         = modadvapi32.NewProc("LsaGetSystemAccessAccount")
+	procLsaLookupNames2                   = modadvapi32.NewProc("LsaLookupNames2")
+	procLsaLookupPrivilegeDisplayName     = modadvapi32.NewProc("LsaLookupPrivilegeDisplayName")
+	procLsaLookupPrivilegeName            = modadvapi32.NewProc("LsaLookupPrivilegeName")
+	procLsaLookupPrivilegeValue           = modadvapi32.NewProc("LsaLookupPrivilegeValue")
+	procLsaLookupSids2                    = modadvapi32.NewProc("LsaLookupSids2")
+	procLsaNtStatusToWinError             = modadvapi32.NewProc("LsaNtStatusToWinError")
+	procLsaOpenAccount                    = modadvapi32.NewProc("LsaOpenAccount")
+	procLsaOpenPolicy                     = modadvapi32.NewProc("LsaOpenPolicy")
+	procLsaQueryCAPs                      = modadvapi32.NewProc("LsaQueryCAPs")
+	procLsaQueryDomainInformationPolicy   = modadvapi32.NewProc("LsaQueryDomainInformationPolicy")
+	procLsaQueryForestTrustInformation    = modadvapi32.NewProc("LsaQueryForestTrustInformation")
+	procLsaQueryInformationPolicy         = modadvapi32.NewProc("LsaQueryInformationPolicy")
+	procLsaQueryTrustedDomainInfoByName   = modadvapi32.NewProc("LsaQueryTrustedDomainInfoByName")
+	procLsaRemoveAccountRights            = modadvapi32.NewProc("LsaRemoveAccountRights")
+	procLsaRetrievePrivateData            = modadvapi32.NewProc("LsaRetrievePrivateData")
+	procLsaSetDomainInformationPolicy     = modadvapi32.NewProc("LsaSetDomainInformationPolicy")
+	procLsaSetForestTrustInformation      = modadvapi32.NewProc("LsaSetForestTrustInformation")
+	procLsaSetSystemAccessAccount         = modadvapi32.NewProc("LsaSetSystemAccessAccount")
+	procLsaStorePrivateData               = modadvapi32.NewProc("LsaStorePrivateData")
+	procIsProcessCritical                 = modkernel32.NewProc("IsProcessCritical")
+	procNetFreeAadJoinInformation         = modnetapi32.NewProc("NetFreeAadJoinInformation")
+	procNetGetAadJoinInformation          = modnetapi32.NewProc("NetGetAadJoinInformation")
+	procNetSessionEnum                    = modnetapi32.NewProc("NetSessionEnum")
+	procLsaCallAuthenticationPackage      = modsecur32.NewProc("LsaCallAuthenticationPackage")
+	procLsaConnectUntrusted               = modsecur32.NewProc("LsaConnectUntrusted")
+	procLsaDeregisterLogonProcess         = modsecur32.NewProc("LsaDeregisterLogonProcess")
+	procLsaEnumerateLogonSessions         = modsecur32.NewProc("LsaEnumerateLogonSessions")
+	procLsaFreeReturnBuffer               = modsecur32.NewProc("LsaFreeReturnBuffer")
+	procLsaGetLogonSessionData            = modsecur32.NewProc("LsaGetLogonSessionData")
+	procLsaLogonUser                      = modsecur32.NewProc("LsaLogonUser")
+	procLsaLookupAuthenticationPackage    = modsecur32.NewProc("LsaLookupAuthenticationPackage")
+	procLsaRegisterLogonProcess           = modsecur32.NewProc("LsaRegisterLogonProcess")
+	procEvtCancel                         = modwevtapi.NewProc("EvtCancel")
+	procEvtClose                          = modwevtapi.NewProc("EvtClose")
+	procEvtNext                           = modwevtapi.NewProc("EvtNext")
+	procEvtQuery                          = modwevtapi.NewProc("EvtQuery")
+	procEvtRender                         = modwevtapi.NewProc("EvtRender")
+	procWTSDisconnectSession              = modwtsapi32.NewProc("WTSDisconnectSession")
+	procWTSLogoffSession                  = modwtsapi32.NewProc("WTSLogoffSession")
+	procWTSQuerySessionInformationW       = modwtsapi32.NewProc("WTSQuerySessionInformationW")
+)
+
+func AuditEnumerateCategories(categories **windows.GUID, countReturned *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall(procAuditEnumerateCategories.Addr(), 2, uintptr(unsafe.Pointer(categories)), uintptr(unsafe.Pointer(countReturned)), 0)
+	if r1&0xff == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func AuditEnumeratePerUserPolicy(auditSidArray **POLICY_AUDIT_SID_ARRAY) (err error) {
+	r1, _, e1 := syscall.Syscall(procAuditEnumeratePerUserPolicy.Addr(), 1, uintptr(unsafe.Pointer(auditSidArray)), 0, 0)
+	if r1&0xff == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func AuditEnumerateSubCategories(category *windows.GUID, retrieveAll bool, subCategories **windows.GUID, countReturned *uint32) (err error) {
+	var _p0 uint32
+	if retrieveAll {
+		_p0 = 1
+	}
+	r1, _, e1 := syscall.Syscall6(procAuditEnumerateSubCategories.Addr(), 4, uintptr(unsafe.Pointer(category)), uintptr(_p0), uintptr(unsafe.Pointer(subCategories)), uintptr(unsafe.Pointer(countReturned)), 0, 0)
+	if r1&0xff == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func AuditFree(buffer unsafe.Pointer) {
+	syscall.Syscall(procAuditFree.Addr(), 1, uintptr(buffer), 0, 0)
+	return
+}
+
+func AuditLookupCategoryName(category *windows.GUID, name **uint16) (err error) {
+	r1, _, e1 := syscall.Syscall(procAuditLookupCategoryNameW.Addr(), 2, uintptr(unsafe.Pointer(category)), uintptr(unsafe.Pointer(name)), 0)
+	if r1&0xff == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func AuditLookupSubCategoryName(subCategory *windows.GUID, name **uint16) (err error) {
+	r1, _, e1 := syscall.Syscall(procAuditLookupSubCategoryNameW.Addr(), 2, uintptr(unsafe.Pointer(subCategory)), uintptr(unsafe.Pointer(name)), 0)
+	if r1&0xff == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func AuditQueryPerUserPolicy(sid *windows.SID, subCategories *windows.GUID, policyCount uint32, policy **AUDIT_POLICY_INFORMATION) (err error) {
+	r1, _, e1 := syscall.Syscall6(procAuditQueryPerUserPolicy.Addr(), 4, uintptr(unsafe.Pointer(sid)), uintptr(unsafe.Pointer(subCategories)), uintptr(policyCount), uintptr(unsafe.Pointer(policy)), 0, 0)
+	if r1&0xff == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func AuditQuerySecurity(securityInformation windows.SECURITY_INFORMATION, securityDescriptor **windows.SECURITY_DESCRIPTOR) (err error) {
+	r1, _, e1 := syscall.Syscall(procAuditQuerySecurity.Addr(), 2, uintptr(securityInformation), uintptr(unsafe.Pointer(securityDescriptor)), 0)
+	if r1&0xff == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func AuditQuerySystemPolicy(subCategories *windows.GUID, policyCount uint32, policy **AUDIT_POLICY_INFORMATION) (err error) {
+	r1, _, e1 := syscall.Syscall(procAuditQuerySystemPolicy.Addr(), 3, uintptr(unsafe.Pointer(subCategories)), uintptr(policyCount), uintptr(unsafe.Pointer(policy)))
+	if r1&0xff == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func AuditSetPerUserPolicy(sid *windows.SID, policy *AUDIT_POLICY_INFORMATION, policyCount uint32) (err error) {
+	r1, _, e1 := syscall.Syscall(procAuditSetPerUserPolicy.Addr(), 3, uintptr(unsafe.Pointer(sid)), uintptr(unsafe.Pointer(policy)), uintptr(policyCount))
+	if r1&0xff == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func AuditSetSecurity(securityInformation windows.SECURITY_INFORMATION, securityDescriptor *windows.SECURITY_DESCRIPTOR) (err error) {
+	r1, _, e1 := syscall.Syscall(procAuditSetSecurity.Addr(), 2, uintptr(securityInformation), uintptr(unsafe.Pointer(securityDescriptor)), 0)
+	if r1&0xff == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func AuditSetSystemPolicy(policy *AUDIT_POLICY_INFORMATION, policyCount uint32) (err error) {
+	r1, _, e1 := syscall.Syscall(procAuditSetSystemPolicy.Addr(), 2, uintptr(unsafe.Pointer(policy)), uintptr(policyCount), 0)
+	if r1&0xff == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func CreateProcessWithToken(token windows.Token, logonFlags uint32, appName *uint16, commandLine *uint16, creationFlags uint32, env *uint16, currentDir *uint16, startupInfo *windows.StartupInfo, outProcInfo *windows.ProcessInformation) (err error) {
+	r1, _, e1 := syscall.Syscall9(procCreateProcessWithTokenW.Addr(), 9, uintptr(token), uintptr(logonFlags), uintptr(unsafe.Pointer(appName)), uintptr(unsafe.Pointer(commandLine)), uintptr(creationFlags), uintptr(unsafe.Pointer(env)), uintptr(unsafe.Pointer(currentDir)), uintptr(unsafe.Pointer(startupInfo)), uintptr(unsafe.Pointer(outProcInfo)))
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func LogonUser(username *uint16, domain *uint16, password *uint16, logonType uint32, logonProvider uint32, token *windows.Token) (err error) {
+	r1, _, e1 := syscall.Syscall6(procLogonUserW.Addr(), 6, uintptr(unsafe.Pointer(username)), uintptr(unsafe.Pointer(domain)), uintptr(unsafe.Pointer(password)), uintptr(logonType), uintptr(logonProvider), uintptr(unsafe.Pointer(token)))
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func LookupPrivilegeName(systemName *uint16, luid *LUID, name *uint16, nameLen *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall6(procLookupPrivilegeNameW.Addr(), 4, uintptr(unsafe.Pointer(systemName)), uintptr(unsafe.Pointer(luid)), uintptr(unsafe.Pointer(name)), uintptr(unsafe.Pointer(nameLen)), 0, 0)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func lsaAddAccountRights(policyHandle LSA_HANDLE, accountSid *windows.SID, userRights *LSA_UNICODE_STRING, countOfRights uint32) (status uintptr) {
+	r0, _, _ := syscall.Syscall6(procLsaAddAccountRights.Addr(), 4, uintptr(policyHandle), uintptr(unsafe.Pointer(accountSid)), uintptr(unsafe.Pointer(userRights)), uintptr(countOfRights), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaClose(objectHandle LSA_HANDLE) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaClose.Addr(), 1, uintptr(objectHandle), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaCreateAccount(policyHandle LSA_HANDLE, accountSid *windows.SID, desiredAccess uint32, accountHandle *LSA_HANDLE) (status uintptr) {
+	r0, _, _ := syscall.Syscall6(procLsaCreateAccount.Addr(), 4, uintptr(policyHandle), uintptr(unsafe.Pointer(accountSid)), uintptr(desiredAccess), uintptr(unsafe.Pointer(accountHandle)), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaDelete(objectHandle LSA_HANDLE) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaDelete.Addr(), 1, uintptr(objectHandle), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaEnumerateAccountRights(policyHandle LSA_HANDLE, accountSid *windows.SID, userRights **LSA_UNICODE_STRING, countOfRights *uint32) (status uintptr) {
+	r0, _, _ := syscall.Syscall6(procLsaEnumerateAccountRights.Addr(), 4, uintptr(policyHandle), uintptr(unsafe.Pointer(accountSid)), uintptr(unsafe.Pointer(userRights)), uintptr(unsafe.Pointer(countOfRights)), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaEnumerateAccounts(policyHandle LSA_HANDLE, enumerationContext *uint32, buffer **LSA_ENUMERATION_INFORMATION, preferedMaximumLength uint32, countReturned *uint32) (status uintptr) {
+	r0, _, _ := syscall.Syscall6(procLsaEnumerateAccounts.Addr(), 5, uintptr(policyHandle), uintptr(unsafe.Pointer(enumerationContext)), uintptr(unsafe.Pointer(buffer)), uintptr(preferedMaximumLength), uintptr(unsafe.Pointer(countReturned)), 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaEnumerateAccountsWithUserRight(policyHandle LSA_HANDLE, userRight *LSA_UNICODE_STRING, buffer **LSA_ENUMERATION_INFORMATION, countReturned *uint32) (status uintptr) {
+	r0, _, _ := syscall.Syscall6(procLsaEnumerateAccountsWithUserRight.Addr(), 4, uintptr(policyHandle), uintptr(unsafe.Pointer(userRight)), uintptr(unsafe.Pointer(buffer)), uintptr(unsafe.Pointer(countReturned)), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaEnumerateTrustedDomainsEx(policyHandle LSA_HANDLE, enumerationContext *uint32, buffer **TRUSTED_DOMAIN_INFORMATION_EX, preferedMaximumLength uint32, countReturned *uint32) (status uintptr) {
+	r0, _, _ := syscall.Syscall6(procLsaEnumerateTrustedDomainsEx.Addr(), 5, uintptr(policyHandle), uintptr(unsafe.Pointer(enumerationContext)), uintptr(unsafe.Pointer(buffer)), uintptr(preferedMaximumLength), uintptr(unsafe.Pointer(countReturned)), 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaFreeMemory(buffer uintptr) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaFreeMemory.Addr(), 1, uintptr(buffer), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaGetAppliedCAPIDs(systemName *LSA_UNICODE_STRING, capids ***windows.SID, capidCount *uint32) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaGetAppliedCAPIDs.Addr(), 3, uintptr(unsafe.Pointer(systemName)), uintptr(unsafe.Pointer(capids)), uintptr(unsafe.Pointer(capidCount)))
+	status = uintptr(r0)
+	return
+}
+
+func lsaGetSystemAccessAccount(accountHandle LSA_HANDLE, systemAccess *uint32) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaGetSystemAccessAccount.Addr(), 2, uintptr(accountHandle), uintptr(unsafe.Pointer(systemAccess)), 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaLookupNames2(policyHandle LSA_HANDLE, flags uint32, count uint32, names *LSA_UNICODE_STRING, referencedDomains **LSA_REFERENCED_DOMAIN_LIST, sids **LSA_TRANSLATED_SID2) (status uintptr) {
+	r0, _, _ := syscall.Syscall6(procLsaLookupNames2.Addr(), 6, uintptr(policyHandle), uintptr(flags), uintptr(count), uintptr(unsafe.Pointer(names)), uintptr(unsafe.Pointer(referencedDomains)), uintptr(unsafe.Pointer(sids)))
+	status = uintptr(r0)
+	return
+}
+
+func lsaLookupPrivilegeDisplayName(policyHandle LSA_HANDLE, name *LSA_UNICODE_STRING, displayName **LSA_UNICODE_STRING, languageReturned *int16) (status uintptr) {
+	r0, _, _ := syscall.Syscall6(procLsaLookupPrivilegeDisplayName.Addr(), 4, uintptr(policyHandle), uintptr(unsafe.Pointer(name)), uintptr(unsafe.Pointer(displayName)), uintptr(unsafe.Pointer(languageReturned)), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaLookupPrivilegeName(policyHandle LSA_HANDLE, value *LUID, name **LSA_UNICODE_STRING) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaLookupPrivilegeName.Addr(), 3, uintptr(policyHandle), uintptr(unsafe.Pointer(value)), uintptr(unsafe.Pointer(name)))
+	status = uintptr(r0)
+	return
+}
+
+func lsaLookupPrivilegeValue(policyHandle LSA_HANDLE, name *LSA_UNICODE_STRING, value *LUID) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaLookupPrivilegeValue.Addr(), 3, uintptr(policyHandle), uintptr(unsafe.Pointer(name)), uintptr(unsafe.Pointer(value)))
+	status = uintptr(r0)
+	return
+}
+
+func lsaLookupSids2(policyHandle LSA_HANDLE, lookupOptions uint32, count uint32, sids **windows.SID, referencedDomains **LSA_REFERENCED_DOMAIN_LIST, names **LSA_TRANSLATED_NAME) (status uintptr) {
+	r0, _, _ := syscall.Syscall6(procLsaLookupSids2.Addr(), 6, uintptr(policyHandle), uintptr(lookupOptions), uintptr(count), uintptr(unsafe.Pointer(sids)), uintptr(unsafe.Pointer(referencedDomains)), uintptr(unsafe.Pointer(names)))
+	status = uintptr(r0)
+	return
+}
+
+func lsaNtStatusToWinError(status uintptr) (winerr syscall.Errno) {
+	r0, _, _ := syscall.Syscall(procLsaNtStatusToWinError.Addr(), 1, uintptr(status), 0, 0)
+	winerr = syscall.Errno(r0)
+	return
+}
+
+func lsaOpenAccount(policyHandle LSA_HANDLE, accountSid *windows.SID, desiredAccess uint32, accountHandle *LSA_HANDLE) (status uintptr) {
+	r0, _, _ := syscall.Syscall6(procLsaOpenAccount.Addr(), 4, uintptr(policyHandle), uintptr(unsafe.Pointer(accountSid)), uintptr(desiredAccess), uintptr(unsafe.Pointer(accountHandle)), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaOpenPolicy(systemName *LSA_UNICODE_STRING, objectAttributes *LSA_OBJECT_ATTRIBUTES, desiredAccess uint32, policyHandle *LSA_HANDLE) (status uintptr) {
+	r0, _, _ := syscall.Syscall6(procLsaOpenPolicy.Addr(), 4, uintptr(unsafe.Pointer(systemName)), uintptr(unsafe.Pointer(objectAttributes)), uintptr(desiredAccess), uintptr(unsafe.Pointer(policyHandle)), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaQueryCAPs(capids **windows.SID, capidCount uint32, caps **CENTRAL_ACCESS_POLICY, capCount *uint32) (status uintptr) {
+	r0, _, _ := syscall.Syscall6(procLsaQueryCAPs.Addr(), 4, uintptr(unsafe.Pointer(capids)), uintptr(capidCount), uintptr(unsafe.Pointer(caps)), uintptr(unsafe.Pointer(capCount)), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaQueryDomainInformationPolicy(policyHandle LSA_HANDLE, informationClass uint32, buffer *unsafe.Pointer) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaQueryDomainInformationPolicy.Addr(), 3, uintptr(policyHandle), uintptr(informationClass), uintptr(unsafe.Pointer(buffer)))
+	status = uintptr(r0)
+	return
+}
+
+func lsaQueryForestTrustInformation(policyHandle LSA_HANDLE, trustedDomainName *LSA_UNICODE_STRING, forestTrustInfo **LSA_FOREST_TRUST_INFORMATION) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaQueryForestTrustInformation.Addr(), 3, uintptr(policyHandle), uintptr(unsafe.Pointer(trustedDomainName)), uintptr(unsafe.Pointer(forestTrustInfo)))
+	status = uintptr(r0)
+	return
+}
+
+func lsaQueryInformationPolicy(policyHandle LSA_HANDLE, informationClass uint32, buffer *unsafe.Pointer) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaQueryInformationPolicy.Addr(), 3, uintptr(policyHandle), uintptr(informationClass), uintptr(unsafe.Pointer(buffer)))
+	status = uintptr(r0)
+	return
+}
+
+func lsaQueryTrustedDomainInfoByName(policyHandle LSA_HANDLE, trustedDomainName *LSA_UNICODE_STRING, informationClass uint32, buffer *unsafe.Pointer) (status uintptr) {
+	r0, _, _ := syscall.Syscall6(procLsaQueryTrustedDomainInfoByName.Addr(), 4, uintptr(policyHandle), uintptr(unsafe.Pointer(trustedDomainName)), uintptr(informationClass), uintptr(unsafe.Pointer(buffer)), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaRemoveAccountRights(policyHandle LSA_HANDLE, accountSid *windows.SID, allRights bool, userRights *LSA_UNICODE_STRING, countOfRights uint32) (status uintptr) {
+	var _p0 uint32
+	if allRights {
+		_p0 = 1
+	}
+	r0, _, _ := syscall.Syscall6(procLsaRemoveAccountRights.Addr(), 5, uintptr(policyHandle), uintptr(unsafe.Pointer(accountSid)), uintptr(_p0), uintptr(unsafe.Pointer(userRights)), uintptr(countOfRights), 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaRetrievePrivateData(policyHandle LSA_HANDLE, keyName *LSA_UNICODE_STRING, privateData **LSA_UNICODE_STRING) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaRetrievePrivateData.Addr(), 3, uintptr(policyHandle), uintptr(unsafe.Pointer(keyName)), uintptr(unsafe.Pointer(privateData)))
+	status = uintptr(r0)
+	return
+}
+
+func lsaSetDomainInformationPolicy(policyHandle LSA_HANDLE, informationClass uint32, buffer unsafe.Pointer) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaSetDomainInformationPolicy.Addr(), 3, uintptr(policyHandle), uintptr(informationClass), uintptr(buffer))
+	status = uintptr(r0)
+	return
+}
+
+func lsaSetForestTrustInformation(policyHandle LSA_HANDLE, trustedDomainName *LSA_UNICODE_STRING, forestTrustInfo *LSA_FOREST_TRUST_INFORMATION, checkOnly bool, collisionInfo **LSA_FOREST_TRUST_COLLISION_INFORMATION) (status uintptr) {
+	var _p0 uint32
+	if checkOnly {
+		_p0 = 1
+	}
+	r0, _, _ := syscall.Syscall6(procLsaSetForestTrustInformation.Addr(), 5, uintptr(policyHandle), uintptr(unsafe.Pointer(trustedDomainName)), uintptr(unsafe.Pointer(forestTrustInfo)), uintptr(_p0), uintptr(unsafe.Pointer(collisionInfo)), 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaSetSystemAccessAccount(accountHandle LSA_HANDLE, systemAccess uint32) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaSetSystemAccessAccount.Addr(), 2, uintptr(accountHandle), uintptr(systemAccess), 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaStorePrivateData(policyHandle LSA_HANDLE, keyName *LSA_UNICODE_STRING, privateData *LSA_UNICODE_STRING) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaStorePrivateData.Addr(), 3, uintptr(policyHandle), uintptr(unsafe.Pointer(keyName)), uintptr(unsafe.Pointer(privateData)))
+	status = uintptr(r0)
+	return
+}
+
+func isProcessCritical(process windows.Handle, critical *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall(procIsProcessCritical.Addr(), 2, uintptr(process), uintptr(unsafe.Pointer(critical)), 0)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func NetFreeAadJoinInformation(info *DSREG_JOIN_INFO) {
+	syscall.Syscall(procNetFreeAadJoinInformation.Addr(), 1, uintptr(unsafe.Pointer(info)), 0, 0)
+	return
+}
+
+func NetGetAadJoinInformation(tenantID *uint16, info **DSREG_JOIN_INFO) (hr error) {
+	r0, _, _ := syscall.Syscall(procNetGetAadJoinInformation.Addr(), 2, uintptr(unsafe.Pointer(tenantID)), uintptr(unsafe.Pointer(info)), 0)
+	if r0 != 0 {
+		hr = syscall.Errno(r0)
+	}
+	return
+}
+
+func NetSessionEnum(server *uint16, client *uint16, user *uint16, level uint32, buf **byte, prefmaxlen uint32, entriesRead *uint32, totalEntries *uint32, resumeHandle *uint32) (neterr error) {
+	r0, _, _ := syscall.Syscall9(procNetSessionEnum.Addr(), 9, uintptr(unsafe.Pointer(server)), uintptr(unsafe.Pointer(client)), uintptr(unsafe.Pointer(user)), uintptr(level), uintptr(unsafe.Pointer(buf)), uintptr(prefmaxlen), uintptr(unsafe.Pointer(entriesRead)), uintptr(unsafe.Pointer(totalEntries)), uintptr(unsafe.Pointer(resumeHandle)))
+	if r0 != 0 {
+		neterr = syscall.Errno(r0)
+	}
+	return
+}
+
+func lsaCallAuthenticationPackage(lsaHandle LSA_HANDLE, authenticationPackage uint32, protocolSubmitBuffer unsafe.Pointer, submitBufferLength uint32, protocolReturnBuffer *unsafe.Pointer, returnBufferLength *uint32, protocolStatus *uint32) (status uintptr) {
+	r0, _, _ := syscall.Syscall9(procLsaCallAuthenticationPackage.Addr(), 7, uintptr(lsaHandle), uintptr(authenticationPackage), uintptr(protocolSubmitBuffer), uintptr(submitBufferLength), uintptr(unsafe.Pointer(protocolReturnBuffer)), uintptr(unsafe.Pointer(returnBufferLength)), uintptr(unsafe.Pointer(protocolStatus)), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaConnectUntrusted(lsaHandle *LSA_HANDLE) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaConnectUntrusted.Addr(), 1, uintptr(unsafe.Pointer(lsaHandle)), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaDeregisterLogonProcess(lsaHandle LSA_HANDLE) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaDeregisterLogonProcess.Addr(), 1, uintptr(lsaHandle), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaEnumerateLogonSessions(sessionCount *uint32, sessions **LUID) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaEnumerateLogonSessions.Addr(), 2, uintptr(unsafe.Pointer(sessionCount)), uintptr(unsafe.Pointer(sessions)), 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaFreeReturnBuffer(buffer uintptr) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaFreeReturnBuffer.Addr(), 1, uintptr(buffer), 0, 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaGetLogonSessionData(luid *LUID, sessionData **SECURITY_LOGON_SESSION_DATA) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaGetLogonSessionData.Addr(), 2, uintptr(unsafe.Pointer(luid)), uintptr(unsafe.Pointer(sessionData)), 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaLogonUser(lsaHandle LSA_HANDLE, originName *LSA_STRING, logonType uint32, authenticationPackage uint32, authenticationInformation unsafe.Pointer, authenticationInformationLength uint32, localGroups *windows.Tokengroups, sourceContext *TOKEN_SOURCE, profileBuffer *unsafe.Pointer, profileBufferLength *uint32, logonId *LUID, token *windows.Token, quotas *QUOTA_LIMITS, subStatus *uint32) (status uintptr) {
+	r0, _, _ := syscall.Syscall15(procLsaLogonUser.Addr(), 14, uintptr(lsaHandle), uintptr(unsafe.Pointer(originName)), uintptr(logonType), uintptr(authenticationPackage), uintptr(authenticationInformation), uintptr(authenticationInformationLength), uintptr(unsafe.Pointer(localGroups)), uintptr(unsafe.Pointer(sourceContext)), uintptr(unsafe.Pointer(profileBuffer)), uintptr(unsafe.Pointer(profileBufferLength)), uintptr(unsafe.Pointer(logonId)), uintptr(unsafe.Pointer(token)), uintptr(unsafe.Pointer(quotas)), uintptr(unsafe.Pointer(subStatus)), 0)
+	status = uintptr(r0)
+	return
+}
+
+func lsaLookupAuthenticationPackage(lsaHandle LSA_HANDLE, packageName *LSA_STRING, authenticationPackage *uint32) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaLookupAuthenticationPackage.Addr(), 3, uintptr(lsaHandle), uintptr(unsafe.Pointer(packageName)), uintptr(unsafe.Pointer(authenticationPackage)))
+	status = uintptr(r0)
+	return
+}
+
+func lsaRegisterLogonProcess(logonProcessName *LSA_STRING, lsaHandle *LSA_HANDLE, securityMode *uint32) (status uintptr) {
+	r0, _, _ := syscall.Syscall(procLsaRegisterLogonProcess.Addr(), 3, uintptr(unsafe.Pointer(logonProcessName)), uintptr(unsafe.Pointer(lsaHandle)), uintptr(unsafe.Pointer(securityMode)))
+	status = uintptr(r0)
+	return
+}
+
+func EvtCancel(object EVT_HANDLE) (err error) {
+	r1, _, e1 := syscall.Syscall(procEvtCancel.Addr(), 1, uintptr(object), 0, 0)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func EvtClose(object EVT_HANDLE) (err error) {
+	r1, _, e1 := syscall.Syscall(procEvtClose.Addr(), 1, uintptr(object), 0, 0)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func EvtNext(resultSet EVT_HANDLE, eventsSize uint32, events *EVT_HANDLE, timeout uint32, flags uint32, returned *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall6(procEvtNext.Addr(), 6, uintptr(resultSet), uintptr(eventsSize), uintptr(unsafe.Pointer(events)), uintptr(timeout), uintptr(flags), uintptr(unsafe.Pointer(returned)))
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func EvtQuery(session EVT_HANDLE, path *uint16, query *uint16, flags uint32) (handle EVT_HANDLE, err error) {
+	r0, _, e1 := syscall.Syscall6(procEvtQuery.Addr(), 4, uintptr(session), uintptr(unsafe.Pointer(path)), uintptr(unsafe.Pointer(query)), uintptr(flags), 0, 0)
+	handle = EVT_HANDLE(r0)
+	if handle == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func EvtRender(context EVT_HANDLE, fragment EVT_HANDLE, flags uint32, bufferSize uint32, buffer *byte, bufferUsed *uint32, propertyCount *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall9(procEvtRender.Addr(), 7, uintptr(context), uintptr(fragment), uintptr(flags), uintptr(bufferSize), uintptr(unsafe.Pointer(buffer)), uintptr(unsafe.Pointer(bufferUsed)), uintptr(unsafe.Pointer(propertyCount)), 0, 0)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func WTSDisconnectSession(server windows.Handle, sessionID uint32, wait bool) (err error) {
+	var _p0 uint32
+	if wait {
+		_p0 = 1
+	}
+	r1, _, e1 := syscall.Syscall(procWTSDisconnectSession.Addr(), 3, uintptr(server), uintptr(sessionID), uintptr(_p0))
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func WTSLogoffSession(server windows.Handle, sessionID uint32, wait bool) (err error) {
+	var _p0 uint32
+	if wait {
+		_p0 = 1
+	}
+	r1, _, e1 := syscall.Syscall(procWTSLogoffSession.Addr(), 3, uintptr(server), uintptr(sessionID), uintptr(_p0))
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
+
+func WTSQuerySessionInformation(server windows.Handle, sessionID uint32, infoClass uint32, buf **byte, bytesReturned *uint32) (err error) {
+	r1, _, e1 := syscall.Syscall6(procWTSQuerySessionInformationW.Addr(), 5, uintptr(server), uintptr(sessionID), uintptr(infoClass), uintptr(unsafe.Pointer(buf)), uintptr(unsafe.Pointer(bytesReturned)), 0)
+	if r1 == 0 {
+		err = errnoErr(e1)
+	}
+	return
+}
@@ -0,0 +1,138 @@
+package winlsa
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (lt LogonType) MarshalText() ([]byte, error) {
+	return []byte(lt.String()), nil
+}
+
+// UnmarshalText accepts the names returned by String as well as plain
+// numbers.
+func (lt *LogonType) UnmarshalText(text []byte) error {
+	s := string(text)
+	for t := LogonTypeSystem; t <= LogonTypeCachedUnlock; t++ {
+		if strings.EqualFold(s, t.String()) {
+			*lt = t
+			return nil
+		}
+	}
+	var n uint32
+	_, err := fmt.Sscanf(s, "%d", &n)
+	if err != nil {
+		return fmt.Errorf("invalid logon type %q", s)
+	}
+	*lt = LogonType(n)
+	return nil
+}
+
+// MarshalJSON encodes sd with the SID in string form and unset times as null.
+func (sd LogonSessionData) MarshalJSON() ([]byte, error) {
+	type sessionData LogonSessionData
+	return json.Marshal(struct {
+		sessionData
+		Sid                 string
+		LogonTime           *time.Time
+		LastSuccessfulLogon *time.Time
+		LastFailedLogon     *time.Time
+		LogoffTime          *time.Time
+		KickOffTime         *time.Time
+		PasswordLastSet     *time.Time
+		PasswordCanChange   *time.Time
+		PasswordMustChange  *time.Time
+	}{
+		sessionData:         sessionData(sd),
+		Sid:                 lsa.SidString(sd.Sid),
+		LogonTime:           optionalTime(sd.LogonTime),
+		LastSuccessfulLogon: optionalTime(sd.LastSuccessfulLogon),
+		LastFailedLogon:     optionalTime(sd.LastFailedLogon),
+		LogoffTime:          optionalTime(sd.LogoffTime),
+		KickOffTime:         optionalTime(sd.KickOffTime),
+		PasswordLastSet:     optionalTime(sd.PasswordLastSet),
+		PasswordCanChange:   optionalTime(sd.PasswordCanChange),
+		PasswordMustChange:  optionalTime(sd.PasswordMustChange),
+	})
+}
+
+// UnmarshalJSON decodes the encoding produced by MarshalJSON.
+func (sd *LogonSessionData) UnmarshalJSON(data []byte) error {
+	type sessionData LogonSessionData
+	v := struct {
+		*sessionData
+		Sid                 string
+		LogonTime           *time.Time
+		LastSuccessfulLogon *time.Time
+		LastFailedLogon     *time.Time
+		LogoffTime          *time.Time
+		KickOffTime         *time.Time
+		PasswordLastSet     *time.Time
+		PasswordCanChange   *time.Time
+		PasswordMustChange  *time.Time
+	}{sessionData: (*sessionData)(sd)}
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+	sd.Sid = nil
+	if v.Sid != "" {
+		sd.Sid, err = lsa.StringToSid(v.Sid)
+		if err != nil {
+			return fmt.Errorf("invalid SID %q: %v", v.Sid, err)
+		}
+	}
+	sd.LogonTime = requiredTime(v.LogonTime)
+	sd.LastSuccessfulLogon = requiredTime(v.LastSuccessfulLogon)
+	sd.LastFailedLogon = requiredTime(v.LastFailedLogon)
+	sd.LogoffTime = requiredTime(v.LogoffTime)
+	sd.KickOffTime = requiredTime(v.KickOffTime)
+	sd.PasswordLastSet = requiredTime(v.PasswordLastSet)
+	sd.PasswordCanChange = requiredTime(v.PasswordCanChange)
+	sd.PasswordMustChange = requiredTime(v.PasswordMustChange)
+	return nil
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func requiredTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+func (il IntegrityLevel) MarshalText() ([]byte, error) {
+	return []byte(il.String()), nil
+}
+
+func (et ElevationType) MarshalText() ([]byte, error) {
+	return []byte(et.String()), nil
+}
+
+// MarshalJSON encodes g with the SID in string form.
+func (g Group) MarshalJSON() ([]byte, error) {
+	type group Group
+	return json.Marshal(struct {
+		group
+		Sid string
+	}{group(g), lsa.SidString(g.Sid)})
+}
+
+// MarshalJSON encodes ti with the user SID in string form.
+func (ti TokenInfo) MarshalJSON() ([]byte, error) {
+	type tokenInfo TokenInfo
+	return json.Marshal(struct {
+		tokenInfo
+		User string
+	}{tokenInfo(ti), lsa.SidString(ti.User)})
+}
@@ -0,0 +1,45 @@
+package kerberos
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// A TicketCacheInfo describes a ticket in a logon session's ticket cache.
+type TicketCacheInfo struct {
+	ClientName     string
+	ClientRealm    string
+	ServerName     string
+	ServerRealm    string
+	StartTime      time.Time
+	EndTime        time.Time
+	RenewTime      time.Time
+	EncryptionType EncryptionType
+	TicketFlags    TicketFlags
+	SessionKeyType EncryptionType
+	BranchId       uint32
+}
+
+// IsTGT reports whether the ticket is a ticket-granting ticket.
+func (t *TicketCacheInfo) IsTGT() bool {
+	return strings.HasPrefix(strings.ToLower(t.ServerName), "krbtgt/")
+}
+
+// QueryTicketCache lists the tickets cached for the logon session luid. The
+// zero LUID refers to the caller's logon session.
+func (c *Conn) QueryTicketCache(luid LUID) ([]TicketCacheInfo, error) {
+	return c.QueryTicketCacheContext(context.Background(), luid)
+}
+
+// QueryTicketCacheContext is like QueryTicketCache but starts its
+// operation as a child of the span carried by ctx; see
+// winlsa.Instrumentation. The query is not interrupted when ctx is done,
+// but it is not retried after.
+func (c *Conn) QueryTicketCacheContext(ctx context.Context, luid LUID) (_ []TicketCacheInfo, err error) {
+	ctx, op := lsa.StartOp(ctx, "kerberos.QueryTicketCache", &luid)
+	defer func() { op.End(err) }()
+	return c.queryTicketCache(ctx, op, luid)
+}
@@ -0,0 +1,50 @@
+package kerberos
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (c *Conn) queryTicketCache(ctx context.Context, op lsa.Op, luid LUID) ([]TicketCacheInfo, error) {
+	req := lsa.KERB_QUERY_TKT_CACHE_REQUEST{
+		MessageType: lsa.KerbQueryTicketCacheEx2Message,
+		LogonId:     luid,
+	}
+	var resp unsafe.Pointer
+	err := lsa.Retry(ctx, func() (err error) {
+		resp, _, err = c.call(op, unsafe.Pointer(&req), unsafe.Sizeof(req))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	tickets := decodeTicketCache((*lsa.KERB_QUERY_TKT_CACHE_EX2_RESPONSE)(resp))
+
+	return tickets, freeReturnBuffer(op, resp)
+}
+
+func decodeTicketCache(header *lsa.KERB_QUERY_TKT_CACHE_EX2_RESPONSE) []TicketCacheInfo {
+	data := unsafe.Slice((*lsa.KERB_TICKET_CACHE_INFO_EX2)(unsafe.Pointer(&header.Tickets)), header.CountOfTickets)
+	tickets := make([]TicketCacheInfo, len(data))
+	for idx, t := range data {
+		tickets[idx] = TicketCacheInfo{
+			ClientName:     t.ClientName.String(),
+			ClientRealm:    t.ClientRealm.String(),
+			ServerName:     t.ServerName.String(),
+			ServerRealm:    t.ServerRealm.String(),
+			StartTime:      lsa.TimeFromUint64(t.StartTime),
+			EndTime:        lsa.TimeFromUint64(t.EndTime),
+			RenewTime:      lsa.TimeFromUint64(t.RenewTime),
+			EncryptionType: EncryptionType(t.EncryptionType),
+			TicketFlags:    TicketFlags(t.TicketFlags),
+			SessionKeyType: EncryptionType(t.SessionKeyType),
+			BranchId:       t.BranchId,
+		}
+	}
+	return tickets
+}
@@ -0,0 +1,241 @@
+package kerberos
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ccacheVersion is the MIT krb5 file credential cache format written by
+// WriteCCache, version 4. ReadCCache also reads version 3, which lacks the
+// header and repeats the key type.
+const ccacheVersion = 0x0504
+
+// WriteCCache writes tickets to w as an MIT krb5 file credential cache, as
+// read by kinit, klist and gokrb5. The default principal is the client of
+// the first ticket. Tickets without a SessionKey are written, but are of no
+// use to other Kerberos implementations.
+func WriteCCache(w io.Writer, tickets []*Ticket) error {
+	if len(tickets) == 0 {
+		return errors.New("kerberos: no tickets")
+	}
+	cw := &ccacheWriter{w: bufio.NewWriter(w)}
+	cw.uint16(ccacheVersion)
+	// One header tag, the KDC time offset, set to zero.
+	cw.uint16(12)
+	cw.uint16(1)
+	cw.uint16(8)
+	cw.uint32(0)
+	cw.uint32(0)
+	cw.principal(nameTypePrincipal, tickets[0].ClientName, tickets[0].clientRealm())
+	for _, t := range tickets {
+		cw.principal(nameTypePrincipal, t.ClientName, t.clientRealm())
+		cw.principal(nameTypeSrvInst, t.ServiceName, t.DomainName)
+		cw.uint16(uint16(t.SessionKeyType))
+		cw.data(t.SessionKey)
+		cw.time(t.StartTime) // authtime
+		cw.time(t.StartTime)
+		cw.time(t.EndTime)
+		cw.time(t.RenewUntil)
+		cw.w.WriteByte(0) // is_skey
+		cw.uint32(uint32(t.TicketFlags))
+		cw.uint32(0) // addresses
+		cw.uint32(0) // authdata
+		cw.data(t.EncodedTicket)
+		cw.data(nil) // second_ticket
+	}
+	if cw.err != nil {
+		return cw.err
+	}
+	return cw.w.Flush()
+}
+
+type ccacheWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (cw *ccacheWriter) write(b []byte) {
+	if cw.err == nil {
+		_, cw.err = cw.w.Write(b)
+	}
+}
+
+func (cw *ccacheWriter) uint16(v uint16) {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], v)
+	cw.write(b[:])
+}
+
+func (cw *ccacheWriter) uint32(v uint32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	cw.write(b[:])
+}
+
+func (cw *ccacheWriter) data(b []byte) {
+	cw.uint32(uint32(len(b)))
+	cw.write(b)
+}
+
+func (cw *ccacheWriter) time(t time.Time) {
+	if t.IsZero() {
+		cw.uint32(0)
+		return
+	}
+	cw.uint32(uint32(t.Unix()))
+}
+
+func (cw *ccacheWriter) principal(nameType uint32, name, realm string) {
+	components := strings.Split(name, "/")
+	cw.uint32(nameType)
+	cw.uint32(uint32(len(components)))
+	cw.data([]byte(realm))
+	for _, c := range components {
+		cw.data([]byte(c))
+	}
+}
+
+// ReadCCache reads the tickets of an MIT krb5 file credential cache, e.g.
+// to pass them to SubmitTicket with MarshalKRBCred. Configuration entries,
+// which MIT krb5 stores as tickets for the X-CACHECONF: realm, are skipped.
+// The names of the returned tickets are set like those retrieved from the
+// LSA, and TargetName and TargetDomainName equal the service's name and
+// realm.
+func ReadCCache(r io.Reader) ([]*Ticket, error) {
+	cr := &ccacheReader{r: bufio.NewReader(r)}
+	version := cr.uint16()
+	switch version {
+	case 0x0504:
+		cr.skip(int(cr.uint16()))
+	case 0x0503:
+	default:
+		if cr.err == nil {
+			return nil, fmt.Errorf("kerberos: unsupported ccache version %#x", version)
+		}
+	}
+	cr.principal() // default principal
+	var tickets []*Ticket
+	for cr.err == nil {
+		if _, err := cr.r.Peek(1); err == io.EOF {
+			break
+		}
+		client, clientRealm := cr.principal()
+		service, serviceRealm := cr.principal()
+		keyType := cr.uint16()
+		if version == 0x0503 {
+			cr.uint16()
+		}
+		t := &Ticket{
+			ServiceName:         service,
+			TargetName:          service,
+			ClientName:          client,
+			DomainName:          serviceRealm,
+			TargetDomainName:    serviceRealm,
+			AltTargetDomainName: clientRealm,
+			SessionKeyType:      EncryptionType(int16(keyType)),
+			SessionKey:          cr.data(),
+		}
+		cr.time() // authtime
+		t.StartTime = cr.time()
+		t.EndTime = cr.time()
+		t.RenewUntil = cr.time()
+		cr.skip(1) // is_skey
+		t.TicketFlags = TicketFlags(cr.uint32())
+		for n := cr.uint32(); n > 0 && cr.err == nil; n-- {
+			cr.skip(2) // address type
+			cr.data()
+		}
+		for n := cr.uint32(); n > 0 && cr.err == nil; n-- {
+			cr.skip(2) // authdata type
+			cr.data()
+		}
+		t.EncodedTicket = cr.data()
+		cr.data() // second_ticket
+		if cr.err == nil && serviceRealm != "X-CACHECONF:" {
+			tickets = append(tickets, t)
+		}
+	}
+	if cr.err != nil {
+		if cr.err == io.EOF {
+			cr.err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("kerberos: reading ccache: %w", cr.err)
+	}
+	return tickets, nil
+}
+
+// maxCCacheData bounds the length of a counted octet string, so that a
+// corrupt cache fails instead of allocating gigabytes.
+const maxCCacheData = 1 << 20
+
+type ccacheReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (cr *ccacheReader) read(n int) []byte {
+	if cr.err != nil {
+		return make([]byte, n)
+	}
+	b := make([]byte, n)
+	_, cr.err = io.ReadFull(cr.r, b)
+	return b
+}
+
+func (cr *ccacheReader) skip(n int) {
+	cr.read(n)
+}
+
+func (cr *ccacheReader) uint16() uint16 {
+	return binary.BigEndian.Uint16(cr.read(2))
+}
+
+func (cr *ccacheReader) uint32() uint32 {
+	return binary.BigEndian.Uint32(cr.read(4))
+}
+
+func (cr *ccacheReader) data() []byte {
+	n := cr.uint32()
+	if n > maxCCacheData {
+		if cr.err == nil {
+			cr.err = fmt.Errorf("octet string of %d bytes", n)
+		}
+		return nil
+	}
+	if n == 0 || cr.err != nil {
+		return nil
+	}
+	return cr.read(int(n))
+}
+
+func (cr *ccacheReader) time() time.Time {
+	sec := cr.uint32()
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(sec), 0)
+}
+
+// principal returns the name, with the components joined by "/", and the
+// realm of a principal.
+func (cr *ccacheReader) principal() (string, string) {
+	cr.uint32() // name type
+	n := cr.uint32()
+	if n > 64 {
+		if cr.err == nil {
+			cr.err = fmt.Errorf("principal with %d components", n)
+		}
+		n = 0
+	}
+	realm := string(cr.data())
+	components := make([]string, 0, n)
+	for ; n > 0 && cr.err == nil; n-- {
+		components = append(components, string(cr.data()))
+	}
+	return strings.Join(components, "/"), realm
+}
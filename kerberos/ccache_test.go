@@ -0,0 +1,226 @@
+package kerberos_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cobraqxx/winlsa/kerberos"
+)
+
+// t0 is the start time of the sample tickets in testdata.
+var t0 = time.Date(2024, 3, 4, 8, 15, 31, 0, time.UTC)
+
+const (
+	tgtFlags  = kerberos.TicketFlagForwardable | kerberos.TicketFlagRenewable | kerberos.TicketFlagInitial | kerberos.TicketFlagPreAuthent
+	cifsFlags = kerberos.TicketFlagForwardable | kerberos.TicketFlagRenewable | kerberos.TicketFlagPreAuthent
+)
+
+// sampleTickets returns the tickets of the samples in testdata, with the
+// names set as ReadCCache and UnmarshalKRBCred set them.
+func sampleTickets(t *testing.T) []*kerberos.Ticket {
+	t.Helper()
+	key := func(first, n byte) []byte {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = first + byte(i)
+		}
+		return b
+	}
+	tickets := []*kerberos.Ticket{{
+		ServiceName:    "krbtgt/CONTOSO.COM",
+		SessionKeyType: kerberos.EncryptionTypeAes256CtsHmacSha196,
+		SessionKey:     key(1, 32),
+		TicketFlags:    tgtFlags,
+		StartTime:      t0,
+		EndTime:        t0.Add(10 * time.Hour),
+		RenewUntil:     t0.Add(7 * 24 * time.Hour),
+	}, {
+		ServiceName:    "cifs/fs01.contoso.com",
+		SessionKeyType: kerberos.EncryptionTypeAes128CtsHmacSha196,
+		SessionKey:     key(0x40, 16),
+		TicketFlags:    cifsFlags,
+		StartTime:      t0.Add(5 * time.Minute),
+		EndTime:        t0.Add(10 * time.Hour),
+		RenewUntil:     t0.Add(7 * 24 * time.Hour),
+	}}
+	for _, tk := range tickets {
+		tk.TargetName = tk.ServiceName
+		tk.ClientName = "alice"
+		tk.DomainName = "CONTOSO.COM"
+		tk.TargetDomainName = "CONTOSO.COM"
+		tk.AltTargetDomainName = "CONTOSO.COM"
+	}
+	tickets[0].EncodedTicket = encodedTicket(t, "krbtgt/CONTOSO.COM", 18, 2, 0xA5, 64)
+	tickets[1].EncodedTicket = encodedTicket(t, "cifs/fs01.contoso.com", 18, 7, 0x5A, 48)
+	return tickets
+}
+
+// encodedTicket returns the DER encoding of a Ticket of the CONTOSO.COM
+// realm whose cipher is n bytes of fill.
+func encodedTicket(t *testing.T, service string, etype, kvno int, fill byte, n int) []byte {
+	t.Helper()
+	integer := func(v int) []byte { return tlv(0x02, []byte{byte(v)}) }
+	var components [][]byte
+	for _, c := range bytes.Split([]byte(service), []byte("/")) {
+		components = append(components, tlv(0x1B, c))
+	}
+	return tlv(0x61, tlv(0x30,
+		tlv(0xA0, integer(5)),
+		tlv(0xA1, tlv(0x1B, []byte("CONTOSO.COM"))),
+		tlv(0xA2, tlv(0x30, tlv(0xA0, integer(2)), tlv(0xA1, tlv(0x30, components...)))),
+		tlv(0xA3, tlv(0x30, tlv(0xA0, integer(etype)), tlv(0xA1, integer(kvno)), tlv(0xA2, tlv(0x04, bytes.Repeat([]byte{fill}, n))))),
+	))
+}
+
+// tlv returns the DER encoding of the concatenated content with the tag tag.
+// The content must be shorter than 256 bytes.
+func tlv(tag byte, content ...[]byte) []byte {
+	b := bytes.Join(content, nil)
+	if len(b) < 0x80 {
+		return append([]byte{tag, byte(len(b))}, b...)
+	}
+	return append([]byte{tag, 0x81, byte(len(b))}, b...)
+}
+
+func readFile(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func checkTickets(t *testing.T, got, want []*kerberos.Ticket) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d tickets, want %d", len(got), len(want))
+	}
+	for i := range want {
+		g, w := *got[i], *want[i]
+		if !g.StartTime.Equal(w.StartTime) || !g.EndTime.Equal(w.EndTime) || !g.RenewUntil.Equal(w.RenewUntil) {
+			t.Errorf("ticket %d: times %v, %v, %v, want %v, %v, %v", i, g.StartTime, g.EndTime, g.RenewUntil, w.StartTime, w.EndTime, w.RenewUntil)
+		}
+		g.StartTime, g.EndTime, g.RenewUntil = w.StartTime, w.EndTime, w.RenewUntil
+		if g.ServiceName != w.ServiceName || g.TargetName != w.TargetName || g.ClientName != w.ClientName ||
+			g.DomainName != w.DomainName || g.TargetDomainName != w.TargetDomainName || g.AltTargetDomainName != w.AltTargetDomainName {
+			t.Errorf("ticket %d: names %+v, want %+v", i, g, w)
+		}
+		if g.SessionKeyType != w.SessionKeyType || !bytes.Equal(g.SessionKey, w.SessionKey) {
+			t.Errorf("ticket %d: session key %v %x, want %v %x", i, g.SessionKeyType, g.SessionKey, w.SessionKeyType, w.SessionKey)
+		}
+		if g.TicketFlags != w.TicketFlags {
+			t.Errorf("ticket %d: flags %v, want %v", i, g.TicketFlags, w.TicketFlags)
+		}
+		if !bytes.Equal(g.EncodedTicket, w.EncodedTicket) {
+			t.Errorf("ticket %d: encoded ticket %x, want %x", i, g.EncodedTicket, w.EncodedTicket)
+		}
+	}
+}
+
+func TestCCacheRoundTrip(t *testing.T) {
+	want := sampleTickets(t)
+	var buf bytes.Buffer
+	if err := kerberos.WriteCCache(&buf, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := kerberos.ReadCCache(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTickets(t, got, want)
+}
+
+// TestCCacheRoundTripTimes checks that times are written in whole seconds
+// and that unset times stay unset.
+func TestCCacheRoundTripTimes(t *testing.T) {
+	tk := sampleTickets(t)[1]
+	tk.StartTime = t0.Add(1500 * time.Millisecond)
+	tk.RenewUntil = time.Time{}
+	var buf bytes.Buffer
+	if err := kerberos.WriteCCache(&buf, []*kerberos.Ticket{tk}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := kerberos.ReadCCache(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || !got[0].StartTime.Equal(t0.Add(time.Second)) || !got[0].RenewUntil.IsZero() {
+		t.Errorf("ReadCCache returned %+v, want StartTime %v and no RenewUntil", got, t0.Add(time.Second))
+	}
+}
+
+func TestWriteCCacheNoTickets(t *testing.T) {
+	if err := kerberos.WriteCCache(io.Discard, nil); err == nil {
+		t.Error("WriteCCache without tickets succeeded")
+	}
+}
+
+// TestReadCCacheSamples reads caches in the MIT formats. The version 4
+// sample starts with a configuration entry, which is skipped, and its TGT
+// has an address and authorization data.
+func TestReadCCacheSamples(t *testing.T) {
+	for _, name := range []string{"alice-v4.ccache", "alice-v3.ccache"} {
+		t.Run(name, func(t *testing.T) {
+			got, err := kerberos.ReadCCache(bytes.NewReader(readFile(t, name)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTickets(t, got, sampleTickets(t))
+		})
+	}
+}
+
+func TestReadCCacheTruncated(t *testing.T) {
+	b := readFile(t, "alice-v4.ccache")
+	// Cuts inside the version, the header, the default principal and the
+	// encoded ticket and second ticket of the last entry.
+	for _, n := range []int{0, 1, 2, 5, 15, 20, len(b) - 40, len(b) - 5, len(b) - 1} {
+		_, err := kerberos.ReadCCache(bytes.NewReader(b[:n]))
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("ReadCCache of the first %d of %d bytes returned %v, want %v", n, len(b), err, io.ErrUnexpectedEOF)
+		}
+	}
+	// Cuts at the end of an entry leave a valid cache; none may panic or
+	// return more tickets than the sample holds.
+	for n := range b {
+		tickets, err := kerberos.ReadCCache(bytes.NewReader(b[:n]))
+		if err == nil && len(tickets) >= 2 {
+			t.Errorf("ReadCCache of the first %d of %d bytes returned %d tickets", n, len(b), len(tickets))
+		}
+	}
+}
+
+func TestReadCCacheCorrupt(t *testing.T) {
+	sample := readFile(t, "alice-v4.ccache")
+	// The default principal follows the 16 bytes of the version and the
+	// header; its name type, component count and realm length follow.
+	const principal = 16
+	corrupt := func(off int, v uint32) []byte {
+		b := append([]byte(nil), sample...)
+		binary.BigEndian.PutUint32(b[off:], v)
+		return b
+	}
+	for _, tt := range []struct {
+		name string
+		b    []byte
+	}{
+		{"version", append([]byte{5, 2}, sample[2:]...)},
+		{"not a ccache", []byte("\x30\x82\x01\x00 not a credential cache")},
+		{"components", corrupt(principal+4, 1000)},
+		{"realm length", corrupt(principal+8, 1<<30)},
+		{"header length", append([]byte{5, 4, 0xff, 0xff}, sample[4:]...)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if tickets, err := kerberos.ReadCCache(bytes.NewReader(tt.b)); err == nil {
+				t.Errorf("ReadCCache returned %d tickets, want an error", len(tickets))
+			}
+		})
+	}
+}
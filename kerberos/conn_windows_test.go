@@ -0,0 +1,72 @@
+package kerberos_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cobraqxx/winlsa/kerberos"
+)
+
+// TestConnConcurrentClose shares a Conn between goroutines querying the
+// caller's ticket cache and closes it while they do. Queries made after
+// Close fail, which is expected, so only the first query is checked.
+func TestConnConcurrentClose(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		conn, err := kerberos.Connect()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := conn.QueryTicketCache(kerberos.LUID{}); err != nil {
+			conn.Close()
+			t.Fatal(err)
+		}
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for n := 0; n < 8; n++ {
+					conn.QueryTicketCache(kerberos.LUID{})
+				}
+			}()
+		}
+		time.Sleep(time.Millisecond)
+		conn.Close()
+		wg.Wait()
+		conn.Close()
+	}
+}
+
+// TestExpiryWatcherConcurrentClose closes ExpiryWatchers from several
+// goroutines while they poll a Conn that other goroutines use as well.
+func TestExpiryWatcherConcurrentClose(t *testing.T) {
+	conn, err := kerberos.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	for i := 0; i < 50; i++ {
+		w, err := conn.WatchExpiry(kerberos.LUID{}, 2*time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range w.Events() {
+			}
+		}()
+		for g := 0; g < 3; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				conn.QueryTicketCache(kerberos.LUID{})
+				w.Err()
+				w.Close()
+			}()
+		}
+		wg.Wait()
+	}
+}
@@ -0,0 +1,191 @@
+package kerberos
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// An ExpiryEvent reports a cached ticket that expires within the threshold
+// of an ExpiryWatcher.
+type ExpiryEvent struct {
+	// Time is when the watcher found the ticket.
+	Time    time.Time
+	LogonId LUID
+	Ticket  TicketCacheInfo
+	// Remaining is the time left until Ticket.EndTime; it is zero or
+	// negative if the ticket had already expired.
+	Remaining time.Duration
+	// Renewable reports whether the ticket can be renewed, i.e. has
+	// TicketFlagRenewable and a RenewTime after its EndTime.
+	Renewable bool
+}
+
+// An ExpiryWatcher polls the ticket cache of a logon session and reports
+// tickets nearing their expiry. Its methods are safe for concurrent use,
+// and Close may be called more than once. Other goroutines may keep using
+// the Conn while the watcher polls it.
+type ExpiryWatcher struct {
+	ctx       context.Context
+	conn      *Conn
+	luid      LUID
+	threshold time.Duration
+	interval  time.Duration
+	events    chan ExpiryEvent
+	stop      chan struct{}
+	done      chan struct{}
+
+	// reported holds the tickets already reported, by name and EndTime, so
+	// that a renewed ticket is reported again when it nears its new expiry.
+	reported map[expiryKey]bool
+
+	closeOnce sync.Once
+
+	mu  sync.Mutex
+	err error
+}
+
+type expiryKey struct {
+	server, realm string
+	end           time.Time
+}
+
+// maxExpiryInterval is the longest time between two polls of the ticket
+// cache.
+const maxExpiryInterval = time.Minute
+
+// WatchExpiry starts watching the ticket cache of the logon session luid,
+// the caller's if it is zero, for TGTs and service tickets that expire
+// within threshold. Each ticket is reported once, when first found within
+// threshold, so a service can renew or reacquire it in time. The cache is
+// polled every threshold/2, but at least every minute. The first poll
+// happens before WatchExpiry returns, so access errors are returned.
+//
+// c must stay open until the watcher is closed.
+func (c *Conn) WatchExpiry(luid LUID, threshold time.Duration) (*ExpiryWatcher, error) {
+	return c.WatchExpiryContext(context.Background(), luid, threshold)
+}
+
+// WatchExpiryContext is like WatchExpiry, but the watcher also stops when
+// ctx is done, as if Close had been called.
+func (c *Conn) WatchExpiryContext(ctx context.Context, luid LUID, threshold time.Duration) (*ExpiryWatcher, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	interval := threshold / 2
+	if interval <= 0 || interval > maxExpiryInterval {
+		interval = maxExpiryInterval
+	}
+	w := &ExpiryWatcher{
+		ctx:       ctx,
+		conn:      c,
+		luid:      luid,
+		threshold: threshold,
+		interval:  interval,
+		events:    make(chan ExpiryEvent, 16),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		reported:  map[expiryKey]bool{},
+	}
+	initial, err := w.poll()
+	if err != nil {
+		return nil, err
+	}
+	go w.run(initial)
+	return w, nil
+}
+
+// Events returns the channel events are delivered on. It is closed by Close.
+func (w *ExpiryWatcher) Events() <-chan ExpiryEvent {
+	return w.events
+}
+
+// Err returns the error of the last failed poll, or nil if the last poll
+// succeeded. Polling continues after errors. Once the context of a
+// watcher started by WatchExpiryContext is done, Err returns ctx.Err().
+func (w *ExpiryWatcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// Close stops the watcher and closes the events channel. It does not close
+// the Conn.
+func (w *ExpiryWatcher) Close() error {
+	w.closeOnce.Do(func() { close(w.stop) })
+	<-w.done
+	return nil
+}
+
+func (w *ExpiryWatcher) run(initial []ExpiryEvent) {
+	defer close(w.done)
+	defer close(w.events)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	events := initial
+	for {
+		for _, ev := range events {
+			select {
+			case w.events <- ev:
+			case <-w.stop:
+				return
+			case <-w.ctx.Done():
+				w.setErr(w.ctx.Err())
+				return
+			}
+		}
+		select {
+		case <-w.stop:
+			return
+		case <-w.ctx.Done():
+			w.setErr(w.ctx.Err())
+			return
+		case <-ticker.C:
+		}
+		var err error
+		events, err = w.poll()
+		w.setErr(err)
+	}
+}
+
+func (w *ExpiryWatcher) setErr(err error) {
+	w.mu.Lock()
+	w.err = err
+	w.mu.Unlock()
+}
+
+func (w *ExpiryWatcher) poll() ([]ExpiryEvent, error) {
+	tickets, err := w.conn.QueryTicketCacheContext(w.ctx, w.luid)
+	if err != nil && !lsa.IsFreeBufferError(err) {
+		return nil, err
+	}
+	now := time.Now()
+	var events []ExpiryEvent
+	seen := make(map[expiryKey]bool, len(tickets))
+	for _, t := range tickets {
+		key := expiryKey{t.ServerName, t.ServerRealm, t.EndTime}
+		seen[key] = true
+		remaining := t.EndTime.Sub(now)
+		if t.EndTime.IsZero() || remaining > w.threshold || w.reported[key] {
+			continue
+		}
+		w.reported[key] = true
+		events = append(events, ExpiryEvent{
+			Time:      now,
+			LogonId:   w.luid,
+			Ticket:    t,
+			Remaining: remaining,
+			Renewable: t.TicketFlags&TicketFlagRenewable != 0 && t.RenewTime.After(t.EndTime),
+		})
+	}
+	// Forget tickets that left the cache, which keeps the map bounded.
+	for key := range w.reported {
+		if !seen[key] {
+			delete(w.reported, key)
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,151 @@
+package kerberos
+
+import (
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/fixture"
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func init() {
+	fixture.RegisterDecoder(fixture.KindTicketCache, func(p unsafe.Pointer) interface{} {
+		return decodeTicketCache((*lsa.KERB_QUERY_TKT_CACHE_EX2_RESPONSE)(p))
+	})
+}
+
+// TestCaptureFixtures writes a TicketCache fixture of every logon session
+// with cached tickets to testdata/fixtures when run with -capture. Ticket
+// caches of other sessions require SeTcbPrivilege and are skipped if they
+// cannot be queried.
+func TestCaptureFixtures(t *testing.T) {
+	if !fixture.Capturing() {
+		t.Skip("run with -capture to capture fixtures")
+	}
+	conn, err := Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	var count uint32
+	var buffer *LUID
+	if err := lsa.LsaEnumerateLogonSessions(&count, &buffer); err != nil {
+		t.Fatal(err)
+	}
+	luids := append([]LUID(nil), unsafe.Slice(buffer, count)...)
+	lsa.LsaFreeReturnBuffer(uintptr(unsafe.Pointer(buffer)))
+
+	for _, luid := range luids {
+		req := lsa.KERB_QUERY_TKT_CACHE_REQUEST{
+			MessageType: lsa.KerbQueryTicketCacheEx2Message,
+			LogonId:     luid,
+		}
+		resp, _, err := lsa.CallPackage(conn.handle, conn.pkg, unsafe.Pointer(&req), unsafe.Sizeof(req))
+		if err != nil {
+			t.Logf("skipping ticket cache of %v: %v", luid, err)
+			continue
+		}
+		if resp == nil {
+			continue
+		}
+		f, err := fixture.Capture(fixture.KindTicketCache, resp)
+		lsa.LsaFreeReturnBuffer(uintptr(resp))
+		if err != nil {
+			t.Fatalf("ticket cache of %v: %v", luid, err)
+		}
+		fixture.Write(t, f, luid.String())
+	}
+}
+
+// TestFixtures decodes the TicketCache fixtures of the running architecture
+// and compares them with their goldens.
+func TestFixtures(t *testing.T) {
+	fixture.CheckAll(t, fixture.KindTicketCache)
+}
+
+// TestTicketCacheRoundTrip captures a ticket cache buffer, reloads it after
+// the original is cleared, and checks the decoded tickets against the values
+// the buffer was built from.
+func TestTicketCacheRoundTrip(t *testing.T) {
+	want := []TicketCacheInfo{{
+		ClientName:     "alice",
+		ClientRealm:    "CONTOSO.COM",
+		ServerName:     "krbtgt/CONTOSO.COM",
+		ServerRealm:    "CONTOSO.COM",
+		StartTime:      time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC),
+		EndTime:        time.Date(2024, 1, 2, 18, 0, 0, 0, time.UTC),
+		RenewTime:      time.Date(2024, 1, 9, 8, 0, 0, 0, time.UTC),
+		EncryptionType: EncryptionTypeAes256CtsHmacSha196,
+		TicketFlags:    TicketFlagForwardable | TicketFlagRenewable | TicketFlagInitial,
+		SessionKeyType: EncryptionTypeAes256CtsHmacSha196,
+	}, {
+		ClientName:     "alice",
+		ClientRealm:    "CONTOSO.COM",
+		ServerName:     "cifs/fs01.contoso.com",
+		ServerRealm:    "CONTOSO.COM",
+		StartTime:      time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC),
+		EndTime:        time.Date(2024, 1, 2, 18, 0, 0, 0, time.UTC),
+		EncryptionType: EncryptionTypeAes256CtsHmacSha196,
+		TicketFlags:    TicketFlagForwardable,
+		SessionKeyType: EncryptionTypeAes128CtsHmacSha196,
+		BranchId:       1,
+	}}
+
+	size := unsafe.Sizeof(lsa.KERB_QUERY_TKT_CACHE_EX2_RESPONSE{}) + uintptr(len(want))*unsafe.Sizeof(lsa.KERB_TICKET_CACHE_INFO_EX2{})
+	b := fixture.NewBuffer(size)
+	h := (*lsa.KERB_QUERY_TKT_CACHE_EX2_RESPONSE)(b.Pointer())
+	h.MessageType = lsa.KerbQueryTicketCacheEx2Message
+	h.CountOfTickets = uint32(len(want))
+	tickets := unsafe.Slice((*lsa.KERB_TICKET_CACHE_INFO_EX2)(unsafe.Pointer(&h.Tickets)), len(want))
+	for i, w := range want {
+		tickets[i] = lsa.KERB_TICKET_CACHE_INFO_EX2{
+			ClientName:     b.String(w.ClientName),
+			ClientRealm:    b.String(w.ClientRealm),
+			ServerName:     b.String(w.ServerName),
+			ServerRealm:    b.String(w.ServerRealm),
+			StartTime:      lsa.Uint64FromTime(w.StartTime),
+			EndTime:        lsa.Uint64FromTime(w.EndTime),
+			RenewTime:      lsa.Uint64FromTime(w.RenewTime),
+			EncryptionType: int32(w.EncryptionType),
+			TicketFlags:    uint32(w.TicketFlags),
+			SessionKeyType: uint32(w.SessionKeyType),
+			BranchId:       w.BranchId,
+		}
+	}
+
+	f, err := fixture.Capture(fixture.KindTicketCache, b.Pointer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Clear()
+	if err := f.Check(); err != nil {
+		t.Fatal(err)
+	}
+	p, err := f.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := fixture.Decode(fixture.KindTicketCache, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := v.([]TicketCacheInfo)
+	if len(got) != len(want) {
+		t.Fatalf("decoded %d tickets, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !equalTicket(got[i], want[i]) {
+			t.Errorf("ticket %d decodes to %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// equalTicket compares tickets with their times in any zone.
+func equalTicket(a, b TicketCacheInfo) bool {
+	if !a.StartTime.Equal(b.StartTime) || !a.EndTime.Equal(b.EndTime) || !a.RenewTime.Equal(b.RenewTime) {
+		return false
+	}
+	a.StartTime, a.EndTime, a.RenewTime = b.StartTime, b.EndTime, b.RenewTime
+	return a == b
+}
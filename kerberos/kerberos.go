@@ -0,0 +1,59 @@
+// Package kerberos calls the Kerberos authentication package through the
+// LSA to inspect and manage the ticket caches of logon sessions.
+//
+// On platforms other than Windows, connecting fails with
+// winlsa.ErrUnsupportedPlatform; the ticket types remain usable.
+package kerberos
+
+import (
+	"sync"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// LUID is the same type as winlsa.LUID.
+type LUID = lsa.LUID
+
+// FreeBufferError is the same type as winlsa.FreeBufferError. The tickets
+// and key tabs returned with it are valid; use winlsa.IsFreeBufferError
+// to detect it.
+type FreeBufferError = lsa.FreeBufferError
+
+// PackageName is the name the Kerberos package is registered under.
+const PackageName = "Kerberos"
+
+// A Conn is a connection to the LSA for calling the Kerberos package. It
+// is safe for concurrent use; Close waits for the calls in progress, and
+// calls made after Close fail.
+type Conn struct {
+	// mu is held for reading by every call of the package and for writing
+	// by Close, so that the handle is not closed while in use.
+	mu      sync.RWMutex
+	handle  lsa.LSA_HANDLE
+	pkg     uint32
+	trusted bool
+}
+
+// Connect opens an untrusted connection. Untrusted connections can only
+// access the ticket cache of the caller's own logon session unless the
+// caller is elevated.
+func Connect() (*Conn, error) {
+	return connect()
+}
+
+// ConnectTrusted registers the caller as the logon process name, which
+// grants access to the ticket caches of every logon session. It requires
+// SeTcbPrivilege.
+func ConnectTrusted(name string) (*Conn, error) {
+	return connectTrusted(name)
+}
+
+// Trusted reports whether c was opened with ConnectTrusted.
+func (c *Conn) Trusted() bool {
+	return c.trusted
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.close()
+}
@@ -0,0 +1,78 @@
+package kerberos
+
+import (
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func connect() (*Conn, error) {
+	var handle lsa.LSA_HANDLE
+	err := lsa.LsaConnectUntrusted(&handle)
+	if err != nil {
+		return nil, err
+	}
+	return newConn(handle, false)
+}
+
+func connectTrusted(name string) (*Conn, error) {
+	lsaName, err := lsa.NewString(name)
+	if err != nil {
+		return nil, err
+	}
+	var handle lsa.LSA_HANDLE
+	var mode uint32
+	err = lsa.LsaRegisterLogonProcess(&lsaName, &handle, &mode)
+	if err != nil {
+		return nil, err
+	}
+	return newConn(handle, true)
+}
+
+func newConn(handle lsa.LSA_HANDLE, trusted bool) (*Conn, error) {
+	name, err := lsa.NewString(PackageName)
+	if err != nil {
+		lsa.LsaDeregisterLogonProcess(handle)
+		return nil, err
+	}
+	c := &Conn{handle: handle, trusted: trusted}
+	err = lsa.LsaLookupAuthenticationPackage(handle, &name, &c.pkg)
+	if err != nil {
+		lsa.LsaDeregisterLogonProcess(handle)
+		return nil, err
+	}
+	lsa.TrackLeak(c, "kerberos.Conn")
+	return c, nil
+}
+
+func (c *Conn) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.handle == 0 {
+		return nil
+	}
+	err := lsa.LsaDeregisterLogonProcess(c.handle)
+	c.handle = 0
+	lsa.UntrackLeak(c)
+	return err
+}
+
+// call submits a request of op to the Kerberos package. The returned
+// buffer, if any, must be released with freeReturnBuffer.
+func (c *Conn) call(op lsa.Op, req unsafe.Pointer, reqLen uintptr) (unsafe.Pointer, uint32, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	call := op.StartCall("LsaCallAuthenticationPackage")
+	resp, status, err := lsa.CallPackage(c.handle, c.pkg, req, reqLen)
+	call.End(err)
+	return resp, status, err
+}
+
+// freeReturnBuffer releases a buffer returned by call once op has decoded
+// it; it fails with a *FreeBufferError.
+func freeReturnBuffer(op lsa.Op, resp unsafe.Pointer) error {
+	call := op.StartCall("LsaFreeReturnBuffer")
+	err := lsa.FreeReturnBuffer(uintptr(resp))
+	call.End(err)
+	return err
+}
@@ -0,0 +1,20 @@
+package kerberos
+
+import (
+	"context"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// RetrieveKeyTab derives the keys of the account userName@domainName from
+// password and returns them as an MIT krb5 keytab file, like ktpass.exe
+// does, e.g. for a Linux service running as the account. The keys are
+// computed by the local Kerberos package for the encryption types it
+// supports; the password is not checked against the domain, so a wrong
+// password yields a keytab the KDC rejects. Older Windows versions fail
+// the call as an unknown message.
+func (c *Conn) RetrieveKeyTab(userName, domainName, password string) (_ []byte, err error) {
+	_, op := lsa.StartOp(context.Background(), "kerberos.RetrieveKeyTab", nil)
+	defer func() { op.End(err) }()
+	return c.retrieveKeyTab(op, userName, domainName, password)
+}
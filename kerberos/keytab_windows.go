@@ -0,0 +1,44 @@
+package kerberos
+
+import (
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (c *Conn) retrieveKeyTab(op lsa.Op, userName, domainName, password string) ([]byte, error) {
+	var req lsa.KERB_RETRIEVE_KEY_TAB_REQUEST
+	buf, names, err := lsa.NewRequest(unsafe.Sizeof(req), userName, domainName, password)
+	if err != nil {
+		return nil, err
+	}
+	// The request holds the password.
+	defer func() {
+		for idx := range buf {
+			buf[idx] = 0
+		}
+	}()
+	p := (*lsa.KERB_RETRIEVE_KEY_TAB_REQUEST)(unsafe.Pointer(&buf[0]))
+	p.MessageType = lsa.KerbRetrieveKeyTabMessage
+	p.UserName = names[0]
+	p.DomainName = names[1]
+	p.Password = names[2]
+
+	resp, _, err := c.call(op, unsafe.Pointer(&buf[0]), uintptr(len(buf)))
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	r := (*lsa.KERB_RETRIEVE_KEY_TAB_RESPONSE)(resp)
+	var keyTab []byte
+	if r.KeyTabLength > 0 {
+		lsaKeyTab := unsafe.Slice(r.KeyTab, r.KeyTabLength)
+		keyTab = append([]byte(nil), lsaKeyTab...)
+		for idx := range lsaKeyTab {
+			lsaKeyTab[idx] = 0
+		}
+	}
+	return keyTab, freeReturnBuffer(op, resp)
+}
@@ -0,0 +1,305 @@
+package kerberos
+
+import (
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ASN.1 application tags of the Kerberos messages, RFC 4120 section 5.
+const (
+	applicationTicket         = 1
+	applicationKrbCred        = 22
+	applicationEncKrbCredPart = 29
+)
+
+// Principal name types.
+const (
+	nameTypePrincipal = 1
+	nameTypeSrvInst   = 2
+)
+
+// The types below mirror the RFC 4120 ASN.1 definitions. encoding/asn1
+// cannot write GeneralString, so KerberosString values are RawValues made
+// by generalString. It also ignores the tags of RawValue fields, so the
+// Realm fields are wrapped into their explicit tags by explicitRealm.
+
+type krbCred struct {
+	Pvno    int             `asn1:"explicit,tag:0"`
+	MsgType int             `asn1:"explicit,tag:1"`
+	Tickets []asn1.RawValue `asn1:"explicit,tag:2"`
+	EncPart encryptedData   `asn1:"explicit,tag:3"`
+}
+
+type encryptedData struct {
+	EType  int    `asn1:"explicit,tag:0"`
+	Kvno   int    `asn1:"optional,explicit,tag:1"`
+	Cipher []byte `asn1:"explicit,tag:2"`
+}
+
+type encKrbCredPart struct {
+	TicketInfo []krbCredInfo `asn1:"explicit,tag:0"`
+}
+
+type krbCredInfo struct {
+	Key       encryptionKey  `asn1:"explicit,tag:0"`
+	PRealm    asn1.RawValue  // [1]
+	PName     principalName  `asn1:"optional,explicit,tag:2"`
+	Flags     asn1.BitString `asn1:"optional,explicit,tag:3"`
+	AuthTime  time.Time      `asn1:"optional,generalized,explicit,tag:4"`
+	StartTime time.Time      `asn1:"optional,generalized,explicit,tag:5"`
+	EndTime   time.Time      `asn1:"optional,generalized,explicit,tag:6"`
+	RenewTill time.Time      `asn1:"optional,generalized,explicit,tag:7"`
+	SRealm    asn1.RawValue  // [8]
+	SName     principalName  `asn1:"optional,explicit,tag:9"`
+}
+
+type encryptionKey struct {
+	KeyType  int    `asn1:"explicit,tag:0"`
+	KeyValue []byte `asn1:"explicit,tag:1"`
+}
+
+type principalName struct {
+	NameType   int             `asn1:"explicit,tag:0"`
+	NameString []asn1.RawValue `asn1:"explicit,tag:1"`
+}
+
+func generalString(s string) asn1.RawValue {
+	return asn1.RawValue{Tag: asn1.TagGeneralString, Bytes: []byte(s)}
+}
+
+func explicitRealm(tag int, realm string) asn1.RawValue {
+	der, _ := asn1.Marshal(generalString(realm))
+	return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: tag, IsCompound: true, Bytes: der}
+}
+
+func newPrincipalName(nameType int, name string) principalName {
+	pn := principalName{NameType: nameType}
+	for _, c := range strings.Split(name, "/") {
+		pn.NameString = append(pn.NameString, generalString(c))
+	}
+	return pn
+}
+
+// application wraps the DER encoding of a SEQUENCE into the application
+// tag tag.
+func application(tag int, der []byte) ([]byte, error) {
+	return asn1.Marshal(asn1.RawValue{Class: asn1.ClassApplication, Tag: tag, IsCompound: true, Bytes: der})
+}
+
+// kerberosTime truncates t to seconds in UTC, as KerberosTime requires.
+func kerberosTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Second)
+}
+
+// MarshalKRBCred encodes tickets as a KRB-CRED message with an unencrypted
+// EncKrbCredPart, the form SubmitTicket and most Kerberos tools accept. The
+// tickets need their EncodedTicket and SessionKey.
+func MarshalKRBCred(tickets ...*Ticket) ([]byte, error) {
+	if len(tickets) == 0 {
+		return nil, errors.New("kerberos: no tickets")
+	}
+	var cred krbCred
+	var part encKrbCredPart
+	for _, t := range tickets {
+		if len(t.EncodedTicket) == 0 {
+			return nil, errors.New("kerberos: ticket for " + t.ServiceName + " has no encoded ticket")
+		}
+		cred.Tickets = append(cred.Tickets, asn1.RawValue{FullBytes: t.EncodedTicket})
+		flags := make([]byte, 4)
+		binary.BigEndian.PutUint32(flags, uint32(t.TicketFlags))
+		info := krbCredInfo{
+			Key:       encryptionKey{KeyType: int(t.SessionKeyType), KeyValue: t.SessionKey},
+			PRealm:    explicitRealm(1, t.clientRealm()),
+			PName:     newPrincipalName(nameTypePrincipal, t.ClientName),
+			Flags:     asn1.BitString{Bytes: flags, BitLength: 32},
+			AuthTime:  kerberosTime(t.StartTime),
+			StartTime: kerberosTime(t.StartTime),
+			EndTime:   kerberosTime(t.EndTime),
+			RenewTill: kerberosTime(t.RenewUntil),
+			SRealm:    explicitRealm(8, t.DomainName),
+			SName:     newPrincipalName(nameTypeSrvInst, t.ServiceName),
+		}
+		part.TicketInfo = append(part.TicketInfo, info)
+	}
+	der, err := asn1.Marshal(part)
+	if err != nil {
+		return nil, err
+	}
+	cred.Pvno = 5
+	cred.MsgType = applicationKrbCred
+	cred.EncPart.Cipher, err = application(applicationEncKrbCredPart, der)
+	if err != nil {
+		return nil, err
+	}
+	der, err = asn1.Marshal(cred)
+	if err != nil {
+		return nil, err
+	}
+	return application(applicationKrbCred, der)
+}
+
+// clientRealm returns the realm of the ticket's client. Tickets decoded
+// from the LSA carry it in AltTargetDomainName.
+func (t *Ticket) clientRealm() string {
+	if t.AltTargetDomainName != "" {
+		return t.AltTargetDomainName
+	}
+	return t.DomainName
+}
+
+// The decoding counterparts of the types above. encoding/asn1 reads
+// GeneralString into strings, so they need no RawValues.
+
+type ticketDER struct {
+	TktVno  int           `asn1:"explicit,tag:0"`
+	Realm   string        `asn1:"explicit,tag:1"`
+	SName   nameDER       `asn1:"explicit,tag:2"`
+	EncPart encryptedData `asn1:"explicit,tag:3"`
+}
+
+type krbCredDER struct {
+	Pvno    int             `asn1:"explicit,tag:0"`
+	MsgType int             `asn1:"explicit,tag:1"`
+	Tickets []asn1.RawValue `asn1:"explicit,tag:2"`
+	EncPart encryptedData   `asn1:"explicit,tag:3"`
+}
+
+type encKrbCredPartDER struct {
+	TicketInfo []krbCredInfoDER `asn1:"explicit,tag:0"`
+}
+
+type krbCredInfoDER struct {
+	Key       encryptionKey  `asn1:"explicit,tag:0"`
+	PRealm    string         `asn1:"optional,explicit,tag:1"`
+	PName     nameDER        `asn1:"optional,explicit,tag:2"`
+	Flags     asn1.BitString `asn1:"optional,explicit,tag:3"`
+	AuthTime  time.Time      `asn1:"optional,generalized,explicit,tag:4"`
+	StartTime time.Time      `asn1:"optional,generalized,explicit,tag:5"`
+	EndTime   time.Time      `asn1:"optional,generalized,explicit,tag:6"`
+	RenewTill time.Time      `asn1:"optional,generalized,explicit,tag:7"`
+	SRealm    string         `asn1:"optional,explicit,tag:8"`
+	SName     nameDER        `asn1:"optional,explicit,tag:9"`
+}
+
+type nameDER struct {
+	NameType   int      `asn1:"explicit,tag:0"`
+	NameString []string `asn1:"explicit,tag:1"`
+}
+
+func (n nameDER) String() string {
+	return strings.Join(n.NameString, "/")
+}
+
+// unmarshalApplication decodes the DER encoding of a SEQUENCE wrapped into
+// the application tag tag into v, which must use the whole of b.
+func unmarshalApplication(b []byte, tag int, v interface{}) error {
+	rest, err := asn1.UnmarshalWithParams(b, v, fmt.Sprintf("application,explicit,tag:%d", tag))
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return errors.New("trailing data")
+	}
+	return nil
+}
+
+// An EncodedTicketInfo is the unencrypted part of an encoded ticket. The
+// client, flags and times of a ticket are encrypted with the service's
+// key; the LSA reports them next to the EncodedTicket.
+type EncodedTicketInfo struct {
+	Realm       string
+	ServiceName string
+	// NameType is the principal name type of ServiceName, e.g. 2 for
+	// service instances such as "krbtgt/CONTOSO.COM".
+	NameType int32
+	// EncryptionType is the type of the service key the ticket is
+	// encrypted with, which klist.exe shows as KerbTicket Encryption Type.
+	EncryptionType EncryptionType
+	// KeyVersion is the version of the service key, or 0 if the ticket
+	// does not name one.
+	KeyVersion int
+}
+
+// ParseEncodedTicket decodes the unencrypted part of an EncodedTicket, a
+// Ticket as defined in RFC 4120 section 5.3.
+func ParseEncodedTicket(encoded []byte) (*EncodedTicketInfo, error) {
+	var t ticketDER
+	if err := unmarshalApplication(encoded, applicationTicket, &t); err != nil {
+		return nil, fmt.Errorf("kerberos: decoding ticket: %w", err)
+	}
+	if t.TktVno != 5 {
+		return nil, fmt.Errorf("kerberos: unsupported ticket version %d", t.TktVno)
+	}
+	return &EncodedTicketInfo{
+		Realm:          t.Realm,
+		ServiceName:    t.SName.String(),
+		NameType:       int32(t.SName.NameType),
+		EncryptionType: EncryptionType(t.EncPart.EType),
+		KeyVersion:     t.EncPart.Kvno,
+	}, nil
+}
+
+// UnmarshalKRBCred decodes a KRB-CRED message with an unencrypted
+// EncKrbCredPart, as written by MarshalKRBCred, Rubeus and Mimikatz (.kirbi
+// files). The names of the returned tickets are set like those read by
+// ReadCCache. Messages encrypted with a session key are not supported.
+func UnmarshalKRBCred(b []byte) ([]*Ticket, error) {
+	var cred krbCredDER
+	if err := unmarshalApplication(b, applicationKrbCred, &cred); err != nil {
+		return nil, fmt.Errorf("kerberos: decoding KRB-CRED: %w", err)
+	}
+	if cred.Pvno != 5 || cred.MsgType != applicationKrbCred {
+		return nil, fmt.Errorf("kerberos: not a KRB-CRED message (pvno %d, msg-type %d)", cred.Pvno, cred.MsgType)
+	}
+	if cred.EncPart.EType != int(EncryptionTypeNull) {
+		return nil, fmt.Errorf("kerberos: KRB-CRED is encrypted with %v", EncryptionType(cred.EncPart.EType))
+	}
+	var part encKrbCredPartDER
+	if err := unmarshalApplication(cred.EncPart.Cipher, applicationEncKrbCredPart, &part); err != nil {
+		return nil, fmt.Errorf("kerberos: decoding EncKrbCredPart: %w", err)
+	}
+	if len(part.TicketInfo) != len(cred.Tickets) {
+		return nil, fmt.Errorf("kerberos: KRB-CRED has %d tickets but %d ticket infos", len(cred.Tickets), len(part.TicketInfo))
+	}
+	tickets := make([]*Ticket, len(cred.Tickets))
+	for i, info := range part.TicketInfo {
+		encoded := cred.Tickets[i].FullBytes
+		serviceName, serviceRealm := info.SName.String(), info.SRealm
+		// SName and SRealm are optional; the ticket always has them.
+		if serviceName == "" || serviceRealm == "" {
+			et, err := ParseEncodedTicket(encoded)
+			if err != nil {
+				return nil, err
+			}
+			serviceName, serviceRealm = et.ServiceName, et.Realm
+		}
+		tickets[i] = &Ticket{
+			ServiceName:         serviceName,
+			TargetName:          serviceName,
+			ClientName:          info.PName.String(),
+			DomainName:          serviceRealm,
+			TargetDomainName:    serviceRealm,
+			AltTargetDomainName: info.PRealm,
+			SessionKeyType:      EncryptionType(info.Key.KeyType),
+			SessionKey:          info.Key.KeyValue,
+			TicketFlags:         ticketFlags(info.Flags),
+			StartTime:           info.StartTime,
+			EndTime:             info.EndTime,
+			RenewUntil:          info.RenewTill,
+			EncodedTicket:       encoded,
+		}
+	}
+	return tickets, nil
+}
+
+// ticketFlags converts a TicketFlags BIT STRING, whose bit 0 is the most
+// significant bit of TicketFlags.
+func ticketFlags(bs asn1.BitString) TicketFlags {
+	var b [4]byte
+	copy(b[:], bs.Bytes)
+	return TicketFlags(binary.BigEndian.Uint32(b[:]))
+}
@@ -0,0 +1,161 @@
+package kerberos_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cobraqxx/winlsa/kerberos"
+)
+
+// krbCred returns the DER encoding of a KRB-CRED message of the tickets
+// whose enc-part has the encryption type etype and the cipher part.
+func krbCred(pvno, etype int, part []byte, tickets ...[]byte) []byte {
+	integer := func(v int) []byte { return tlv(0x02, []byte{byte(v)}) }
+	return tlv(0x76, tlv(0x30,
+		tlv(0xA0, integer(pvno)),
+		tlv(0xA1, integer(22)),
+		tlv(0xA2, tlv(0x30, tickets...)),
+		tlv(0xA3, tlv(0x30, tlv(0xA0, integer(etype)), tlv(0xA2, tlv(0x04, part)))),
+	))
+}
+
+// noTicketInfo is an EncKrbCredPart without ticket infos.
+var noTicketInfo = tlv(0x7D, tlv(0x30, tlv(0xA0, tlv(0x30))))
+
+func TestKRBCredRoundTrip(t *testing.T) {
+	want := sampleTickets(t)
+	b, err := kerberos.MarshalKRBCred(want...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := kerberos.UnmarshalKRBCred(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTickets(t, got, want)
+}
+
+// TestMarshalKRBCredSample checks that MarshalKRBCred writes a TGT as
+// Rubeus and Mimikatz do. Tickets have no AuthTime, so MarshalKRBCred writes
+// their StartTime, which is the AuthTime of the TGT only.
+func TestMarshalKRBCredSample(t *testing.T) {
+	want := readFile(t, "alice-tgt.kirbi")
+	b, err := kerberos.MarshalKRBCred(sampleTickets(t)[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, want) {
+		t.Errorf("MarshalKRBCred returned\n%x\nwant\n%x", b, want)
+	}
+}
+
+func TestMarshalKRBCredInvalid(t *testing.T) {
+	if _, err := kerberos.MarshalKRBCred(); err == nil {
+		t.Error("MarshalKRBCred without tickets succeeded")
+	}
+	tk := sampleTickets(t)[1]
+	tk.EncodedTicket = nil
+	if _, err := kerberos.MarshalKRBCred(tk); err == nil {
+		t.Error("MarshalKRBCred of a ticket without EncodedTicket succeeded")
+	}
+}
+
+// TestUnmarshalKRBCredSamples reads .kirbi files. The service names of the
+// nonames sample are only in its ticket.
+func TestUnmarshalKRBCredSamples(t *testing.T) {
+	tickets := sampleTickets(t)
+	for _, tt := range []struct {
+		name string
+		want []*kerberos.Ticket
+	}{
+		{"alice-tgt.kirbi", tickets[:1]},
+		{"alice-tickets.kirbi", tickets},
+		{"alice-cifs-nonames.kirbi", tickets[1:]},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := kerberos.UnmarshalKRBCred(readFile(t, tt.name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkTickets(t, got, tt.want)
+		})
+	}
+}
+
+func TestUnmarshalKRBCredTruncated(t *testing.T) {
+	b := readFile(t, "alice-tickets.kirbi")
+	for n := range b {
+		if tickets, err := kerberos.UnmarshalKRBCred(b[:n]); err == nil {
+			t.Errorf("UnmarshalKRBCred of the first %d of %d bytes returned %d tickets, want an error", n, len(b), len(tickets))
+		}
+	}
+	if _, err := kerberos.UnmarshalKRBCred(append(b, 0)); err == nil {
+		t.Error("UnmarshalKRBCred with trailing data succeeded")
+	}
+}
+
+func TestUnmarshalKRBCredInvalid(t *testing.T) {
+	ticket := readFile(t, "cifs.ticket")
+	if _, err := kerberos.UnmarshalKRBCred(krbCred(5, 0, noTicketInfo)); err != nil {
+		t.Fatalf("UnmarshalKRBCred of a message without tickets: %v", err)
+	}
+	for _, tt := range []struct {
+		name string
+		b    []byte
+	}{
+		{"ticket", ticket},
+		{"pvno", krbCred(4, 0, noTicketInfo)},
+		{"encrypted", krbCred(5, int(kerberos.EncryptionTypeAes256CtsHmacSha196), noTicketInfo)},
+		{"enc-part", krbCred(5, 0, []byte("not an EncKrbCredPart"))},
+		{"ticket infos", krbCred(5, 0, noTicketInfo, ticket)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if tickets, err := kerberos.UnmarshalKRBCred(tt.b); err == nil {
+				t.Errorf("UnmarshalKRBCred returned %d tickets, want an error", len(tickets))
+			}
+		})
+	}
+}
+
+func TestParseEncodedTicket(t *testing.T) {
+	got, err := kerberos.ParseEncodedTicket(readFile(t, "cifs.ticket"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := kerberos.EncodedTicketInfo{
+		Realm:          "CONTOSO.COM",
+		ServiceName:    "cifs/fs01.contoso.com",
+		NameType:       2,
+		EncryptionType: kerberos.EncryptionTypeAes256CtsHmacSha196,
+		KeyVersion:     7,
+	}
+	if *got != want {
+		t.Errorf("ParseEncodedTicket returned %+v, want %+v", *got, want)
+	}
+}
+
+func TestParseEncodedTicketInvalid(t *testing.T) {
+	b := readFile(t, "cifs.ticket")
+	for n := range b {
+		if _, err := kerberos.ParseEncodedTicket(b[:n]); err == nil {
+			t.Errorf("ParseEncodedTicket of the first %d of %d bytes succeeded", n, len(b))
+		}
+	}
+	// The tkt-vno follows the application tag and the SEQUENCE.
+	v4 := append([]byte(nil), b...)
+	v4[8] = 4
+	for _, tt := range []struct {
+		name string
+		b    []byte
+	}{
+		{"version", v4},
+		{"trailing data", append(b[:len(b):len(b)], 0)},
+		{"KRB-CRED", readFile(t, "alice-tgt.kirbi")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := kerberos.ParseEncodedTicket(tt.b); err == nil {
+				t.Error("ParseEncodedTicket succeeded")
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package kerberos
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// PurgeTicketCache removes tickets from the ticket cache of the logon
+// session luid. If serverName and realmName are empty, every ticket is
+// removed; otherwise only the ticket for serverName@realmName is. The zero
+// LUID refers to the caller's logon session.
+func (c *Conn) PurgeTicketCache(luid LUID, serverName, realmName string) (err error) {
+	_, op := lsa.StartOp(context.Background(), "kerberos.PurgeTicketCache", &luid)
+	defer func() { op.End(err) }()
+	return c.purgeTicketCache(op, luid, serverName, realmName)
+}
+
+// PurgeTickets removes the tickets of the logon session luid whose server
+// name matches serverPattern and whose realm matches realmPattern, e.g.
+// "*.contoso.com" and "", and returns them. Patterns are case-insensitive;
+// "*" matches any run of characters, including "/", "?" matches one
+// character, and the empty pattern matches everything.
+//
+// The Kerberos package only purges exact names, so the patterns are
+// matched against the cached tickets and each match is purged on its own.
+// If only some purges fail, the purged tickets are returned with a
+// TicketErrors error keyed by server@realm.
+func (c *Conn) PurgeTickets(luid LUID, serverPattern, realmPattern string) ([]TicketCacheInfo, error) {
+	cached, err := c.QueryTicketCache(luid)
+	if err != nil && !lsa.IsFreeBufferError(err) {
+		return nil, err
+	}
+	var purged []TicketCacheInfo
+	var errs TicketErrors
+	done := map[string]bool{}
+	for _, t := range cached {
+		if !matchPattern(serverPattern, t.ServerName) || !matchPattern(realmPattern, t.ServerRealm) {
+			continue
+		}
+		// The purge message removes every ticket of the name, e.g. those of
+		// several clients.
+		target := t.ServerName + "@" + t.ServerRealm
+		if !done[target] {
+			done[target] = true
+			if err := c.PurgeTicketCache(luid, t.ServerName, t.ServerRealm); err != nil {
+				if errs == nil {
+					errs = TicketErrors{}
+				}
+				errs[target] = err
+			}
+		}
+		if errs[target] == nil {
+			purged = append(purged, t)
+		}
+	}
+	if errs != nil {
+		return purged, errs
+	}
+	return purged, nil
+}
+
+// matchPattern reports whether s matches pattern, as described at
+// PurgeTickets.
+func matchPattern(pattern, s string) bool {
+	if pattern == "" {
+		return true
+	}
+	p, n := []rune(strings.ToLower(pattern)), []rune(strings.ToLower(s))
+	// star and next are the positions after the last "*" and the rune of
+	// s it has consumed up to, to backtrack to when a match fails.
+	star, next := -1, 0
+	for i, j := 0, 0; j < len(n) || i < len(p); {
+		if i < len(p) {
+			switch p[i] {
+			case '*':
+				star, next = i+1, j
+				i++
+				continue
+			case '?':
+				if j < len(n) {
+					i++
+					j++
+					continue
+				}
+			default:
+				if j < len(n) && p[i] == n[j] {
+					i++
+					j++
+					continue
+				}
+			}
+		}
+		if star < 0 || next >= len(n) {
+			return false
+		}
+		next++
+		i, j = star, next
+	}
+	return true
+}
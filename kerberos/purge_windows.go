@@ -0,0 +1,29 @@
+package kerberos
+
+import (
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (c *Conn) purgeTicketCache(op lsa.Op, luid LUID, serverName, realmName string) error {
+	var req lsa.KERB_PURGE_TKT_CACHE_REQUEST
+	buf, names, err := lsa.NewRequest(unsafe.Sizeof(req), serverName, realmName)
+	if err != nil {
+		return err
+	}
+	p := (*lsa.KERB_PURGE_TKT_CACHE_REQUEST)(unsafe.Pointer(&buf[0]))
+	p.MessageType = lsa.KerbPurgeTicketCacheMessage
+	p.LogonId = luid
+	p.ServerName = names[0]
+	p.RealmName = names[1]
+
+	resp, _, err := c.call(op, unsafe.Pointer(&buf[0]), uintptr(len(buf)))
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		return freeReturnBuffer(op, resp)
+	}
+	return nil
+}
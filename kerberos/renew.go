@@ -0,0 +1,67 @@
+package kerberos
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// TicketErrors maps target names to the errors renewing or purging their
+// tickets failed with. RenewTickets and PurgeTickets return it alongside
+// their results when only some tickets failed; use a type assertion to tell
+// it from a failure of the whole call.
+type TicketErrors map[string]error
+
+func (e TicketErrors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	msgs := make([]string, len(names))
+	for idx, name := range names {
+		msgs[idx] = fmt.Sprintf("%s: %v", name, e[name])
+	}
+	noun := "tickets"
+	if len(names) == 1 {
+		noun = "ticket"
+	}
+	return fmt.Sprintf("%d %s failed: %s", len(names), noun, strings.Join(msgs, "; "))
+}
+
+// RenewTickets renews every ticket in the ticket cache of the logon session
+// luid that is renewable and whose RenewTime has not passed, as kinit -R
+// does for a TGT, and stores the renewed tickets in the cache. Services can
+// call it periodically, or on the events of WatchExpiry, to keep their
+// logon session's tickets valid without its password.
+func (c *Conn) RenewTickets(luid LUID) ([]*Ticket, error) {
+	cached, err := c.QueryTicketCache(luid)
+	if err != nil && !lsa.IsFreeBufferError(err) {
+		return nil, err
+	}
+	now := time.Now()
+	var renewed []*Ticket
+	var errs TicketErrors
+	for _, t := range cached {
+		if t.TicketFlags&TicketFlagRenewable == 0 || !t.RenewTime.After(now) {
+			continue
+		}
+		target := t.ServerName + "@" + t.ServerRealm
+		ticket, err := c.RenewTicket(luid, target)
+		if err != nil {
+			if errs == nil {
+				errs = TicketErrors{}
+			}
+			errs[target] = err
+			continue
+		}
+		renewed = append(renewed, ticket)
+	}
+	if errs != nil {
+		return renewed, errs
+	}
+	return renewed, nil
+}
@@ -0,0 +1,124 @@
+package kerberos
+
+import (
+	"context"
+	"time"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// A Ticket is a ticket retrieved from the Kerberos package.
+type Ticket struct {
+	ServiceName      string
+	TargetName       string
+	ClientName       string
+	DomainName       string
+	TargetDomainName string
+	// AltTargetDomainName is the realm of the client.
+	AltTargetDomainName string
+	SessionKeyType      EncryptionType
+	// SessionKey is empty or zeroed if the LSA withholds it, which it does
+	// for TGTs unless the AllowTgtSessionKey policy is set; see
+	// SessionKeyStatus.
+	SessionKey    []byte
+	TicketFlags   TicketFlags
+	StartTime     time.Time
+	EndTime       time.Time
+	RenewUntil    time.Time
+	EncodedTicket []byte
+}
+
+// RetrieveCacheOptions are the cache options of RetrieveTicketWithOpts,
+// the KERB_RETRIEVE_TICKET_* flags.
+type RetrieveCacheOptions uint32
+
+const (
+	// RetrieveDontUseCache always requests a new ticket from the KDC.
+	RetrieveDontUseCache RetrieveCacheOptions = 0x1
+	// RetrieveUseCacheOnly fails instead of asking the KDC for a ticket
+	// that is not cached.
+	RetrieveUseCacheOnly RetrieveCacheOptions = 0x2
+	// RetrieveUseCredHandle uses the credentials of CredentialsHandle
+	// instead of those of the logon session.
+	RetrieveUseCredHandle RetrieveCacheOptions = 0x4
+	// RetrieveAsKerbCred returns the ticket as a KRB-CRED message in
+	// EncodedTicket, see UnmarshalKRBCred.
+	RetrieveAsKerbCred RetrieveCacheOptions = 0x8
+	// RetrieveWithSecCred uses the credentials of CredentialsHandle to
+	// request the ticket.
+	RetrieveWithSecCred RetrieveCacheOptions = 0x10
+	// RetrieveCacheTicket stores a ticket requested from the KDC in the
+	// cache.
+	RetrieveCacheTicket RetrieveCacheOptions = 0x20
+	// RetrieveMaxLifetime requests the longest lifetime the KDC allows.
+	RetrieveMaxLifetime RetrieveCacheOptions = 0x40
+)
+
+// A CredentialsHandle is an SSPI CredHandle, e.g. from
+// AcquireCredentialsHandle.
+type CredentialsHandle struct {
+	Lower uintptr
+	Upper uintptr
+}
+
+// RetrieveTicketOpts are the options of RetrieveTicketWithOpts. The zero
+// value retrieves the ticket like RetrieveTicket.
+type RetrieveTicketOpts struct {
+	CacheOptions RetrieveCacheOptions
+	// KDCOptions are set in a request to the KDC. The KDC options share
+	// their bits with the flags of the issued ticket, e.g.
+	// TicketFlagForwardable requests a forwardable ticket and
+	// TicketFlagForwarded a forwarded TGT for delegation.
+	KDCOptions TicketFlags
+	// EncryptionType is the encryption type required of the ticket's
+	// session key, or EncryptionTypeNull for any.
+	EncryptionType EncryptionType
+	// CredentialsHandle is used with RetrieveUseCredHandle and
+	// RetrieveWithSecCred.
+	CredentialsHandle CredentialsHandle
+	// RequireSessionKey fails the call with a *SessionKeyError if the LSA
+	// withholds the session key, instead of returning a ticket with an
+	// empty or zeroed SessionKey. See Ticket.SessionKeyStatus.
+	RequireSessionKey bool
+}
+
+// kdcOptionRenew is the renew KDC option, requesting the KDC to renew the
+// presented ticket.
+const kdcOptionRenew TicketFlags = 0x00000002
+
+// RetrieveTicket returns the ticket for targetName, e.g.
+// "cifs/fs01.contoso.com", in the logon session luid, from the cache or,
+// if it is not cached, from the KDC.
+func (c *Conn) RetrieveTicket(luid LUID, targetName string) (*Ticket, error) {
+	return c.retrieve("kerberos.RetrieveTicket", luid, targetName, RetrieveTicketOpts{})
+}
+
+// RetrieveTicketWithOpts is like RetrieveTicket, but takes the full set of
+// KerbRetrieveEncodedTicketMessage options.
+func (c *Conn) RetrieveTicketWithOpts(luid LUID, targetName string, opts RetrieveTicketOpts) (*Ticket, error) {
+	t, err := c.retrieve("kerberos.RetrieveTicket", luid, targetName, opts)
+	if err != nil || !opts.RequireSessionKey {
+		return t, err
+	}
+	if status := t.SessionKeyStatus(); status != SessionKeyPresent {
+		return nil, &SessionKeyError{TargetName: targetName, Status: status}
+	}
+	return t, nil
+}
+
+// RenewTicket asks the KDC to renew the ticket for targetName, e.g.
+// "krbtgt/CONTOSO.COM", in the logon session luid and stores the renewed
+// ticket in the cache. The ticket must be renewable.
+func (c *Conn) RenewTicket(luid LUID, targetName string) (*Ticket, error) {
+	return c.retrieve("kerberos.RenewTicket", luid, targetName, RetrieveTicketOpts{
+		CacheOptions: RetrieveDontUseCache | RetrieveCacheTicket,
+		KDCOptions:   kdcOptionRenew,
+	})
+}
+
+// retrieve retrieves a ticket as the operation name.
+func (c *Conn) retrieve(name string, luid LUID, targetName string, opts RetrieveTicketOpts) (t *Ticket, err error) {
+	ctx, op := lsa.StartOp(context.Background(), name, &luid)
+	defer func() { op.End(err) }()
+	return c.retrieveTicket(ctx, op, luid, targetName, opts)
+}
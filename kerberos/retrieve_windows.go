@@ -0,0 +1,70 @@
+package kerberos
+
+import (
+	"context"
+	"strings"
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (c *Conn) retrieveTicket(ctx context.Context, op lsa.Op, luid LUID, targetName string, opts RetrieveTicketOpts) (*Ticket, error) {
+	var req lsa.KERB_RETRIEVE_TKT_REQUEST
+	buf, names, err := lsa.NewRequest(unsafe.Sizeof(req), targetName)
+	if err != nil {
+		return nil, err
+	}
+	p := (*lsa.KERB_RETRIEVE_TKT_REQUEST)(unsafe.Pointer(&buf[0]))
+	p.MessageType = lsa.KerbRetrieveEncodedTicketMessage
+	p.LogonId = luid
+	p.TargetName = names[0]
+	p.TicketFlags = uint32(opts.KDCOptions)
+	p.CacheOptions = uint32(opts.CacheOptions)
+	p.EncryptionType = int32(opts.EncryptionType)
+	p.CredentialsHandle = lsa.SecHandle(opts.CredentialsHandle)
+
+	var resp unsafe.Pointer
+	err = lsa.Retry(ctx, func() (err error) {
+		resp, _, err = c.call(op, unsafe.Pointer(&buf[0]), uintptr(len(buf)))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	t := &(*lsa.KERB_RETRIEVE_TKT_RESPONSE)(resp).Ticket
+	ticket := &Ticket{
+		ServiceName:         externalName(t.ServiceName),
+		TargetName:          externalName(t.TargetName),
+		ClientName:          externalName(t.ClientName),
+		DomainName:          t.DomainName.String(),
+		TargetDomainName:    t.TargetDomainName.String(),
+		AltTargetDomainName: t.AltTargetDomainName.String(),
+		SessionKeyType:      EncryptionType(t.SessionKey.KeyType),
+		TicketFlags:         TicketFlags(t.TicketFlags),
+		StartTime:           lsa.TimeFromUint64(t.StartTime),
+		EndTime:             lsa.TimeFromUint64(t.EndTime),
+		RenewUntil:          lsa.TimeFromUint64(t.RenewUntil),
+	}
+	if t.SessionKey.Length > 0 {
+		ticket.SessionKey = append([]byte(nil), unsafe.Slice(t.SessionKey.Value, t.SessionKey.Length)...)
+	}
+	if t.EncodedTicketSize > 0 {
+		ticket.EncodedTicket = make([]byte, t.EncodedTicketSize)
+		copy(ticket.EncodedTicket, (*[1 << 30]byte)(unsafe.Pointer(t.EncodedTicket))[:t.EncodedTicketSize:t.EncodedTicketSize])
+	}
+
+	return ticket, freeReturnBuffer(op, resp)
+}
+
+// externalName joins the components of a Kerberos principal name with "/".
+func externalName(name *lsa.KERB_EXTERNAL_NAME) string {
+	if name == nil {
+		return ""
+	}
+	data := unsafe.Slice((*lsa.LSA_UNICODE_STRING)(unsafe.Pointer(&name.Names)), name.NameCount)
+	parts := make([]string, len(data))
+	for idx, s := range data {
+		parts[idx] = s.String()
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,78 @@
+package kerberos
+
+import "strings"
+
+// SessionKeyStatus tells whether a retrieved ticket has its session key and
+// why the LSA withheld it otherwise.
+type SessionKeyStatus int
+
+const (
+	// SessionKeyPresent means the ticket has a non-zero session key.
+	SessionKeyPresent SessionKeyStatus = iota
+	// SessionKeyWithheldByPolicy means the ticket is a TGT and the
+	// AllowTgtSessionKey policy is not set, so the LSA returned a zeroed
+	// key.
+	SessionKeyWithheldByPolicy
+	// SessionKeyWithheld means the key is missing for another reason, e.g.
+	// because Credential Guard keeps the session's keys in the isolated
+	// LSA.
+	SessionKeyWithheld
+)
+
+func (s SessionKeyStatus) String() string {
+	switch s {
+	case SessionKeyPresent:
+		return "present"
+	case SessionKeyWithheldByPolicy:
+		return "withheld for TGTs unless AllowTgtSessionKey is set"
+	default:
+		return "withheld, e.g. by Credential Guard"
+	}
+}
+
+// A SessionKeyError is returned by RetrieveTicketWithOpts with
+// RequireSessionKey if the LSA withheld the session key.
+type SessionKeyError struct {
+	TargetName string
+	Status     SessionKeyStatus
+}
+
+func (e *SessionKeyError) Error() string {
+	return "kerberos: session key of " + e.TargetName + " " + e.Status.String()
+}
+
+// IsTGT reports whether the ticket is a ticket-granting ticket.
+func (t *Ticket) IsTGT() bool {
+	return strings.HasPrefix(strings.ToLower(t.ServiceName), "krbtgt/")
+}
+
+// SessionKeyStatus reports whether t has its session key. For tickets
+// retrieved with RetrieveAsKerbCred the key is looked up in the KRB-CRED
+// message. The AllowTgtSessionKey policy is read to tell why the key of a
+// TGT is missing.
+func (t *Ticket) SessionKeyStatus() SessionKeyStatus {
+	key := t.SessionKey
+	if len(key) == 0 && len(t.EncodedTicket) > 0 && t.EncodedTicket[0] == 0x76 {
+		if creds, err := UnmarshalKRBCred(t.EncodedTicket); err == nil && len(creds) == 1 {
+			key = creds[0].SessionKey
+		}
+	}
+	for _, b := range key {
+		if b != 0 {
+			return SessionKeyPresent
+		}
+	}
+	if t.IsTGT() {
+		if allowed, err := AllowTgtSessionKey(); err == nil && !allowed {
+			return SessionKeyWithheldByPolicy
+		}
+	}
+	return SessionKeyWithheld
+}
+
+// AllowTgtSessionKey reports whether the allowtgtsessionkey value of the
+// Kerberos parameters is set, which makes the LSA return the session keys
+// of TGTs. It is false if the value does not exist.
+func AllowTgtSessionKey() (bool, error) {
+	return allowTgtSessionKey()
+}
@@ -0,0 +1,21 @@
+package kerberos
+
+import "golang.org/x/sys/windows/registry"
+
+const parametersKey = `SYSTEM\CurrentControlSet\Control\Lsa\Kerberos\Parameters`
+
+func allowTgtSessionKey() (bool, error) {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, parametersKey, registry.QUERY_VALUE)
+	if err == registry.ErrNotExist {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer k.Close()
+	v, _, err := k.GetIntegerValue("AllowTgtSessionKey")
+	if err == registry.ErrNotExist {
+		return false, nil
+	}
+	return v != 0, err
+}
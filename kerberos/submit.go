@@ -0,0 +1,17 @@
+package kerberos
+
+import (
+	"context"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// SubmitTicket stores the tickets of the KRB-CRED message krbCred in the
+// ticket cache of the logon session luid. The EncKrbCredPart of krbCred
+// must not be encrypted, as in the messages made by MarshalKRBCred. The
+// zero LUID refers to the caller's logon session.
+func (c *Conn) SubmitTicket(luid LUID, krbCred []byte) (err error) {
+	_, op := lsa.StartOp(context.Background(), "kerberos.SubmitTicket", &luid)
+	defer func() { op.End(err) }()
+	return c.submitTicket(op, luid, krbCred)
+}
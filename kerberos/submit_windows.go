@@ -0,0 +1,34 @@
+package kerberos
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (c *Conn) submitTicket(op lsa.Op, luid LUID, krbCred []byte) error {
+	if len(krbCred) == 0 {
+		return errors.New("kerberos: empty KRB-CRED message")
+	}
+	var req lsa.KERB_SUBMIT_TKT_REQUEST
+	size := unsafe.Sizeof(req)
+	buf := make([]byte, size+uintptr(len(krbCred)))
+	p := (*lsa.KERB_SUBMIT_TKT_REQUEST)(unsafe.Pointer(&buf[0]))
+	p.MessageType = lsa.KerbSubmitTicketMessage
+	p.LogonId = luid
+	// The EncKrbCredPart of the message is not encrypted, so no key is
+	// given.
+	p.KerbCredSize = uint32(len(krbCred))
+	p.KerbCredOffset = uint32(size)
+	copy(buf[size:], krbCred)
+
+	resp, _, err := c.call(op, unsafe.Pointer(&buf[0]), uintptr(len(buf)))
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		return freeReturnBuffer(op, resp)
+	}
+	return nil
+}
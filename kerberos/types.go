@@ -0,0 +1,172 @@
+package kerberos
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TicketFlags are the flags of a Kerberos ticket.
+type TicketFlags uint32
+
+const (
+	TicketFlagForwardable      TicketFlags = 0x40000000
+	TicketFlagForwarded        TicketFlags = 0x20000000
+	TicketFlagProxiable        TicketFlags = 0x10000000
+	TicketFlagProxy            TicketFlags = 0x08000000
+	TicketFlagMayPostdate      TicketFlags = 0x04000000
+	TicketFlagPostdated        TicketFlags = 0x02000000
+	TicketFlagInvalid          TicketFlags = 0x01000000
+	TicketFlagRenewable        TicketFlags = 0x00800000
+	TicketFlagInitial          TicketFlags = 0x00400000
+	TicketFlagPreAuthent       TicketFlags = 0x00200000
+	TicketFlagHWAuthent        TicketFlags = 0x00100000
+	TicketFlagOkAsDelegate     TicketFlags = 0x00040000
+	TicketFlagNameCanonicalize TicketFlags = 0x00010000
+)
+
+var ticketFlagNames = []struct {
+	flag TicketFlags
+	name string
+}{
+	{TicketFlagForwardable, "forwardable"},
+	{TicketFlagForwarded, "forwarded"},
+	{TicketFlagProxiable, "proxiable"},
+	{TicketFlagProxy, "proxy"},
+	{TicketFlagMayPostdate, "may_postdate"},
+	{TicketFlagPostdated, "postdated"},
+	{TicketFlagInvalid, "invalid"},
+	{TicketFlagRenewable, "renewable"},
+	{TicketFlagInitial, "initial"},
+	{TicketFlagPreAuthent, "pre_authent"},
+	{TicketFlagHWAuthent, "hw_authent"},
+	{TicketFlagOkAsDelegate, "ok_as_delegate"},
+	{TicketFlagNameCanonicalize, "name_canonicalize"},
+}
+
+// String lists the set flags using the names printed by klist.exe.
+func (f TicketFlags) String() string {
+	var names []string
+	for _, n := range ticketFlagNames {
+		if f&n.flag != 0 {
+			names = append(names, n.name)
+			f &^= n.flag
+		}
+	}
+	if f != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint32(f)))
+	}
+	return strings.Join(names, " ")
+}
+
+func (f TicketFlags) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText parses the format returned by String.
+func (f *TicketFlags) UnmarshalText(text []byte) error {
+	var flags TicketFlags
+next:
+	for _, name := range strings.Fields(string(text)) {
+		for _, n := range ticketFlagNames {
+			if name == n.name {
+				flags |= n.flag
+				continue next
+			}
+		}
+		var v uint32
+		_, err := fmt.Sscanf(name, "0x%x", &v)
+		if err != nil {
+			return fmt.Errorf("invalid ticket flag %q", name)
+		}
+		flags |= TicketFlags(v)
+	}
+	*f = flags
+	return nil
+}
+
+// EncryptionType is a Kerberos encryption type (etype).
+type EncryptionType int32
+
+const (
+	EncryptionTypeNull                 EncryptionType = 0
+	EncryptionTypeDesCbcCrc            EncryptionType = 1
+	EncryptionTypeDesCbcMd4            EncryptionType = 2
+	EncryptionTypeDesCbcMd5            EncryptionType = 3
+	EncryptionTypeAes128CtsHmacSha196  EncryptionType = 17
+	EncryptionTypeAes256CtsHmacSha196  EncryptionType = 18
+	EncryptionTypeAes128CtsHmacSha2256 EncryptionType = 19
+	EncryptionTypeAes256CtsHmacSha2384 EncryptionType = 20
+	EncryptionTypeRc4HmacNt            EncryptionType = 23
+	EncryptionTypeRc4HmacNtExp         EncryptionType = 24
+	EncryptionTypeRc4HmacOld           EncryptionType = -133
+	EncryptionTypeRc4HmacOldExp        EncryptionType = -135
+)
+
+// String returns the name klist.exe uses for the encryption type.
+func (e EncryptionType) String() string {
+	switch e {
+	case EncryptionTypeNull:
+		return "NULL"
+	case EncryptionTypeDesCbcCrc:
+		return "DES-CBC-CRC"
+	case EncryptionTypeDesCbcMd4:
+		return "DES-CBC-MD4"
+	case EncryptionTypeDesCbcMd5:
+		return "DES-CBC-MD5"
+	case EncryptionTypeAes128CtsHmacSha196:
+		return "AES-128-CTS-HMAC-SHA1-96"
+	case EncryptionTypeAes256CtsHmacSha196:
+		return "AES-256-CTS-HMAC-SHA1-96"
+	case EncryptionTypeAes128CtsHmacSha2256:
+		return "AES-128-CTS-HMAC-SHA256-128"
+	case EncryptionTypeAes256CtsHmacSha2384:
+		return "AES-256-CTS-HMAC-SHA384-192"
+	case EncryptionTypeRc4HmacNt:
+		return "RSADSI RC4-HMAC(NT)"
+	case EncryptionTypeRc4HmacNtExp:
+		return "RSADSI RC4-HMAC(NT)-EXP"
+	case EncryptionTypeRc4HmacOld:
+		return "RSADSI RC4-HMAC(OLD)"
+	case EncryptionTypeRc4HmacOldExp:
+		return "RSADSI RC4-HMAC(OLD)-EXP"
+	default:
+		return fmt.Sprintf("Undefined EncryptionType(%d)", e)
+	}
+}
+
+func (e EncryptionType) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+var encryptionTypes = []EncryptionType{
+	EncryptionTypeNull,
+	EncryptionTypeDesCbcCrc,
+	EncryptionTypeDesCbcMd4,
+	EncryptionTypeDesCbcMd5,
+	EncryptionTypeAes128CtsHmacSha196,
+	EncryptionTypeAes256CtsHmacSha196,
+	EncryptionTypeAes128CtsHmacSha2256,
+	EncryptionTypeAes256CtsHmacSha2384,
+	EncryptionTypeRc4HmacNt,
+	EncryptionTypeRc4HmacNtExp,
+	EncryptionTypeRc4HmacOld,
+	EncryptionTypeRc4HmacOldExp,
+}
+
+// UnmarshalText parses the names returned by String.
+func (e *EncryptionType) UnmarshalText(text []byte) error {
+	s := string(text)
+	for _, et := range encryptionTypes {
+		if s == et.String() {
+			*e = et
+			return nil
+		}
+	}
+	var n int32
+	_, err := fmt.Sscanf(s, "Undefined EncryptionType(%d)", &n)
+	if err != nil {
+		return fmt.Errorf("invalid encryption type %q", s)
+	}
+	*e = EncryptionType(n)
+	return nil
+}
@@ -0,0 +1,46 @@
+//go:build !windows
+// +build !windows
+
+package kerberos
+
+import (
+	"context"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func connect() (*Conn, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func connectTrusted(name string) (*Conn, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (c *Conn) close() error {
+	return nil
+}
+
+func (c *Conn) queryTicketCache(ctx context.Context, op lsa.Op, luid LUID) ([]TicketCacheInfo, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (c *Conn) purgeTicketCache(op lsa.Op, luid LUID, serverName, realmName string) error {
+	return lsa.ErrUnsupportedPlatform
+}
+
+func (c *Conn) retrieveTicket(ctx context.Context, op lsa.Op, luid LUID, targetName string, opts RetrieveTicketOpts) (*Ticket, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (c *Conn) submitTicket(op lsa.Op, luid LUID, krbCred []byte) error {
+	return lsa.ErrUnsupportedPlatform
+}
+
+func (c *Conn) retrieveKeyTab(op lsa.Op, userName, domainName, password string) ([]byte, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func allowTgtSessionKey() (bool, error) {
+	return false, lsa.ErrUnsupportedPlatform
+}
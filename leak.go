@@ -0,0 +1,37 @@
+package winlsa
+
+import "github.com/cobraqxx/winlsa/internal/lsa"
+
+// A Leak is a resource holding an LSA handle or buffer that was garbage
+// collected without being closed.
+type Leak struct {
+	// Resource is the type of the resource, e.g. "kerberos.Conn" or
+	// "policy.Policy".
+	Resource string
+	// Stack is the stack trace of the goroutine that opened the resource.
+	Stack []byte
+}
+
+// SetLeakHandler enables leak detection for the resources of this module
+// that own LSA handles or buffers: kerberos.Conn, policy.Policy,
+// policy.Account, lsaraw.Handle and lsaraw.Buffer. Resources opened
+// afterwards carry a finalizer that calls h if they are garbage collected
+// without being closed. A nil h disables detection for resources opened
+// afterwards, which is the default.
+//
+// Detection records a stack trace per resource and depends on the garbage
+// collector running, so it is meant for debugging and tests of long-running
+// processes rather than production. h is called from the finalizer
+// goroutine and must not block.
+//
+// SetLeakHandler must not be called concurrently with other functions of
+// this module.
+func SetLeakHandler(h func(Leak)) {
+	if h == nil {
+		lsa.SetLeakHandler(nil)
+		return
+	}
+	lsa.SetLeakHandler(func(resource string, stack []byte) {
+		h(Leak{Resource: resource, Stack: stack})
+	})
+}
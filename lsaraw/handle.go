@@ -0,0 +1,127 @@
+package lsaraw
+
+import (
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// A Handle is a connection to the LSA. Unlike an LSA_HANDLE, it is closed
+// at most once and is reported by winlsa.SetLeakHandler if it is garbage
+// collected without being closed. Value exposes the handle, so Handle does
+// not lock it: Close must not be called concurrently with other methods.
+type Handle struct {
+	h LSA_HANDLE
+}
+
+// Connect opens an untrusted connection with LsaConnectUntrusted.
+func Connect() (*Handle, error) {
+	var h LSA_HANDLE
+	if err := lsa.LsaConnectUntrusted(&h); err != nil {
+		return nil, err
+	}
+	return newHandle(h), nil
+}
+
+// Register opens a trusted connection with LsaRegisterLogonProcess, which
+// requires SeTcbPrivilege.
+func Register(logonProcessName string) (*Handle, error) {
+	name, err := lsa.NewString(logonProcessName)
+	if err != nil {
+		return nil, err
+	}
+	var h LSA_HANDLE
+	var mode uint32
+	if err := lsa.LsaRegisterLogonProcess(&name, &h, &mode); err != nil {
+		return nil, err
+	}
+	return newHandle(h), nil
+}
+
+func newHandle(h LSA_HANDLE) *Handle {
+	handle := &Handle{h: h}
+	lsa.TrackLeak(handle, "lsaraw.Handle")
+	return handle
+}
+
+// Value returns the underlying handle, which is zero once h is closed.
+func (h *Handle) Value() LSA_HANDLE {
+	return h.h
+}
+
+// LookupPackage returns the identifier of the authentication package name.
+func (h *Handle) LookupPackage(name string) (uint32, error) {
+	return LookupPackage(h.h, name)
+}
+
+// Call is like CallPackage but returns the response as a Buffer.
+func (h *Handle) Call(authenticationPackage uint32, req unsafe.Pointer, reqLen uintptr) (*Buffer, error) {
+	resp, respLen, err := lsa.CallPackage(h.h, authenticationPackage, req, reqLen)
+	if err != nil {
+		return nil, err
+	}
+	return newBuffer(resp, respLen), nil
+}
+
+// Close closes the connection with LsaDeregisterLogonProcess.
+func (h *Handle) Close() error {
+	if h.h == 0 {
+		return nil
+	}
+	err := lsa.LsaDeregisterLogonProcess(h.h)
+	h.h = 0
+	lsa.UntrackLeak(h)
+	return err
+}
+
+// A Buffer is a buffer returned by the LSA. It is released at most once and
+// is reported by winlsa.SetLeakHandler if it is garbage collected without
+// being closed.
+type Buffer struct {
+	p unsafe.Pointer
+	n uint32
+}
+
+// GetLogonSessionData returns the SECURITY_LOGON_SESSION_DATA of the logon
+// session luid.
+func GetLogonSessionData(luid LUID) (*Buffer, error) {
+	var data *SECURITY_LOGON_SESSION_DATA
+	if err := lsa.LsaGetLogonSessionData(&luid, &data); err != nil {
+		return nil, err
+	}
+	return newBuffer(unsafe.Pointer(data), uint32(unsafe.Sizeof(*data))), nil
+}
+
+func newBuffer(p unsafe.Pointer, n uint32) *Buffer {
+	b := &Buffer{p: p, n: n}
+	if p != nil {
+		lsa.TrackLeak(b, "lsaraw.Buffer")
+	}
+	return b
+}
+
+// Pointer returns the start of the buffer, which is nil if the LSA returned
+// no buffer or b is closed.
+func (b *Buffer) Pointer() unsafe.Pointer {
+	return b.p
+}
+
+// Len returns the length the LSA reported for the buffer. For
+// GetLogonSessionData, which reports none, it is the size of the structure
+// without the data it references.
+func (b *Buffer) Len() uint32 {
+	return b.n
+}
+
+// Close releases the buffer with LsaFreeReturnBuffer. Pointers into the
+// buffer must not be used afterwards.
+func (b *Buffer) Close() error {
+	if b.p == nil {
+		return nil
+	}
+	err := lsa.LsaFreeReturnBuffer(uintptr(b.p))
+	b.p = nil
+	b.n = 0
+	lsa.UntrackLeak(b)
+	return err
+}
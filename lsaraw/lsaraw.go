@@ -0,0 +1,136 @@
+// Package lsaraw exposes the syscall layer the winlsa packages are built
+// on: the LSA structures, the authentication package calls and helpers to
+// build request buffers. It is meant for messages and fields the high-level
+// packages do not cover yet.
+//
+// The functions return Windows errors; NTSTATUS results are converted with
+// LsaNtStatusToWinError. The Lsa functions take and return raw handles and
+// buffers, which the caller must release. Handle and Buffer wrap them
+// instead, and are reported by winlsa.SetLeakHandler when they are not
+// closed.
+//
+// A minimal Kerberos call:
+//
+//	h, err := lsaraw.Connect()
+//	if err != nil { ... }
+//	defer h.Close()
+//	pkg, err := h.LookupPackage(lsaraw.KerberosPackageName)
+//	req := lsaraw.KERB_QUERY_TKT_CACHE_REQUEST{MessageType: lsaraw.KerbQueryTicketCacheEx2Message}
+//	resp, err := h.Call(pkg, unsafe.Pointer(&req), unsafe.Sizeof(req))
+//	if err != nil { ... }
+//	defer resp.Close()
+//
+// On platforms other than Windows, the calls fail with
+// winlsa.ErrUnsupportedPlatform; the structures and the request helpers
+// remain usable.
+package lsaraw
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// LsaEnumerateLogonSessions returns the LUIDs of the current logon sessions
+// in a buffer to be released with LsaFreeReturnBuffer.
+func LsaEnumerateLogonSessions(sessionCount *uint32, sessions **LUID) error {
+	return lsa.LsaEnumerateLogonSessions(sessionCount, sessions)
+}
+
+func LsaGetLogonSessionData(luid *LUID, sessionData **SECURITY_LOGON_SESSION_DATA) error {
+	return lsa.LsaGetLogonSessionData(luid, sessionData)
+}
+
+// LsaFreeReturnBuffer releases a buffer returned by the LSA. A nil buffer
+// is ignored.
+func LsaFreeReturnBuffer(buffer unsafe.Pointer) error {
+	if buffer == nil {
+		return nil
+	}
+	return lsa.LsaFreeReturnBuffer(uintptr(buffer))
+}
+
+func LsaConnectUntrusted(lsaHandle *LSA_HANDLE) error {
+	return lsa.LsaConnectUntrusted(lsaHandle)
+}
+
+// LsaRegisterLogonProcess requires SeTcbPrivilege.
+func LsaRegisterLogonProcess(logonProcessName *LSA_STRING, lsaHandle *LSA_HANDLE, securityMode *uint32) error {
+	return lsa.LsaRegisterLogonProcess(logonProcessName, lsaHandle, securityMode)
+}
+
+func LsaDeregisterLogonProcess(lsaHandle LSA_HANDLE) error {
+	return lsa.LsaDeregisterLogonProcess(lsaHandle)
+}
+
+func LsaLookupAuthenticationPackage(lsaHandle LSA_HANDLE, packageName *LSA_STRING, authenticationPackage *uint32) error {
+	return lsa.LsaLookupAuthenticationPackage(lsaHandle, packageName, authenticationPackage)
+}
+
+// LsaCallAuthenticationPackage returns the error of the call itself; the
+// status reported by the package is stored in protocolStatus as an NTSTATUS.
+// CallPackage checks both.
+func LsaCallAuthenticationPackage(lsaHandle LSA_HANDLE, authenticationPackage uint32, protocolSubmitBuffer unsafe.Pointer, submitBufferLength uint32, protocolReturnBuffer *unsafe.Pointer, returnBufferLength *uint32, protocolStatus *uint32) error {
+	return lsa.LsaCallAuthenticationPackage(lsaHandle, authenticationPackage, protocolSubmitBuffer, submitBufferLength, protocolReturnBuffer, returnBufferLength, protocolStatus)
+}
+
+// LsaNtStatusToWinError converts an NTSTATUS to a Windows error, returning
+// nil for STATUS_SUCCESS.
+func LsaNtStatusToWinError(ntstatus uint32) error {
+	return lsa.LsaNtStatusToWinError(uintptr(ntstatus))
+}
+
+// LookupPackage returns the identifier of the authentication package name,
+// e.g. KerberosPackageName.
+func LookupPackage(lsaHandle LSA_HANDLE, name string) (uint32, error) {
+	lsaName, err := lsa.NewString(name)
+	if err != nil {
+		return 0, err
+	}
+	var pkg uint32
+	err = lsa.LsaLookupAuthenticationPackage(lsaHandle, &lsaName, &pkg)
+	return pkg, err
+}
+
+// CallPackage submits the reqLen bytes at req to an authentication package
+// and returns the response buffer and its length. A failed protocol status
+// is returned as an error, in which case no buffer is returned. Otherwise
+// the buffer, which may be nil, must be released with LsaFreeReturnBuffer.
+func CallPackage(lsaHandle LSA_HANDLE, authenticationPackage uint32, req unsafe.Pointer, reqLen uintptr) (unsafe.Pointer, uint32, error) {
+	return lsa.CallPackage(lsaHandle, authenticationPackage, req, reqLen)
+}
+
+// NewRequest allocates a request buffer of size bytes followed by the UTF-16
+// encodings of strs, and returns the buffer and strings referencing the
+// encodings. Authentication packages require the strings of a request to lie
+// within the submitted buffer; cast the buffer to the request structure and
+// assign the strings to its fields.
+func NewRequest(size uintptr, strs ...string) ([]byte, []LSA_UNICODE_STRING, error) {
+	return lsa.NewRequest(size, strs...)
+}
+
+// NewUnicodeString returns an LSA_UNICODE_STRING referencing a freshly
+// allocated UTF-16 copy of s.
+func NewUnicodeString(s string) (LSA_UNICODE_STRING, error) {
+	return lsa.NewUnicodeString(s)
+}
+
+// NewString returns an LSA_STRING referencing a NUL terminated copy of the
+// ANSI string s.
+func NewString(s string) (LSA_STRING, error) {
+	return lsa.NewString(s)
+}
+
+// UTF16String decodes the first size bytes of the UTF-16 buffer at p, e.g.
+// a string that is not an LSA_UNICODE_STRING. LSA_UNICODE_STRING has a
+// String method.
+func UTF16String(p *uint16, size int) string {
+	return lsa.UTF16String(p, size)
+}
+
+// Time converts a LARGE_INTEGER timestamp in 100ns intervals since 1601.
+// Zero and the "never" value 0x7FFFFFFFFFFFFFFF both map to the zero time.
+func Time(t uint64) time.Time {
+	return lsa.TimeFromUint64(t)
+}
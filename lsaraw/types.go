@@ -0,0 +1,58 @@
+package lsaraw
+
+import "github.com/cobraqxx/winlsa/internal/lsa"
+
+// The structures mirror the Windows SDK definitions of the same name,
+// including their layout on 386, amd64 and arm64.
+type (
+	LUID                              = lsa.LUID
+	LSA_HANDLE                        = lsa.LSA_HANDLE
+	LSA_STRING                        = lsa.LSA_STRING
+	LSA_UNICODE_STRING                = lsa.LSA_UNICODE_STRING
+	LSA_LAST_INTER_LOGON_INFO         = lsa.LSA_LAST_INTER_LOGON_INFO
+	SECURITY_LOGON_SESSION_DATA       = lsa.SECURITY_LOGON_SESSION_DATA
+	SecHandle                         = lsa.SecHandle
+	KERB_QUERY_TKT_CACHE_REQUEST      = lsa.KERB_QUERY_TKT_CACHE_REQUEST
+	KERB_QUERY_TKT_CACHE_EX2_RESPONSE = lsa.KERB_QUERY_TKT_CACHE_EX2_RESPONSE
+	KERB_TICKET_CACHE_INFO_EX2        = lsa.KERB_TICKET_CACHE_INFO_EX2
+	KERB_PURGE_TKT_CACHE_REQUEST      = lsa.KERB_PURGE_TKT_CACHE_REQUEST
+	KERB_RETRIEVE_TKT_REQUEST         = lsa.KERB_RETRIEVE_TKT_REQUEST
+	KERB_RETRIEVE_TKT_RESPONSE        = lsa.KERB_RETRIEVE_TKT_RESPONSE
+	KERB_EXTERNAL_TICKET              = lsa.KERB_EXTERNAL_TICKET
+	KERB_EXTERNAL_NAME                = lsa.KERB_EXTERNAL_NAME
+	KERB_CRYPTO_KEY                   = lsa.KERB_CRYPTO_KEY
+	KERB_RETRIEVE_KEY_TAB_REQUEST     = lsa.KERB_RETRIEVE_KEY_TAB_REQUEST
+	KERB_RETRIEVE_KEY_TAB_RESPONSE    = lsa.KERB_RETRIEVE_KEY_TAB_RESPONSE
+)
+
+// Authentication package names for LookupPackage.
+const (
+	KerberosPackageName = "Kerberos"
+	MSV1_0_PACKAGE_NAME = lsa.MSV1_0_PACKAGE_NAME
+)
+
+// KERB_PROTOCOL_MESSAGE_TYPE values.
+const (
+	KerbQueryTicketCacheMessage      = lsa.KerbQueryTicketCacheMessage
+	KerbRetrieveTicketMessage        = lsa.KerbRetrieveTicketMessage
+	KerbPurgeTicketCacheMessage      = lsa.KerbPurgeTicketCacheMessage
+	KerbRetrieveEncodedTicketMessage = lsa.KerbRetrieveEncodedTicketMessage
+	KerbQueryTicketCacheExMessage    = lsa.KerbQueryTicketCacheExMessage
+	KerbPurgeTicketCacheExMessage    = lsa.KerbPurgeTicketCacheExMessage
+	KerbQueryTicketCacheEx2Message   = lsa.KerbQueryTicketCacheEx2Message
+	KerbSubmitTicketMessage          = lsa.KerbSubmitTicketMessage
+	KerbQueryTicketCacheEx3Message   = lsa.KerbQueryTicketCacheEx3Message
+	KerbRetrieveKeyTabMessage        = lsa.KerbRetrieveKeyTabMessage
+)
+
+// KERB_RETRIEVE_TKT_REQUEST CacheOptions.
+const (
+	KERB_RETRIEVE_TICKET_DEFAULT        = lsa.KERB_RETRIEVE_TICKET_DEFAULT
+	KERB_RETRIEVE_TICKET_DONT_USE_CACHE = lsa.KERB_RETRIEVE_TICKET_DONT_USE_CACHE
+	KERB_RETRIEVE_TICKET_USE_CACHE_ONLY = lsa.KERB_RETRIEVE_TICKET_USE_CACHE_ONLY
+	KERB_RETRIEVE_TICKET_USE_CREDHANDLE = lsa.KERB_RETRIEVE_TICKET_USE_CREDHANDLE
+	KERB_RETRIEVE_TICKET_AS_KERB_CRED   = lsa.KERB_RETRIEVE_TICKET_AS_KERB_CRED
+	KERB_RETRIEVE_TICKET_WITH_SEC_CRED  = lsa.KERB_RETRIEVE_TICKET_WITH_SEC_CRED
+	KERB_RETRIEVE_TICKET_CACHE_TICKET   = lsa.KERB_RETRIEVE_TICKET_CACHE_TICKET
+	KERB_RETRIEVE_TICKET_MAX_LIFETIME   = lsa.KERB_RETRIEVE_TICKET_MAX_LIFETIME
+)
@@ -0,0 +1,153 @@
+// Package metrics exposes logon session telemetry as Prometheus metrics.
+//
+//	prometheus.MustRegister(metrics.NewCollector(metrics.Options{}))
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+// DefaultAgeBuckets are the session age histogram buckets used when
+// Options.AgeBuckets is empty: 1m, 10m, 1h, 8h, 1d, 7d and 30d.
+var DefaultAgeBuckets = []float64{60, 600, 3600, 8 * 3600, 86400, 7 * 86400, 30 * 86400}
+
+type Options struct {
+	// Namespace prefixes the metric names. It defaults to "winlsa".
+	Namespace string
+	// AgeBuckets are the upper bounds of the session age histogram in
+	// seconds.
+	AgeBuckets []float64
+	// PerUser adds a gauge of sessions per account. Its cardinality grows
+	// with the number of users logging on.
+	PerUser bool
+}
+
+// A Collector enumerates the logon sessions on every scrape.
+type Collector struct {
+	opts Options
+
+	sessions     *prometheus.Desc
+	age          *prometheus.Desc
+	oldest       *prometheus.Desc
+	userSessions *prometheus.Desc
+	anonymous    *prometheus.Desc
+	nullSessions *prometheus.Desc
+	queryErrors  *prometheus.Desc
+	up           *prometheus.Desc
+}
+
+// NewCollector returns a Collector using opts.
+func NewCollector(opts Options) *Collector {
+	if opts.Namespace == "" {
+		opts.Namespace = "winlsa"
+	}
+	if len(opts.AgeBuckets) == 0 {
+		opts.AgeBuckets = DefaultAgeBuckets
+	}
+	name := func(n string) string {
+		return prometheus.BuildFQName(opts.Namespace, "", n)
+	}
+	return &Collector{
+		opts:         opts,
+		sessions:     prometheus.NewDesc(name("sessions"), "Number of logon sessions by logon type and authentication package.", []string{"logon_type", "auth_package"}, nil),
+		age:          prometheus.NewDesc(name("session_age_seconds"), "Age of the logon sessions.", nil, nil),
+		oldest:       prometheus.NewDesc(name("oldest_session_age_seconds"), "Age of the oldest logon session.", nil, nil),
+		userSessions: prometheus.NewDesc(name("user_sessions"), "Number of logon sessions by account.", []string{"user"}, nil),
+		anonymous:    prometheus.NewDesc(name("anonymous_sessions"), "Number of ANONYMOUS LOGON sessions, including null sessions.", nil, nil),
+		nullSessions: prometheus.NewDesc(name("null_sessions"), "Number of null sessions, network logons without credentials.", nil, nil),
+		queryErrors:  prometheus.NewDesc(name("session_query_errors"), "Number of sessions that could not be queried during the scrape.", nil, nil),
+		up:           prometheus.NewDesc(name("up"), "Whether the logon sessions could be enumerated.", nil, nil),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.sessions
+	ch <- c.age
+	ch <- c.oldest
+	if c.opts.PerUser {
+		ch <- c.userSessions
+	}
+	ch <- c.anonymous
+	ch <- c.nullSessions
+	ch <- c.queryErrors
+	ch <- c.up
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	luids, err := winlsa.GetLogonSessions()
+	if err != nil && !winlsa.IsFreeBufferError(err) {
+		ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 0)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 1)
+
+	now := time.Now()
+	byType := map[[2]string]int{}
+	byUser := map[string]int{}
+	buckets := make(map[float64]uint64, len(c.opts.AgeBuckets))
+	var ageCount uint64
+	var ageSum, oldest float64
+	var anonymous, nullSessions, queryErrors int
+	for _, luid := range luids {
+		sd, err := winlsa.GetLogonSessionData(&luid)
+		if err != nil && !winlsa.IsFreeBufferError(err) {
+			queryErrors++
+			continue
+		}
+		byType[[2]string{sd.LogonType.String(), sd.AuthenticationPackage}]++
+		if c.opts.PerUser && sd.UserName != "" {
+			user := sd.UserName
+			if sd.LogonDomain != "" {
+				user = sd.LogonDomain + `\` + user
+			}
+			byUser[user]++
+		}
+		if sd.IsAnonymous() {
+			anonymous++
+			if sd.IsNullSession() {
+				nullSessions++
+			}
+		}
+		if sd.LogonTime.IsZero() {
+			continue
+		}
+		age := now.Sub(sd.LogonTime).Seconds()
+		ageCount++
+		ageSum += age
+		if age > oldest {
+			oldest = age
+		}
+		for _, b := range c.opts.AgeBuckets {
+			if age <= b {
+				buckets[b]++
+			}
+		}
+	}
+
+	keys := make([][2]string, 0, len(byType))
+	for key := range byType {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, key := range keys {
+		ch <- prometheus.MustNewConstMetric(c.sessions, prometheus.GaugeValue, float64(byType[key]), key[0], key[1])
+	}
+	ch <- prometheus.MustNewConstHistogram(c.age, ageCount, ageSum, buckets)
+	ch <- prometheus.MustNewConstMetric(c.oldest, prometheus.GaugeValue, oldest)
+	for user, n := range byUser {
+		ch <- prometheus.MustNewConstMetric(c.userSessions, prometheus.GaugeValue, float64(n), user)
+	}
+	ch <- prometheus.MustNewConstMetric(c.anonymous, prometheus.GaugeValue, float64(anonymous))
+	ch <- prometheus.MustNewConstMetric(c.nullSessions, prometheus.GaugeValue, float64(nullSessions))
+	ch <- prometheus.MustNewConstMetric(c.queryErrors, prometheus.GaugeValue, float64(queryErrors))
+}
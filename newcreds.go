@@ -0,0 +1,113 @@
+package winlsa
+
+import "github.com/cobraqxx/winlsa/securitylog"
+
+// A NewCredentialsSession is a logon session of type NewCredentials, as
+// created by "runas /netonly" or LogonUser with
+// LOGON32_LOGON_NEW_CREDENTIALS. Such a session keeps the caller's identity
+// locally but uses other credentials on the network, which makes it a
+// common lateral movement indicator. The session data only shows the
+// local identity; the network credentials and the creating process come
+// from the Security log and the ticket cache.
+type NewCredentialsSession struct {
+	Data *LogonSessionData
+	// Processes are the processes running in the session. ProcessesError
+	// tells why they are missing.
+	Processes      []SessionProcess
+	ProcessesError string `json:",omitempty"`
+	// OriginLogonId is the logon session that created the session, from
+	// the TokenOrigin of its processes, e.g. the interactive session runas
+	// ran in. It is zero if no process token could be read.
+	OriginLogonId LUID
+	// OriginProcessId and OriginProcessName identify the process that
+	// created the session, and OutboundUserName and OutboundDomainName
+	// the network credentials, from the 4624 event. They are empty if the
+	// event could not be read; EventError tells why.
+	OriginProcessId    uint32 `json:",omitempty"`
+	OriginProcessName  string `json:",omitempty"`
+	OutboundUserName   string `json:",omitempty"`
+	OutboundDomainName string `json:",omitempty"`
+	EventError         string `json:",omitempty"`
+	// KerberosClients are the distinct client principals, as
+	// name@REALM, of the tickets the session obtained with its network
+	// credentials. TicketsError tells why they are missing.
+	KerberosClients []string `json:",omitempty"`
+	TicketsError    string   `json:",omitempty"`
+}
+
+// NewCredentialsSessions reports the NewCredentials logon sessions. Reading
+// the Security log requires administrator rights, and attributing
+// processes and reading other users' ticket caches require
+// SeDebugPrivilege and SeTcbPrivilege; the parts that cannot be collected
+// are left empty with an explanation. If some sessions cannot be queried,
+// the others are returned with a SessionErrors error.
+func NewCredentialsSessions() ([]*NewCredentialsSession, error) {
+	sessions, err := FindLogonSessions(SessionFilter{LogonTypes: []LogonType{LogonTypeNewCredentials}})
+	if _, partial := err.(SessionErrors); err != nil && !partial {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, err
+	}
+
+	report := make([]*NewCredentialsSession, len(sessions))
+	byLUID := make(map[LUID]*NewCredentialsSession, len(sessions))
+	for i, sd := range sessions {
+		report[i] = &NewCredentialsSession{Data: sd}
+		byLUID[sd.LogonId] = report[i]
+	}
+	procs, procErr := processList()
+	for _, s := range report {
+		if procErr != nil {
+			s.ProcessesError = procErr.Error()
+		}
+	}
+	for _, p := range procs {
+		if s := byLUID[p.luid]; p.attributed && s != nil {
+			s.Processes = append(s.Processes, p.SessionProcess)
+			if s.OriginLogonId == (LUID{}) {
+				s.OriginLogonId = p.origin
+			}
+		}
+	}
+
+	for _, s := range report {
+		ev, evErr := securitylog.FindLogonEvent(s.Data.LogonId, s.Data.LogonTime)
+		switch {
+		case evErr != nil:
+			s.EventError = evErr.Error()
+		case ev == nil:
+			s.EventError = "no 4624 event in the Security log"
+		default:
+			s.OriginProcessId = ev.ProcessId
+			s.OriginProcessName = unlessDash(ev.ProcessName)
+			s.OutboundUserName = unlessDash(ev.TargetOutboundUserName)
+			s.OutboundDomainName = unlessDash(ev.TargetOutboundDomainName)
+		}
+	}
+
+	conn, connErr := connectKerberos()
+	if connErr == nil {
+		defer conn.Close()
+	}
+	for _, s := range report {
+		if connErr != nil {
+			s.TicketsError = connErr.Error()
+			continue
+		}
+		tickets, err := conn.QueryTicketCache(s.Data.LogonId)
+		if err != nil && !IsFreeBufferError(err) {
+			s.TicketsError = err.Error()
+			continue
+		}
+		seen := map[string]bool{}
+		for _, t := range tickets {
+			client := t.ClientName + "@" + t.ClientRealm
+			if !seen[client] {
+				seen[client] = true
+				s.KerberosClients = append(s.KerberosClients, client)
+			}
+		}
+	}
+	return report, err
+}
@@ -0,0 +1,69 @@
+package winlsa
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cobraqxx/winlsa/securitylog"
+)
+
+// A RemoteOrigin is the client a remote logon came from. The LSA does not
+// record it, so it is taken from the session's 4624 Security log event.
+type RemoteOrigin struct {
+	// Address is the client IP address, if known.
+	Address string `json:",omitempty"`
+	Port    uint16 `json:",omitempty"`
+	// Workstation is the client's NetBIOS name, if known.
+	Workstation string `json:",omitempty"`
+	// LogonGuid links the logon to the Kerberos events on the domain
+	// controller; it is all zeros for other packages.
+	LogonGuid string `json:",omitempty"`
+}
+
+// hasRemoteOrigin reports whether logons of type lt record a client.
+func hasRemoteOrigin(lt LogonType) bool {
+	switch lt {
+	case LogonTypeNetwork, LogonTypeNetworkCleartext, LogonTypeRemoteInteractive, LogonTypeCachedRemoteInteractive:
+		return true
+	}
+	return false
+}
+
+// EnrichRemoteOrigin sets the RemoteOrigin of a network or remote
+// interactive session from its 4624 event. It does nothing for other
+// logon types or if the event is no longer in the Security log. Reading
+// the Security log requires administrator rights.
+func EnrichRemoteOrigin(sd *LogonSessionData) error {
+	return EnrichRemoteOriginContext(context.Background(), sd)
+}
+
+// EnrichRemoteOriginContext is like EnrichRemoteOrigin but stops searching
+// the Security log once ctx is done.
+func EnrichRemoteOriginContext(ctx context.Context, sd *LogonSessionData) error {
+	if !hasRemoteOrigin(sd.LogonType) {
+		return nil
+	}
+	ev, err := securitylog.FindLogonEventContext(ctx, sd.LogonId, sd.LogonTime)
+	if err != nil || ev == nil {
+		return err
+	}
+	origin := &RemoteOrigin{
+		Address:     unlessDash(ev.IpAddress),
+		Workstation: unlessDash(ev.WorkstationName),
+		LogonGuid:   ev.LogonGuid,
+	}
+	if port, err := strconv.ParseUint(ev.IpPort, 10, 16); err == nil {
+		origin.Port = uint16(port)
+	}
+	sd.RemoteOrigin = origin
+	return nil
+}
+
+// unlessDash returns s, or "" if s is the "-" Windows logs for absent
+// values.
+func unlessDash(s string) string {
+	if s == "-" {
+		return ""
+	}
+	return s
+}
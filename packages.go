@@ -0,0 +1,86 @@
+package winlsa
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Names of authentication packages as reported in
+// LogonSessionData.AuthenticationPackage.
+const (
+	AuthenticationPackageNTLM      = "NTLM"
+	AuthenticationPackageKerberos  = "Kerberos"
+	AuthenticationPackageNegotiate = "Negotiate"
+	AuthenticationPackageCloudAP   = "CloudAP"
+	AuthenticationPackagePKU2U     = "pku2u"
+	AuthenticationPackageWDigest   = "WDigest"
+)
+
+func (sd *LogonSessionData) authenticatedBy(pkg string) bool {
+	return strings.EqualFold(sd.AuthenticationPackage, pkg)
+}
+
+// IsKerberos reports whether the session was authenticated by Kerberos.
+func (sd *LogonSessionData) IsKerberos() bool {
+	return sd.authenticatedBy(AuthenticationPackageKerberos)
+}
+
+// IsNTLM reports whether the session was authenticated by NTLM, including
+// Negotiate logons that fell back to NTLM.
+func (sd *LogonSessionData) IsNTLM() bool {
+	return sd.authenticatedBy(AuthenticationPackageNTLM)
+}
+
+// IsCloudAP reports whether the session was authenticated by the cloud
+// authentication provider, i.e. with an Azure AD or Microsoft account.
+func (sd *LogonSessionData) IsCloudAP() bool {
+	return sd.authenticatedBy(AuthenticationPackageCloudAP)
+}
+
+// NTLMUsage summarizes the NTLM logon sessions of one account.
+type NTLMUsage struct {
+	LogonDomain string
+	UserName    string
+	Sessions    []*LogonSessionData
+	LogonTypes  []LogonType
+	// LastLogon is the latest logon time of the sessions.
+	LastLogon time.Time
+}
+
+// NTLMUsageReport lists the accounts with logon sessions authenticated by
+// NTLM, with the most sessions first, to track down remaining NTLM use
+// before disabling it. Like FindLogonSessions, it returns a partial report
+// with a SessionErrors error if some sessions could not be queried.
+func NTLMUsageReport() ([]*NTLMUsage, error) {
+	sessions, err := FindLogonSessions(SessionFilter{AuthenticationPackage: AuthenticationPackageNTLM})
+	if _, partial := err.(SessionErrors); err != nil && !partial {
+		return nil, err
+	}
+	var report []*NTLMUsage
+	byAccount := map[string]*NTLMUsage{}
+	for _, sd := range sessions {
+		key := strings.ToLower(sd.LogonDomain + `\` + sd.UserName)
+		u, ok := byAccount[key]
+		if !ok {
+			u = &NTLMUsage{LogonDomain: sd.LogonDomain, UserName: sd.UserName}
+			byAccount[key] = u
+			report = append(report, u)
+		}
+		u.Sessions = append(u.Sessions, sd)
+		if sd.LogonTime.After(u.LastLogon) {
+			u.LastLogon = sd.LogonTime
+		}
+		seen := false
+		for _, lt := range u.LogonTypes {
+			seen = seen || lt == sd.LogonType
+		}
+		if !seen {
+			u.LogonTypes = append(u.LogonTypes, sd.LogonType)
+		}
+	}
+	sort.SliceStable(report, func(i, j int) bool {
+		return len(report[i].Sessions) > len(report[j].Sessions)
+	})
+	return report, err
+}
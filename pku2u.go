@@ -0,0 +1,82 @@
+package winlsa
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrNotPKU2U is returned for sessions not authenticated by PKU2U.
+var ErrNotPKU2U = errors.New("session was not authenticated by PKU2U")
+
+// IsPKU2U reports whether the session was authenticated by PKU2U, the
+// peer-to-peer certificate protocol Entra ID joined devices use to
+// authenticate each other's users, e.g. for RDP and SMB, without a domain
+// controller.
+func (sd *LogonSessionData) IsPKU2U() bool {
+	return sd.authenticatedBy(AuthenticationPackagePKU2U)
+}
+
+// Identity providers of PKU2U peers, as reported in LogonDomain.
+const (
+	PKU2UProviderAzureAD          = "AzureAD"
+	PKU2UProviderMicrosoftAccount = "MicrosoftAccount"
+)
+
+// PKU2UInfo describes the peer of a PKU2U logon session.
+type PKU2UInfo struct {
+	// PeerIdentity is the account named by the peer's certificate, as
+	// provider\name, e.g. AzureAD\alice@contoso.com.
+	PeerIdentity string
+	// IdentityProvider is the authority that vouched for the peer, e.g.
+	// PKU2UProviderAzureAD for the P2P certificates Entra ID issues to
+	// joined devices. It is the session's LogonDomain.
+	IdentityProvider string
+	// UserName is the peer's user principal name if the LSA reports one,
+	// its user name otherwise.
+	UserName string
+	// Peer is the device the logon came from, taken from the session's
+	// 4624 event. It is nil if the event could not be read.
+	Peer *RemoteOrigin `json:",omitempty"`
+}
+
+// GetPKU2UInfo returns the peer of a session authenticated by PKU2U. The
+// peer device is taken from sd.RemoteOrigin, which is set with
+// EnrichRemoteOrigin if it is nil; if that fails, the info is returned
+// with the error. It returns ErrNotPKU2U for other sessions.
+func GetPKU2UInfo(sd *LogonSessionData) (*PKU2UInfo, error) {
+	return GetPKU2UInfoContext(context.Background(), sd)
+}
+
+// GetPKU2UInfoContext is like GetPKU2UInfo but stops searching the
+// Security log once ctx is done.
+func GetPKU2UInfoContext(ctx context.Context, sd *LogonSessionData) (*PKU2UInfo, error) {
+	if !sd.IsPKU2U() {
+		return nil, ErrNotPKU2U
+	}
+	info := &PKU2UInfo{
+		IdentityProvider: sd.LogonDomain,
+		UserName:         sd.Upn,
+	}
+	if info.UserName == "" {
+		info.UserName = sd.UserName
+	}
+	// Accept an identity given as provider\name in the user name, too.
+	if idx := strings.IndexByte(info.UserName, '\\'); idx >= 0 {
+		if info.IdentityProvider == "" {
+			info.IdentityProvider = info.UserName[:idx]
+		}
+		info.UserName = info.UserName[idx+1:]
+	}
+	info.PeerIdentity = info.UserName
+	if info.IdentityProvider != "" {
+		info.PeerIdentity = info.IdentityProvider + `\` + info.UserName
+	}
+
+	var err error
+	if sd.RemoteOrigin == nil {
+		err = EnrichRemoteOriginContext(ctx, sd)
+	}
+	info.Peer = sd.RemoteOrigin
+	return info, err
+}
@@ -0,0 +1,123 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// AccountAccess is a mask of the rights requested when opening an account
+// object.
+type AccountAccess uint32
+
+const (
+	AccountAccessView               AccountAccess = 0x00000001
+	AccountAccessAdjustPrivileges   AccountAccess = 0x00000002
+	AccountAccessAdjustQuotas       AccountAccess = 0x00000004
+	AccountAccessAdjustSystemAccess AccountAccess = 0x00000008
+	AccountAccessAll                AccountAccess = 0x000F000F
+)
+
+// SystemAccess is the set of logon rights granted or denied to an account.
+type SystemAccess uint32
+
+const (
+	SystemAccessInteractiveLogon           SystemAccess = 0x00000001
+	SystemAccessNetworkLogon               SystemAccess = 0x00000002
+	SystemAccessBatchLogon                 SystemAccess = 0x00000004
+	SystemAccessServiceLogon               SystemAccess = 0x00000010
+	SystemAccessProxyLogon                 SystemAccess = 0x00000020
+	SystemAccessDenyInteractiveLogon       SystemAccess = 0x00000040
+	SystemAccessDenyNetworkLogon           SystemAccess = 0x00000080
+	SystemAccessDenyBatchLogon             SystemAccess = 0x00000100
+	SystemAccessDenyServiceLogon           SystemAccess = 0x00000200
+	SystemAccessRemoteInteractiveLogon     SystemAccess = 0x00000400
+	SystemAccessDenyRemoteInteractiveLogon SystemAccess = 0x00000800
+)
+
+var systemAccessNames = []struct {
+	flag SystemAccess
+	name string
+}{
+	{SystemAccessInteractiveLogon, "InteractiveLogon"},
+	{SystemAccessNetworkLogon, "NetworkLogon"},
+	{SystemAccessBatchLogon, "BatchLogon"},
+	{SystemAccessServiceLogon, "ServiceLogon"},
+	{SystemAccessProxyLogon, "ProxyLogon"},
+	{SystemAccessDenyInteractiveLogon, "DenyInteractiveLogon"},
+	{SystemAccessDenyNetworkLogon, "DenyNetworkLogon"},
+	{SystemAccessDenyBatchLogon, "DenyBatchLogon"},
+	{SystemAccessDenyServiceLogon, "DenyServiceLogon"},
+	{SystemAccessRemoteInteractiveLogon, "RemoteInteractiveLogon"},
+	{SystemAccessDenyRemoteInteractiveLogon, "DenyRemoteInteractiveLogon"},
+}
+
+func (sa SystemAccess) String() string {
+	if sa == 0 {
+		return "None"
+	}
+	var names []string
+	for _, n := range systemAccessNames {
+		if sa&n.flag != 0 {
+			names = append(names, n.name)
+			sa &^= n.flag
+		}
+	}
+	if sa != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint32(sa)))
+	}
+	return strings.Join(names, "|")
+}
+
+// An Account is an open handle to an LSA account object. Like a Policy, it
+// is safe for concurrent use.
+type Account struct {
+	mu     sync.RWMutex
+	handle lsa.LSA_HANDLE
+}
+
+// CreateAccount creates the account object for sid and opens it with the
+// requested access.
+func (p *Policy) CreateAccount(sid *SID, access AccountAccess) (_ *Account, err error) {
+	op := startOp("CreateAccount")
+	defer func() { op.End(err) }()
+	return p.createAccount(sid, access)
+}
+
+// OpenAccount opens the existing account object for sid.
+func (p *Policy) OpenAccount(sid *SID, access AccountAccess) (_ *Account, err error) {
+	op := startOp("OpenAccount")
+	defer func() { op.End(err) }()
+	return p.openAccount(sid, access)
+}
+
+// EnumerateAccounts returns the SIDs of all account objects in the policy
+// database.
+func (p *Policy) EnumerateAccounts() (_ []*SID, err error) {
+	op := startOp("EnumerateAccounts")
+	defer func() { op.End(err) }()
+	return p.enumerateAccounts()
+}
+
+// SystemAccess returns the logon rights of the account.
+func (a *Account) SystemAccess() (SystemAccess, error) {
+	return a.systemAccess()
+}
+
+// SetSystemAccess replaces the logon rights of the account.
+func (a *Account) SetSystemAccess(sa SystemAccess) error {
+	return a.setSystemAccess(sa)
+}
+
+// Delete removes the account object from the policy database and closes the
+// handle.
+func (a *Account) Delete() error {
+	return a.delete()
+}
+
+// Close releases the account handle.
+func (a *Account) Close() error {
+	return a.close()
+}
@@ -0,0 +1,113 @@
+package policy
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (p *Policy) createAccount(sid *windows.SID, access AccountAccess) (*Account, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var handle lsa.LSA_HANDLE
+	err := lsa.LsaCreateAccount(p.handle, sid, uint32(access), &handle)
+	if err != nil {
+		return nil, err
+	}
+	return newAccount(handle), nil
+}
+
+func (p *Policy) openAccount(sid *windows.SID, access AccountAccess) (*Account, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var handle lsa.LSA_HANDLE
+	err := lsa.LsaOpenAccount(p.handle, sid, uint32(access), &handle)
+	if err != nil {
+		return nil, err
+	}
+	return newAccount(handle), nil
+}
+
+func (p *Policy) enumerateAccounts() ([]*windows.SID, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var sids []*windows.SID
+	var enumCtx uint32
+	// freeErr is the last failure to free a buffer whose entries were
+	// copied; it is returned with the complete result.
+	var freeErr error
+	for {
+		var buffer *lsa.LSA_ENUMERATION_INFORMATION
+		var cnt uint32
+		err := lsa.LsaEnumerateAccounts(p.handle, &enumCtx, &buffer, 0x10000, &cnt)
+		if err == windows.ERROR_NO_MORE_ITEMS {
+			return sids, freeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		data := unsafe.Slice(buffer, cnt)
+		for _, entry := range data {
+			sid, err := entry.Sid.Copy()
+			if err != nil {
+				lsa.LsaFreeMemory(uintptr(unsafe.Pointer(buffer)))
+				return nil, err
+			}
+			sids = append(sids, sid)
+		}
+
+		if err := lsa.FreeMemory(uintptr(unsafe.Pointer(buffer))); err != nil {
+			freeErr = err
+		}
+	}
+}
+
+func (a *Account) systemAccess() (SystemAccess, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	var sa uint32
+	err := lsa.LsaGetSystemAccessAccount(a.handle, &sa)
+	if err != nil {
+		return 0, err
+	}
+	return SystemAccess(sa), nil
+}
+
+func (a *Account) setSystemAccess(sa SystemAccess) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return lsa.LsaSetSystemAccessAccount(a.handle, uint32(sa))
+}
+
+func (a *Account) delete() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	err := lsa.LsaDelete(a.handle)
+	if err != nil {
+		return err
+	}
+	a.handle = 0
+	lsa.UntrackLeak(a)
+	return nil
+}
+
+func (a *Account) close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.handle == 0 {
+		return nil
+	}
+	err := lsa.LsaClose(a.handle)
+	a.handle = 0
+	lsa.UntrackLeak(a)
+	return err
+}
+
+func newAccount(handle lsa.LSA_HANDLE) *Account {
+	a := &Account{handle: handle}
+	lsa.TrackLeak(a, "policy.Account")
+	return a
+}
@@ -0,0 +1,30 @@
+package policy
+
+// A CentralAccessPolicy is a Dynamic Access Control policy applied to a
+// system.
+type CentralAccessPolicy struct {
+	ID          *SID
+	Name        string
+	Description string
+	ChangeId    string
+	Flags       uint32
+	// EntryCount is the number of central access rules in the policy.
+	EntryCount uint32
+}
+
+// AppliedCAPIDs returns the IDs of the Central Access Policies applied to
+// systemName. An empty systemName refers to the local system.
+func AppliedCAPIDs(systemName string) (_ []*SID, err error) {
+	op := startOp("AppliedCAPIDs")
+	defer func() { op.End(err) }()
+	return appliedCAPIDs(systemName)
+}
+
+// AppliedCentralAccessPolicies returns the Central Access Policies applied to
+// systemName, resolved to their names and descriptions. IDs that the local
+// system cannot resolve are returned with only ID set.
+func AppliedCentralAccessPolicies(systemName string) (_ []CentralAccessPolicy, err error) {
+	op := startOp("AppliedCentralAccessPolicies")
+	defer func() { op.End(err) }()
+	return appliedCentralAccessPolicies(systemName)
+}
@@ -0,0 +1,73 @@
+package policy
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func appliedCAPIDs(systemName string) ([]*windows.SID, error) {
+	name, err := optionalUnicodeString(systemName)
+	if err != nil {
+		return nil, err
+	}
+	var buffer **windows.SID
+	var cnt uint32
+	err = lsa.LsaGetAppliedCAPIDs(name, &buffer, &cnt)
+	if err != nil {
+		return nil, err
+	}
+	if buffer == nil {
+		return nil, nil
+	}
+
+	data := unsafe.Slice(buffer, cnt)
+	ids := make([]*windows.SID, 0, cnt)
+	for _, sid := range data {
+		id, err := sid.Copy()
+		if err != nil {
+			lsa.LsaFreeMemory(uintptr(unsafe.Pointer(buffer)))
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, lsa.FreeMemory(uintptr(unsafe.Pointer(buffer)))
+}
+
+func appliedCentralAccessPolicies(systemName string) ([]CentralAccessPolicy, error) {
+	ids, err := AppliedCAPIDs(systemName)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	var buffer *lsa.CENTRAL_ACCESS_POLICY
+	var cnt uint32
+	err = lsa.LsaQueryCAPs(&ids[0], uint32(len(ids)), &buffer, &cnt)
+	if err != nil {
+		return nil, err
+	}
+
+	data := unsafe.Slice(buffer, cnt)
+	resolved := make(map[string]lsa.CENTRAL_ACCESS_POLICY, cnt)
+	for _, entry := range data {
+		if entry.CAPID != nil {
+			resolved[entry.CAPID.String()] = entry
+		}
+	}
+	caps := make([]CentralAccessPolicy, 0, len(ids))
+	for _, id := range ids {
+		policy := CentralAccessPolicy{ID: id}
+		if entry, ok := resolved[id.String()]; ok {
+			policy.Name = entry.Name.String()
+			policy.Description = entry.Description.String()
+			policy.ChangeId = entry.ChangeId.String()
+			policy.Flags = entry.Flags
+			policy.EntryCount = entry.CAPECount
+		}
+		caps = append(caps, policy)
+	}
+
+	return caps, lsa.FreeMemory(uintptr(unsafe.Pointer(buffer)))
+}
@@ -0,0 +1,7 @@
+// Package policy wraps the LSA policy object APIs (LsaOpenPolicy and the
+// calls that operate on a policy handle).
+//
+// On platforms other than Windows, Open and the other calls fail with
+// winlsa.ErrUnsupportedPlatform; the types and their encodings remain
+// usable.
+package policy
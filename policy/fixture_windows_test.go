@@ -0,0 +1,112 @@
+package policy
+
+import (
+	"testing"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/fixture"
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func init() {
+	fixture.RegisterDecoder(fixture.KindReferencedDomains, func(p unsafe.Pointer) interface{} {
+		return newReferencedDomains((*lsa.LSA_REFERENCED_DOMAIN_LIST)(p))
+	})
+}
+
+// TestCaptureFixtures writes a ReferencedDomains fixture of the lookup of
+// well-known SIDs and of the local Administrators group to testdata/fixtures
+// when run with -capture.
+func TestCaptureFixtures(t *testing.T) {
+	if !fixture.Capturing() {
+		t.Skip("run with -capture to capture fixtures")
+	}
+	p, err := Open("", AccessLookupNames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	var sids []*windows.SID
+	for _, s := range []string{"S-1-5-18", "S-1-5-32-544", "S-1-1-0"} {
+		sid, err := windows.StringToSid(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sids = append(sids, sid)
+	}
+	var domains *lsa.LSA_REFERENCED_DOMAIN_LIST
+	var names *lsa.LSA_TRANSLATED_NAME
+	err = lsa.LsaLookupSids2(p.handle, 0, uint32(len(sids)), &sids[0], &domains, &names)
+	defer freeLookupBuffers(unsafe.Pointer(domains), unsafe.Pointer(names))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := fixture.Capture(fixture.KindReferencedDomains, unsafe.Pointer(domains))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixture.Write(t, f, "wellknown")
+}
+
+// TestFixtures decodes the ReferencedDomains fixtures of the running
+// architecture and compares them with their goldens.
+func TestFixtures(t *testing.T) {
+	fixture.CheckAll(t, fixture.KindReferencedDomains)
+}
+
+// TestReferencedDomainsRoundTrip captures a referenced domain list buffer,
+// reloads it after the original is cleared, and checks the decoded domains
+// against the values the buffer was built from. The domain array is a
+// pointer inside the buffer that holds the pointers to the names and SIDs.
+func TestReferencedDomainsRoundTrip(t *testing.T) {
+	want := []struct{ name, sid string }{
+		{"NT AUTHORITY", "S-1-5"},
+		{"BUILTIN", "S-1-5-32"},
+		{"CONTOSO", "S-1-5-21-1004336348-1177238915-682003330"},
+		{"", ""},
+	}
+	b := fixture.NewBuffer(unsafe.Sizeof(lsa.LSA_REFERENCED_DOMAIN_LIST{}))
+	l := (*lsa.LSA_REFERENCED_DOMAIN_LIST)(b.Pointer())
+	l.Entries = uint32(len(want))
+	l.Domains = (*lsa.LSA_TRUST_INFORMATION)(b.Alloc(uintptr(len(want)) * unsafe.Sizeof(lsa.LSA_TRUST_INFORMATION{})))
+	entries := unsafe.Slice(l.Domains, len(want))
+	for i, w := range want {
+		if w.name == "" {
+			continue
+		}
+		entries[i].Name = b.String(w.name)
+		sid, err := b.Sid(w.sid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[i].Sid = sid
+	}
+
+	f, err := fixture.Capture(fixture.KindReferencedDomains, b.Pointer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Clear()
+	if err := f.Check(); err != nil {
+		t.Fatal(err)
+	}
+	p, err := f.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := fixture.Decode(fixture.KindReferencedDomains, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := v.([]ReferencedDomain)
+	if len(got) != len(want) {
+		t.Fatalf("decoded %d domains, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if got[i].Name != w.name || lsa.SidString(got[i].Sid) != w.sid {
+			t.Errorf("domain %d decodes to %s %v, want %s %s", i, got[i].Name, got[i].Sid, w.name, w.sid)
+		}
+	}
+}
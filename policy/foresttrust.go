@@ -0,0 +1,143 @@
+package policy
+
+import (
+	"fmt"
+	"time"
+)
+
+type ForestTrustRecordType uint32
+
+const (
+	ForestTrustTopLevelName   ForestTrustRecordType = 0
+	ForestTrustTopLevelNameEx ForestTrustRecordType = 1
+	ForestTrustDomainInfo     ForestTrustRecordType = 2
+)
+
+func (t ForestTrustRecordType) String() string {
+	switch t {
+	case ForestTrustTopLevelName:
+		return "TopLevelName"
+	case ForestTrustTopLevelNameEx:
+		return "TopLevelNameEx"
+	case ForestTrustDomainInfo:
+		return "DomainInfo"
+	default:
+		return fmt.Sprintf("Undefined ForestTrustRecordType(%d)", t)
+	}
+}
+
+// Flags of top level name records.
+const (
+	TLNDisabledNew      = 0x00000001
+	TLNDisabledAdmin    = 0x00000002
+	TLNDisabledConflict = 0x00000004
+)
+
+// Flags of domain info records.
+const (
+	SIDDisabledAdmin    = 0x00000001
+	SIDDisabledConflict = 0x00000002
+	NBDisabledAdmin     = 0x00000004
+	NBDisabledConflict  = 0x00000008
+)
+
+// A ForestTrustRecord is a single entry of a forest trust's name-suffix
+// routing table.
+type ForestTrustRecord struct {
+	Type  ForestTrustRecordType
+	Flags uint32
+	Time  time.Time
+	// TopLevelName is set for ForestTrustTopLevelName records and for
+	// ForestTrustTopLevelNameEx (exclusion) records.
+	TopLevelName string
+	// Sid, DnsName and NetbiosName are set for ForestTrustDomainInfo records.
+	Sid         *SID
+	DnsName     string
+	NetbiosName string
+	// Data holds the raw payload of record types this package does not
+	// know how to decode.
+	Data []byte
+}
+
+// Enabled reports whether none of the record's disable flags are set.
+func (r *ForestTrustRecord) Enabled() bool {
+	return r.Flags == 0
+}
+
+type ForestTrustInformation struct {
+	Records []ForestTrustRecord
+}
+
+// TopLevelNames returns the name suffixes routed to the trusted forest.
+func (fti *ForestTrustInformation) TopLevelNames() []ForestTrustRecord {
+	return fti.filter(ForestTrustTopLevelName)
+}
+
+// Exclusions returns the name suffixes explicitly excluded from routing.
+func (fti *ForestTrustInformation) Exclusions() []ForestTrustRecord {
+	return fti.filter(ForestTrustTopLevelNameEx)
+}
+
+// Domains returns the domains of the trusted forest.
+func (fti *ForestTrustInformation) Domains() []ForestTrustRecord {
+	return fti.filter(ForestTrustDomainInfo)
+}
+
+func (fti *ForestTrustInformation) filter(t ForestTrustRecordType) []ForestTrustRecord {
+	var records []ForestTrustRecord
+	for _, r := range fti.Records {
+		if r.Type == t {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+type ForestTrustCollisionType uint32
+
+const (
+	CollisionTdo ForestTrustCollisionType = iota
+	CollisionXref
+	CollisionOther
+)
+
+func (t ForestTrustCollisionType) String() string {
+	switch t {
+	case CollisionTdo:
+		return "Tdo"
+	case CollisionXref:
+		return "Xref"
+	case CollisionOther:
+		return "Other"
+	default:
+		return fmt.Sprintf("Undefined ForestTrustCollisionType(%d)", t)
+	}
+}
+
+// A ForestTrustCollision reports a record of a proposed forest trust
+// information set that conflicts with existing data.
+type ForestTrustCollision struct {
+	// Index is the position of the conflicting record in the proposed set.
+	Index uint32
+	Type  ForestTrustCollisionType
+	Flags uint32
+	Name  string
+}
+
+// QueryForestTrustInformation retrieves the forest trust information of the
+// trusted domain object named trustedDomainName. It must be called against a
+// domain controller.
+func (p *Policy) QueryForestTrustInformation(trustedDomainName string) (_ *ForestTrustInformation, err error) {
+	op := startOp("QueryForestTrustInformation")
+	defer func() { op.End(err) }()
+	return p.queryForestTrustInformation(trustedDomainName)
+}
+
+// SetForestTrustInformation replaces the forest trust information of the
+// trusted domain object named trustedDomainName. If checkOnly is set, the
+// records are only validated. Any collisions with existing data are returned.
+func (p *Policy) SetForestTrustInformation(trustedDomainName string, fti *ForestTrustInformation, checkOnly bool) (_ []ForestTrustCollision, err error) {
+	op := startOp("SetForestTrustInformation")
+	defer func() { op.End(err) }()
+	return p.setForestTrustInformation(trustedDomainName, fti, checkOnly)
+}
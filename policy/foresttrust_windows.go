@@ -0,0 +1,137 @@
+package policy
+
+import (
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (p *Policy) queryForestTrustInformation(trustedDomainName string) (*ForestTrustInformation, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	name, err := lsa.NewUnicodeString(trustedDomainName)
+	if err != nil {
+		return nil, err
+	}
+	var buffer *lsa.LSA_FOREST_TRUST_INFORMATION
+	err = lsa.LsaQueryForestTrustInformation(p.handle, &name, &buffer)
+	if err != nil {
+		return nil, err
+	}
+	fti := newForestTrustInformation(buffer)
+
+	return fti, lsa.FreeMemory(uintptr(unsafe.Pointer(buffer)))
+}
+
+func (p *Policy) setForestTrustInformation(trustedDomainName string, fti *ForestTrustInformation, checkOnly bool) ([]ForestTrustCollision, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	name, err := lsa.NewUnicodeString(trustedDomainName)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]lsa.LSA_FOREST_TRUST_RECORD, len(fti.Records))
+	entries := make([]*lsa.LSA_FOREST_TRUST_RECORD, len(fti.Records))
+	for idx, r := range fti.Records {
+		err = encodeForestTrustRecord(&records[idx], &r)
+		if err != nil {
+			return nil, err
+		}
+		entries[idx] = &records[idx]
+	}
+	info := lsa.LSA_FOREST_TRUST_INFORMATION{RecordCount: uint32(len(entries))}
+	if len(entries) > 0 {
+		info.Entries = &entries[0]
+	}
+
+	var buffer *lsa.LSA_FOREST_TRUST_COLLISION_INFORMATION
+	err = lsa.LsaSetForestTrustInformation(p.handle, &name, &info, checkOnly, &buffer)
+	if err != nil {
+		return nil, err
+	}
+	if buffer == nil {
+		return nil, nil
+	}
+	collisions := newForestTrustCollisions(buffer)
+
+	return collisions, lsa.FreeMemory(uintptr(unsafe.Pointer(buffer)))
+}
+
+func newForestTrustInformation(info *lsa.LSA_FOREST_TRUST_INFORMATION) *ForestTrustInformation {
+	entries := unsafe.Slice(info.Entries, info.RecordCount)
+
+	fti := &ForestTrustInformation{Records: make([]ForestTrustRecord, 0, len(entries))}
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		record := ForestTrustRecord{
+			Type:  ForestTrustRecordType(entry.ForestTrustType),
+			Flags: entry.Flags,
+			Time:  lsa.TimeFromUint64(entry.Time),
+		}
+		switch record.Type {
+		case ForestTrustTopLevelName, ForestTrustTopLevelNameEx:
+			record.TopLevelName = entry.TopLevelName().String()
+		case ForestTrustDomainInfo:
+			di := entry.DomainInfo()
+			if di.Sid != nil {
+				record.Sid, _ = di.Sid.Copy()
+			}
+			record.DnsName = di.DnsName.String()
+			record.NetbiosName = di.NetbiosName.String()
+		default:
+			data := entry.Data()
+			if data.Buffer != nil && data.Length > 0 {
+				raw := unsafe.Slice(data.Buffer, data.Length)
+				record.Data = append([]byte(nil), raw...)
+			}
+		}
+		fti.Records = append(fti.Records, record)
+	}
+	return fti
+}
+
+func encodeForestTrustRecord(dst *lsa.LSA_FOREST_TRUST_RECORD, r *ForestTrustRecord) error {
+	dst.Flags = r.Flags
+	dst.ForestTrustType = uint32(r.Type)
+	dst.Time = lsa.Uint64FromTime(r.Time)
+	var err error
+	switch r.Type {
+	case ForestTrustTopLevelName, ForestTrustTopLevelNameEx:
+		*dst.TopLevelName(), err = lsa.NewUnicodeString(r.TopLevelName)
+	case ForestTrustDomainInfo:
+		di := dst.DomainInfo()
+		di.Sid = r.Sid
+		di.DnsName, err = lsa.NewUnicodeString(r.DnsName)
+		if err != nil {
+			return err
+		}
+		di.NetbiosName, err = lsa.NewUnicodeString(r.NetbiosName)
+	default:
+		data := dst.Data()
+		data.Length = uint32(len(r.Data))
+		if len(r.Data) > 0 {
+			data.Buffer = &r.Data[0]
+		}
+	}
+	return err
+}
+
+func newForestTrustCollisions(info *lsa.LSA_FOREST_TRUST_COLLISION_INFORMATION) []ForestTrustCollision {
+	entries := unsafe.Slice(info.Entries, info.RecordCount)
+
+	collisions := make([]ForestTrustCollision, 0, len(entries))
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		collisions = append(collisions, ForestTrustCollision{
+			Index: entry.Index,
+			Type:  ForestTrustCollisionType(entry.Type),
+			Flags: entry.Flags,
+			Name:  entry.Name.String(),
+		})
+	}
+	return collisions
+}
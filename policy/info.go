@@ -0,0 +1,152 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A DomainInfo names a domain and its SID. The SID is nil for the primary
+// domain of a workgroup member.
+type DomainInfo struct {
+	Name string
+	Sid  *SID
+}
+
+// DnsDomainInfo describes the Active Directory domain a system belongs to.
+type DnsDomainInfo struct {
+	Name          string
+	DnsDomainName string
+	DnsForestName string
+	DomainGuid    GUID
+	Sid           *SID
+}
+
+// ServerRole is the role of a domain controller's LSA server.
+type ServerRole uint32
+
+const (
+	ServerRoleBackup  ServerRole = 2
+	ServerRolePrimary ServerRole = 3
+)
+
+func (r ServerRole) String() string {
+	switch r {
+	case ServerRoleBackup:
+		return "Backup"
+	case ServerRolePrimary:
+		return "Primary"
+	default:
+		return fmt.Sprintf("ServerRole(%d)", r)
+	}
+}
+
+// MachineAccount identifies the domain account of a domain member.
+type MachineAccount struct {
+	Rid uint32
+	Sid *SID
+}
+
+// AuditEventCategory is a legacy audit event category, as configured by
+// the "Audit Policy" node of the local security policy.
+type AuditEventCategory int
+
+const (
+	AuditCategorySystem AuditEventCategory = iota
+	AuditCategoryLogon
+	AuditCategoryObjectAccess
+	AuditCategoryPrivilegeUse
+	AuditCategoryDetailedTracking
+	AuditCategoryPolicyChange
+	AuditCategoryAccountManagement
+	AuditCategoryDirectoryServiceAccess
+	AuditCategoryAccountLogon
+)
+
+var auditEventCategoryNames = []string{
+	"System",
+	"Logon",
+	"ObjectAccess",
+	"PrivilegeUse",
+	"DetailedTracking",
+	"PolicyChange",
+	"AccountManagement",
+	"DirectoryServiceAccess",
+	"AccountLogon",
+}
+
+func (c AuditEventCategory) String() string {
+	if c >= 0 && int(c) < len(auditEventCategoryNames) {
+		return auditEventCategoryNames[c]
+	}
+	return fmt.Sprintf("AuditEventCategory(%d)", int(c))
+}
+
+// AuditEventOptions selects which events of a category are audited.
+type AuditEventOptions uint32
+
+const (
+	AuditEventSuccess AuditEventOptions = 0x1
+	AuditEventFailure AuditEventOptions = 0x2
+	AuditEventNone    AuditEventOptions = 0x4
+)
+
+func (o AuditEventOptions) String() string {
+	var names []string
+	if o&AuditEventSuccess != 0 {
+		names = append(names, "Success")
+	}
+	if o&AuditEventFailure != 0 {
+		names = append(names, "Failure")
+	}
+	if len(names) == 0 {
+		return "No auditing"
+	}
+	return strings.Join(names, " and ")
+}
+
+// AuditEventsInfo is the legacy audit policy of a system.
+type AuditEventsInfo struct {
+	AuditingMode bool
+	// Options is indexed by AuditEventCategory.
+	Options []AuditEventOptions
+}
+
+// QueryAccountDomain returns the name and SID of the account domain, i.e.
+// the local SAM domain or, on a domain controller, the domain itself. The
+// policy must be opened with AccessViewLocalInformation.
+func (p *Policy) QueryAccountDomain() (*DomainInfo, error) {
+	return p.queryAccountDomain()
+}
+
+// QueryPrimaryDomain returns the name and SID of the domain or workgroup
+// the system is a member of. The policy must be opened with
+// AccessViewLocalInformation.
+func (p *Policy) QueryPrimaryDomain() (*DomainInfo, error) {
+	return p.queryPrimaryDomain()
+}
+
+// QueryDnsDomain returns the DNS names of the domain the system is a
+// member of. The policy must be opened with AccessViewLocalInformation.
+func (p *Policy) QueryDnsDomain() (*DnsDomainInfo, error) {
+	return p.queryDnsDomain()
+}
+
+// QueryServerRole returns the role of the LSA server of a domain
+// controller. The policy must be opened with AccessViewLocalInformation.
+func (p *Policy) QueryServerRole() (ServerRole, error) {
+	return p.queryServerRole()
+}
+
+// QueryMachineAccount returns the domain account of the system; Sid is nil
+// if the system is not joined to a domain. It is supported starting with
+// Windows 10 and Windows Server 2016. The policy must be opened with
+// AccessViewLocalInformation.
+func (p *Policy) QueryMachineAccount() (*MachineAccount, error) {
+	return p.queryMachineAccount()
+}
+
+// QueryAuditEvents returns the legacy audit policy. The policy must be
+// opened with AccessViewAuditInformation.
+func (p *Policy) QueryAuditEvents() (*AuditEventsInfo, error) {
+	return p.queryAuditEvents()
+}
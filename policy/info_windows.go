@@ -0,0 +1,98 @@
+package policy
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (p *Policy) queryAccountDomain() (*DomainInfo, error) {
+	return p.queryDomainInfo(lsa.PolicyAccountDomainInformation)
+}
+
+func (p *Policy) queryPrimaryDomain() (*DomainInfo, error) {
+	return p.queryDomainInfo(lsa.PolicyPrimaryDomainInformation)
+}
+
+func (p *Policy) queryDnsDomain() (*DnsDomainInfo, error) {
+	var info *DnsDomainInfo
+	err := p.queryInformation(lsa.PolicyDnsDomainInformation, func(buffer unsafe.Pointer) {
+		data := (*lsa.POLICY_DNS_DOMAIN_INFO)(buffer)
+		info = &DnsDomainInfo{
+			Name:          data.Name.String(),
+			DnsDomainName: data.DnsDomainName.String(),
+			DnsForestName: data.DnsForestName.String(),
+			DomainGuid:    data.DomainGuid,
+			Sid:           copySid(data.Sid),
+		}
+	})
+	return info, err
+}
+
+func (p *Policy) queryServerRole() (ServerRole, error) {
+	var role ServerRole
+	err := p.queryInformation(lsa.PolicyLsaServerRoleInformation, func(buffer unsafe.Pointer) {
+		role = ServerRole((*lsa.POLICY_LSA_SERVER_ROLE_INFO)(buffer).LsaServerRole)
+	})
+	return role, err
+}
+
+func (p *Policy) queryMachineAccount() (*MachineAccount, error) {
+	var info *MachineAccount
+	err := p.queryInformation(lsa.PolicyMachineAccountInformation, func(buffer unsafe.Pointer) {
+		data := (*lsa.POLICY_MACHINE_ACCT_INFO)(buffer)
+		info = &MachineAccount{Rid: data.Rid, Sid: copySid(data.Sid)}
+	})
+	return info, err
+}
+
+func (p *Policy) queryAuditEvents() (*AuditEventsInfo, error) {
+	var info *AuditEventsInfo
+	err := p.queryInformation(lsa.PolicyAuditEventsInformation, func(buffer unsafe.Pointer) {
+		data := (*lsa.POLICY_AUDIT_EVENTS_INFO)(buffer)
+		options := unsafe.Slice(data.EventAuditingOptions, data.MaximumAuditEventCount)
+		info = &AuditEventsInfo{
+			AuditingMode: data.AuditingMode != 0,
+			Options:      make([]AuditEventOptions, len(options)),
+		}
+		for idx, o := range options {
+			info.Options[idx] = AuditEventOptions(o)
+		}
+	})
+	return info, err
+}
+
+// queryInformation calls LsaQueryInformationPolicy for class and passes
+// the returned buffer to decode before freeing it. decode is only called
+// if the query succeeds, and its results are valid if the free fails with
+// a *FreeBufferError.
+func (p *Policy) queryInformation(class uint32, decode func(buffer unsafe.Pointer)) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var buffer unsafe.Pointer
+	err := lsa.LsaQueryInformationPolicy(p.handle, class, &buffer)
+	if err != nil {
+		return err
+	}
+	decode(buffer)
+	return lsa.FreeMemory(uintptr(buffer))
+}
+
+func (p *Policy) queryDomainInfo(class uint32) (*DomainInfo, error) {
+	var info *DomainInfo
+	err := p.queryInformation(class, func(buffer unsafe.Pointer) {
+		data := (*lsa.POLICY_ACCOUNT_DOMAIN_INFO)(buffer)
+		info = &DomainInfo{Name: data.DomainName.String(), Sid: copySid(data.DomainSid)}
+	})
+	return info, err
+}
+
+func copySid(sid *windows.SID) *windows.SID {
+	if sid == nil {
+		return nil
+	}
+	c, _ := sid.Copy()
+	return c
+}
@@ -0,0 +1,133 @@
+package policy
+
+import (
+	"encoding/json"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (t ForestTrustRecordType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t ForestTrustCollisionType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (sa SystemAccess) MarshalText() ([]byte, error) {
+	return []byte(sa.String()), nil
+}
+
+// MarshalJSON encodes r with the SID in string form.
+func (r ForestTrustRecord) MarshalJSON() ([]byte, error) {
+	type record ForestTrustRecord
+	return json.Marshal(struct {
+		record
+		Sid string `json:",omitempty"`
+	}{record(r), lsa.SidString(r.Sid)})
+}
+
+// MarshalJSON encodes c with the ID in string form.
+func (c CentralAccessPolicy) MarshalJSON() ([]byte, error) {
+	type policy CentralAccessPolicy
+	return json.Marshal(struct {
+		policy
+		ID string
+	}{policy(c), lsa.SidString(c.ID)})
+}
+
+// MarshalJSON encodes d with the SID in string form.
+func (d ReferencedDomain) MarshalJSON() ([]byte, error) {
+	type domain ReferencedDomain
+	return json.Marshal(struct {
+		domain
+		Sid string `json:",omitempty"`
+	}{domain(d), lsa.SidString(d.Sid)})
+}
+
+// MarshalJSON encodes t with the SID in string form.
+func (t TranslatedSid) MarshalJSON() ([]byte, error) {
+	type translated TranslatedSid
+	return json.Marshal(struct {
+		translated
+		Sid string `json:",omitempty"`
+	}{translated(t), lsa.SidString(t.Sid)})
+}
+
+// MarshalJSON encodes t with the SID in string form.
+func (t TranslatedName) MarshalJSON() ([]byte, error) {
+	type translated TranslatedName
+	return json.Marshal(struct {
+		translated
+		Sid string
+	}{translated(t), lsa.SidString(t.Sid)})
+}
+
+func (r ServerRole) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (o AuditEventOptions) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// MarshalJSON encodes d with the SID in string form.
+func (d DomainInfo) MarshalJSON() ([]byte, error) {
+	type info DomainInfo
+	return json.Marshal(struct {
+		info
+		Sid string `json:",omitempty"`
+	}{info(d), lsa.SidString(d.Sid)})
+}
+
+// MarshalJSON encodes d with the GUID and SID in string form.
+func (d DnsDomainInfo) MarshalJSON() ([]byte, error) {
+	type info DnsDomainInfo
+	return json.Marshal(struct {
+		info
+		DomainGuid string
+		Sid        string `json:",omitempty"`
+	}{info(d), d.DomainGuid.String(), lsa.SidString(d.Sid)})
+}
+
+// MarshalJSON encodes m with the SID in string form.
+func (m MachineAccount) MarshalJSON() ([]byte, error) {
+	type account MachineAccount
+	return json.Marshal(struct {
+		account
+		Sid string `json:",omitempty"`
+	}{account(m), lsa.SidString(m.Sid)})
+}
+
+// MarshalJSON encodes a with the options keyed by category name.
+func (a AuditEventsInfo) MarshalJSON() ([]byte, error) {
+	options := make(map[string]AuditEventOptions, len(a.Options))
+	for idx, o := range a.Options {
+		options[AuditEventCategory(idx).String()] = o
+	}
+	return json.Marshal(struct {
+		AuditingMode bool
+		Options      map[string]AuditEventOptions
+	}{a.AuditingMode, options})
+}
+
+func (d TrustDirection) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (t TrustType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (a TrustAttributes) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// MarshalJSON encodes td with the SID in string form.
+func (td TrustedDomain) MarshalJSON() ([]byte, error) {
+	type trustedDomain TrustedDomain
+	return json.Marshal(struct {
+		trustedDomain
+		Sid string `json:",omitempty"`
+	}{trustedDomain(td), lsa.SidString(td.Sid)})
+}
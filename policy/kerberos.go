@@ -0,0 +1,38 @@
+package policy
+
+import "time"
+
+// AuthenticationOptions flags of KerberosTicketInfo.
+const (
+	KerberosValidateClient = 0x00000080
+)
+
+// KerberosTicketInfo is the domain Kerberos ticket policy.
+type KerberosTicketInfo struct {
+	AuthenticationOptions uint32
+	MaxServiceTicketAge   time.Duration
+	MaxTicketAge          time.Duration
+	MaxRenewAge           time.Duration
+	MaxClockSkew          time.Duration
+}
+
+// ValidateClient reports whether the KDC enforces user logon restrictions.
+func (k *KerberosTicketInfo) ValidateClient() bool {
+	return k.AuthenticationOptions&KerberosValidateClient != 0
+}
+
+// QueryKerberosTicketInfo returns the Kerberos ticket policy of the domain.
+// The policy must be opened with AccessViewLocalInformation.
+func (p *Policy) QueryKerberosTicketInfo() (_ *KerberosTicketInfo, err error) {
+	op := startOp("QueryKerberosTicketInfo")
+	defer func() { op.End(err) }()
+	return p.queryKerberosTicketInfo()
+}
+
+// SetKerberosTicketInfo replaces the Kerberos ticket policy of the domain.
+// The policy must be opened with AccessTrustAdmin.
+func (p *Policy) SetKerberosTicketInfo(info *KerberosTicketInfo) (err error) {
+	op := startOp("SetKerberosTicketInfo")
+	defer func() { op.End(err) }()
+	return p.setKerberosTicketInfo(info)
+}
@@ -0,0 +1,54 @@
+package policy
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (p *Policy) queryKerberosTicketInfo() (*KerberosTicketInfo, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var buffer unsafe.Pointer
+	err := lsa.LsaQueryDomainInformationPolicy(p.handle, lsa.PolicyDomainKerberosTicketInformation, &buffer)
+	if err != nil {
+		return nil, err
+	}
+	data := (*lsa.POLICY_DOMAIN_KERBEROS_TICKET_INFO)(buffer)
+	info := &KerberosTicketInfo{
+		AuthenticationOptions: data.AuthenticationOptions,
+		MaxServiceTicketAge:   durationFromInterval(data.MaxServiceTicketAge),
+		MaxTicketAge:          durationFromInterval(data.MaxTicketAge),
+		MaxRenewAge:           durationFromInterval(data.MaxRenewAge),
+		MaxClockSkew:          durationFromInterval(data.MaxClockSkew),
+	}
+
+	return info, lsa.FreeMemory(uintptr(buffer))
+}
+
+func (p *Policy) setKerberosTicketInfo(info *KerberosTicketInfo) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	data := lsa.POLICY_DOMAIN_KERBEROS_TICKET_INFO{
+		AuthenticationOptions: info.AuthenticationOptions,
+		MaxServiceTicketAge:   intervalFromDuration(info.MaxServiceTicketAge),
+		MaxTicketAge:          intervalFromDuration(info.MaxTicketAge),
+		MaxRenewAge:           intervalFromDuration(info.MaxRenewAge),
+		MaxClockSkew:          intervalFromDuration(info.MaxClockSkew),
+	}
+	return lsa.LsaSetDomainInformationPolicy(p.handle, lsa.PolicyDomainKerberosTicketInformation, unsafe.Pointer(&data))
+}
+
+// durationFromInterval converts a LARGE_INTEGER interval in 100ns units.
+// Relative times are sometimes stored negated, so the sign is dropped.
+func durationFromInterval(interval int64) time.Duration {
+	if interval < 0 {
+		interval = -interval
+	}
+	return time.Duration(interval) * 100
+}
+
+func intervalFromDuration(d time.Duration) int64 {
+	return int64(d / 100)
+}
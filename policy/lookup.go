@@ -0,0 +1,74 @@
+package policy
+
+// LookupFlags modify how isolated names are looked up.
+type LookupFlags uint32
+
+const (
+	// LookupIsolatedAsLocal looks up names without a domain part on the
+	// local computer only.
+	LookupIsolatedAsLocal LookupFlags = 0x80000000
+
+	// LookupDisallowConnectedAccountInternetSid returns the local account
+	// name instead of the internet name for SIDs of connected accounts.
+	LookupDisallowConnectedAccountInternetSid LookupFlags = 0x80000000
+	// LookupPreferInternetNames returns the internet name for SIDs of
+	// connected accounts.
+	LookupPreferInternetNames LookupFlags = 0x40000000
+)
+
+// A ReferencedDomain is the domain an entry of a lookup was resolved in.
+type ReferencedDomain struct {
+	Name string
+	Sid  *SID
+}
+
+// A TranslatedSid is the result of looking up a single name.
+type TranslatedSid struct {
+	Name string
+	Use  SidNameUse
+	Sid  *SID
+	// DomainIndex is the index of Domain in the referenced domain list, or
+	// -1 if the name has no domain.
+	DomainIndex int
+	Domain      ReferencedDomain
+	Flags       uint32
+}
+
+// LookupNames resolves names to SIDs in a single call. Names that cannot be
+// resolved are returned with Use set to SidTypeUnknown rather than failing
+// the whole batch. The policy must be opened with AccessLookupNames.
+func (p *Policy) LookupNames(names []string, flags LookupFlags) (_ []TranslatedSid, err error) {
+	op := startOp("LookupNames")
+	defer func() { op.End(err) }()
+	return p.lookupNames(names, flags)
+}
+
+// A TranslatedName is the result of looking up a single SID.
+type TranslatedName struct {
+	Sid  *SID
+	Use  SidNameUse
+	Name string
+	// DomainIndex is the index of Domain in the referenced domain list, or
+	// -1 if the SID has no domain.
+	DomainIndex int
+	Domain      ReferencedDomain
+}
+
+// AccountName returns the name in DOMAIN\name form, or just the name if the
+// SID has no domain.
+func (t *TranslatedName) AccountName() string {
+	if t.Domain.Name == "" {
+		return t.Name
+	}
+	return t.Domain.Name + `\` + t.Name
+}
+
+// LookupSids resolves SIDs to account names in a single call. SIDs that
+// cannot be resolved are returned with Use set to SidTypeUnknown (and Name
+// usually holding the SID string) rather than failing the whole batch. The
+// policy must be opened with AccessLookupNames.
+func (p *Policy) LookupSids(sids []*SID, flags LookupFlags) (_ []TranslatedName, err error) {
+	op := startOp("LookupSids")
+	defer func() { op.End(err) }()
+	return p.lookupSids(sids, flags)
+}
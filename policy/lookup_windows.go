@@ -0,0 +1,119 @@
+package policy
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (p *Policy) lookupNames(names []string, flags LookupFlags) ([]TranslatedSid, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(names) == 0 {
+		return nil, nil
+	}
+	lsaNames, err := unicodeStrings(names)
+	if err != nil {
+		return nil, err
+	}
+
+	var domainsBuffer *lsa.LSA_REFERENCED_DOMAIN_LIST
+	var sidsBuffer *lsa.LSA_TRANSLATED_SID2
+	err = lsa.LsaLookupNames2(p.handle, uint32(flags), uint32(len(names)), &lsaNames[0], &domainsBuffer, &sidsBuffer)
+	defer freeLookupBuffers(unsafe.Pointer(domainsBuffer), unsafe.Pointer(sidsBuffer))
+	if err != nil && err != windows.ERROR_SOME_NOT_MAPPED && err != windows.ERROR_NONE_MAPPED {
+		return nil, err
+	}
+
+	domains := newReferencedDomains(domainsBuffer)
+	results := make([]TranslatedSid, len(names))
+	var data []lsa.LSA_TRANSLATED_SID2
+	if sidsBuffer != nil {
+		data = unsafe.Slice(sidsBuffer, len(names))
+	}
+	for idx, name := range names {
+		results[idx] = TranslatedSid{Name: name, Use: SidTypeUnknown, DomainIndex: -1}
+		if data == nil {
+			continue
+		}
+		entry := data[idx]
+		results[idx].Use = SidNameUse(entry.Use)
+		results[idx].Flags = entry.Flags
+		if entry.Sid != nil {
+			results[idx].Sid, err = entry.Sid.Copy()
+			if err != nil {
+				return nil, err
+			}
+		}
+		if entry.DomainIndex >= 0 && int(entry.DomainIndex) < len(domains) {
+			results[idx].DomainIndex = int(entry.DomainIndex)
+			results[idx].Domain = domains[entry.DomainIndex]
+		}
+	}
+	return results, nil
+}
+
+func (p *Policy) lookupSids(sids []*windows.SID, flags LookupFlags) ([]TranslatedName, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(sids) == 0 {
+		return nil, nil
+	}
+
+	var domainsBuffer *lsa.LSA_REFERENCED_DOMAIN_LIST
+	var namesBuffer *lsa.LSA_TRANSLATED_NAME
+	err := lsa.LsaLookupSids2(p.handle, uint32(flags), uint32(len(sids)), &sids[0], &domainsBuffer, &namesBuffer)
+	defer freeLookupBuffers(unsafe.Pointer(domainsBuffer), unsafe.Pointer(namesBuffer))
+	if err != nil && err != windows.ERROR_SOME_NOT_MAPPED && err != windows.ERROR_NONE_MAPPED {
+		return nil, err
+	}
+
+	domains := newReferencedDomains(domainsBuffer)
+	results := make([]TranslatedName, len(sids))
+	var data []lsa.LSA_TRANSLATED_NAME
+	if namesBuffer != nil {
+		data = unsafe.Slice(namesBuffer, len(sids))
+	}
+	for idx, sid := range sids {
+		results[idx] = TranslatedName{Sid: sid, Use: SidTypeUnknown, DomainIndex: -1}
+		if data == nil {
+			continue
+		}
+		entry := data[idx]
+		results[idx].Use = SidNameUse(entry.Use)
+		results[idx].Name = entry.Name.String()
+		if entry.DomainIndex >= 0 && int(entry.DomainIndex) < len(domains) {
+			results[idx].DomainIndex = int(entry.DomainIndex)
+			results[idx].Domain = domains[entry.DomainIndex]
+		}
+	}
+	return results, nil
+}
+
+func newReferencedDomains(list *lsa.LSA_REFERENCED_DOMAIN_LIST) []ReferencedDomain {
+	if list == nil || list.Domains == nil {
+		return nil
+	}
+	data := unsafe.Slice(list.Domains, list.Entries)
+
+	domains := make([]ReferencedDomain, len(data))
+	for idx, entry := range data {
+		domains[idx].Name = entry.Name.String()
+		if entry.Sid != nil {
+			domains[idx].Sid, _ = entry.Sid.Copy()
+		}
+	}
+	return domains
+}
+
+// freeLookupBuffers releases the buffers returned by a lookup call, which
+// may be allocated even when the call reports that nothing was mapped.
+func freeLookupBuffers(buffers ...unsafe.Pointer) {
+	for _, buffer := range buffers {
+		if buffer != nil {
+			lsa.LsaFreeMemory(uintptr(buffer))
+		}
+	}
+}
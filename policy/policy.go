@@ -0,0 +1,75 @@
+package policy
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// LUID is the same type as winlsa.LUID.
+type LUID = lsa.LUID
+
+// SID is the same type as winlsa.SID.
+type SID = lsa.SID
+
+// GUID is windows.GUID on Windows.
+type GUID = lsa.GUID
+
+// FreeBufferError is the same type as winlsa.FreeBufferError. The results
+// returned with it are valid; use winlsa.IsFreeBufferError to detect it.
+type FreeBufferError = lsa.FreeBufferError
+
+// Access is a mask of the rights requested when opening a policy object.
+type Access uint32
+
+const (
+	AccessViewLocalInformation  Access = 0x00000001
+	AccessViewAuditInformation  Access = 0x00000002
+	AccessGetPrivateInformation Access = 0x00000004
+	AccessTrustAdmin            Access = 0x00000008
+	AccessCreateAccount         Access = 0x00000010
+	AccessCreateSecret          Access = 0x00000020
+	AccessCreatePrivilege       Access = 0x00000040
+	AccessSetDefaultQuotaLimits Access = 0x00000080
+	AccessSetAuditRequirements  Access = 0x00000100
+	AccessAuditLogAdmin         Access = 0x00000200
+	AccessServerAdmin           Access = 0x00000400
+	AccessLookupNames           Access = 0x00000800
+	AccessNotification          Access = 0x00001000
+	AccessAll                   Access = 0x000F0FFF
+	AccessRead                  Access = 0x00020006
+	AccessWrite                 Access = 0x000207F8
+	AccessExecute               Access = 0x00020801
+)
+
+// A Policy is an open handle to the LSA policy object of a system. It is
+// safe for concurrent use; Close waits for the calls in progress, and
+// calls made after Close fail.
+type Policy struct {
+	// mu is held for reading by the methods using handle and for writing
+	// by Close.
+	mu     sync.RWMutex
+	handle lsa.LSA_HANDLE
+}
+
+// Open opens the policy object of systemName with the requested access.
+// An empty systemName refers to the local system.
+func Open(systemName string, access Access) (_ *Policy, err error) {
+	op := startOp("Open")
+	defer func() { op.End(err) }()
+	return open(systemName, access)
+}
+
+// Close releases the policy handle.
+func (p *Policy) Close() error {
+	return p.close()
+}
+
+// startOp starts the operation name of this package, e.g. "LookupNames",
+// for the Instrumentation installed with winlsa.SetInstrumentation. The
+// methods of a Policy take no context.Context, so it has no parent.
+func startOp(name string) lsa.Op {
+	_, op := lsa.StartOp(context.Background(), "policy."+name, nil)
+	return op
+}
@@ -0,0 +1,42 @@
+package policy
+
+import "github.com/cobraqxx/winlsa/internal/lsa"
+
+func open(systemName string, access Access) (*Policy, error) {
+	name, err := optionalUnicodeString(systemName)
+	if err != nil {
+		return nil, err
+	}
+	var attrs lsa.LSA_OBJECT_ATTRIBUTES
+	var handle lsa.LSA_HANDLE
+	err = lsa.LsaOpenPolicy(name, &attrs, uint32(access), &handle)
+	if err != nil {
+		return nil, err
+	}
+	p := &Policy{handle: handle}
+	lsa.TrackLeak(p, "policy.Policy")
+	return p, nil
+}
+
+func (p *Policy) close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.handle == 0 {
+		return nil
+	}
+	err := lsa.LsaClose(p.handle)
+	p.handle = 0
+	lsa.UntrackLeak(p)
+	return err
+}
+
+func optionalUnicodeString(s string) (*lsa.LSA_UNICODE_STRING, error) {
+	if s == "" {
+		return nil, nil
+	}
+	us, err := lsa.NewUnicodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return &us, nil
+}
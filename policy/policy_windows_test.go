@@ -0,0 +1,35 @@
+package policy_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cobraqxx/winlsa/policy"
+)
+
+// TestPolicyConcurrentClose shares a Policy between goroutines looking up
+// privileges and closes it while they do. Lookups made after Close fail,
+// which is expected.
+func TestPolicyConcurrentClose(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		p, err := policy.Open("", policy.AccessLookupNames)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var wg sync.WaitGroup
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for n := 0; n < 8; n++ {
+					p.LookupPrivilegeValue("SeChangeNotifyPrivilege")
+				}
+			}()
+		}
+		time.Sleep(time.Millisecond)
+		p.Close()
+		wg.Wait()
+		p.Close()
+	}
+}
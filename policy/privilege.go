@@ -0,0 +1,47 @@
+package policy
+
+// LookupPrivilegeValue returns the LUID that represents the privilege name
+// (e.g. "SeTcbPrivilege") on the policy's system.
+func (p *Policy) LookupPrivilegeValue(name string) (_ LUID, err error) {
+	op := startOp("LookupPrivilegeValue")
+	defer func() { op.End(err) }()
+	return p.lookupPrivilegeValue(name)
+}
+
+// LookupPrivilegeName returns the programmatic name of the privilege
+// represented by luid.
+func (p *Policy) LookupPrivilegeName(luid LUID) (_ string, err error) {
+	op := startOp("LookupPrivilegeName")
+	defer func() { op.End(err) }()
+	return p.lookupPrivilegeName(luid)
+}
+
+// LookupPrivilegeDisplayName returns the localized description of the
+// privilege name, e.g. "Act as part of the operating system" for
+// "SeTcbPrivilege".
+func (p *Policy) LookupPrivilegeDisplayName(name string) (_ string, err error) {
+	op := startOp("LookupPrivilegeDisplayName")
+	defer func() { op.End(err) }()
+	return p.lookupPrivilegeDisplayName(name)
+}
+
+// A Privilege is a privilege LUID together with its names.
+type Privilege struct {
+	LUID        LUID
+	Name        string
+	DisplayName string
+}
+
+// DescribePrivilege resolves luid, as found in token privilege lists, to its
+// programmatic and display names.
+func (p *Policy) DescribePrivilege(luid LUID) (*Privilege, error) {
+	name, err := p.LookupPrivilegeName(luid)
+	if err != nil {
+		return nil, err
+	}
+	displayName, err := p.LookupPrivilegeDisplayName(name)
+	if err != nil {
+		return nil, err
+	}
+	return &Privilege{LUID: luid, Name: name, DisplayName: displayName}, nil
+}
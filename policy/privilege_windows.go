@@ -0,0 +1,53 @@
+package policy
+
+import (
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (p *Policy) lookupPrivilegeValue(name string) (LUID, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	lsaName, err := lsa.NewUnicodeString(name)
+	if err != nil {
+		return LUID{}, err
+	}
+	var luid LUID
+	err = lsa.LsaLookupPrivilegeValue(p.handle, &lsaName, &luid)
+	if err != nil {
+		return LUID{}, err
+	}
+	return luid, nil
+}
+
+func (p *Policy) lookupPrivilegeName(luid LUID) (string, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var buffer *lsa.LSA_UNICODE_STRING
+	err := lsa.LsaLookupPrivilegeName(p.handle, &luid, &buffer)
+	if err != nil {
+		return "", err
+	}
+	name := buffer.String()
+
+	return name, lsa.FreeMemory(uintptr(unsafe.Pointer(buffer)))
+}
+
+func (p *Policy) lookupPrivilegeDisplayName(name string) (string, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	lsaName, err := lsa.NewUnicodeString(name)
+	if err != nil {
+		return "", err
+	}
+	var buffer *lsa.LSA_UNICODE_STRING
+	var language int16
+	err = lsa.LsaLookupPrivilegeDisplayName(p.handle, &lsaName, &buffer, &language)
+	if err != nil {
+		return "", err
+	}
+	displayName := buffer.String()
+
+	return displayName, lsa.FreeMemory(uintptr(unsafe.Pointer(buffer)))
+}
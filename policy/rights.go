@@ -0,0 +1,41 @@
+package policy
+
+// EnumerateAccountRights returns the privileges and logon rights assigned to
+// the account sid, e.g. "SeServiceLogonRight". An account without any rights
+// yields an empty list.
+func (p *Policy) EnumerateAccountRights(sid *SID) (_ []string, err error) {
+	op := startOp("EnumerateAccountRights")
+	defer func() { op.End(err) }()
+	return p.enumerateAccountRights(sid)
+}
+
+// AddAccountRights assigns rights to the account sid, creating its account
+// object if necessary.
+func (p *Policy) AddAccountRights(sid *SID, rights ...string) (err error) {
+	op := startOp("AddAccountRights")
+	defer func() { op.End(err) }()
+	return p.addAccountRights(sid, rights...)
+}
+
+// RemoveAccountRights removes rights from the account sid.
+func (p *Policy) RemoveAccountRights(sid *SID, rights ...string) (err error) {
+	op := startOp("RemoveAccountRights")
+	defer func() { op.End(err) }()
+	return p.removeAccountRights(sid, rights...)
+}
+
+// RemoveAllAccountRights removes every right from the account sid and
+// deletes its account object.
+func (p *Policy) RemoveAllAccountRights(sid *SID) (err error) {
+	op := startOp("RemoveAllAccountRights")
+	defer func() { op.End(err) }()
+	return p.removeAllAccountRights(sid)
+}
+
+// EnumerateAccountsWithUserRight returns the SIDs of all accounts holding
+// right.
+func (p *Policy) EnumerateAccountsWithUserRight(right string) (_ []*SID, err error) {
+	op := startOp("EnumerateAccountsWithUserRight")
+	defer func() { op.End(err) }()
+	return p.enumerateAccountsWithUserRight(right)
+}
@@ -0,0 +1,106 @@
+package policy
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (p *Policy) enumerateAccountRights(sid *windows.SID) ([]string, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var buffer *lsa.LSA_UNICODE_STRING
+	var cnt uint32
+	err := lsa.LsaEnumerateAccountRights(p.handle, sid, &buffer, &cnt)
+	if err == windows.ERROR_FILE_NOT_FOUND {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data := unsafe.Slice(buffer, cnt)
+	rights := make([]string, len(data))
+	for idx, right := range data {
+		rights[idx] = right.String()
+	}
+
+	return rights, lsa.FreeMemory(uintptr(unsafe.Pointer(buffer)))
+}
+
+func (p *Policy) addAccountRights(sid *windows.SID, rights ...string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(rights) == 0 {
+		return nil
+	}
+	lsaRights, err := unicodeStrings(rights)
+	if err != nil {
+		return err
+	}
+	return lsa.LsaAddAccountRights(p.handle, sid, &lsaRights[0], uint32(len(lsaRights)))
+}
+
+func (p *Policy) removeAccountRights(sid *windows.SID, rights ...string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(rights) == 0 {
+		return nil
+	}
+	lsaRights, err := unicodeStrings(rights)
+	if err != nil {
+		return err
+	}
+	return lsa.LsaRemoveAccountRights(p.handle, sid, false, &lsaRights[0], uint32(len(lsaRights)))
+}
+
+func (p *Policy) removeAllAccountRights(sid *windows.SID) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return lsa.LsaRemoveAccountRights(p.handle, sid, true, nil, 0)
+}
+
+func (p *Policy) enumerateAccountsWithUserRight(right string) ([]*windows.SID, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	lsaRight, err := lsa.NewUnicodeString(right)
+	if err != nil {
+		return nil, err
+	}
+	var buffer *lsa.LSA_ENUMERATION_INFORMATION
+	var cnt uint32
+	err = lsa.LsaEnumerateAccountsWithUserRight(p.handle, &lsaRight, &buffer, &cnt)
+	if err == windows.ERROR_NO_MORE_ITEMS {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data := unsafe.Slice(buffer, cnt)
+	sids := make([]*windows.SID, 0, len(data))
+	for _, entry := range data {
+		sid, err := entry.Sid.Copy()
+		if err != nil {
+			lsa.LsaFreeMemory(uintptr(unsafe.Pointer(buffer)))
+			return nil, err
+		}
+		sids = append(sids, sid)
+	}
+
+	return sids, lsa.FreeMemory(uintptr(unsafe.Pointer(buffer)))
+}
+
+func unicodeStrings(ss []string) ([]lsa.LSA_UNICODE_STRING, error) {
+	lsaStrings := make([]lsa.LSA_UNICODE_STRING, len(ss))
+	for idx, s := range ss {
+		var err error
+		lsaStrings[idx], err = lsa.NewUnicodeString(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return lsaStrings, nil
+}
@@ -0,0 +1,172 @@
+package policy
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// A RightsDocument is a serializable snapshot of every user rights
+// assignment of a system.
+type RightsDocument struct {
+	Rights []RightAssignment `json:"rights"`
+}
+
+// A RightAssignment lists the accounts holding a single right.
+type RightAssignment struct {
+	Right    string        `json:"right"`
+	Accounts []RightHolder `json:"accounts"`
+}
+
+// A RightHolder identifies an account by SID. Name is informational when
+// snapshotting; on restore it is looked up only if Sid is empty, which allows
+// hand-written baselines to refer to accounts by name.
+type RightHolder struct {
+	Sid  string `json:"sid,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// SnapshotRights captures all user rights assignments. The policy must be
+// opened with AccessViewLocalInformation and AccessLookupNames.
+func (p *Policy) SnapshotRights() (*RightsDocument, error) {
+	assignments, err := p.rightsAssignments()
+	if err != nil {
+		return nil, err
+	}
+
+	rights := make([]string, 0, len(assignments))
+	sidSet := map[string]*SID{}
+	for right, sids := range assignments {
+		rights = append(rights, right)
+		for s, sid := range sids {
+			sidSet[s] = sid
+		}
+	}
+	sort.Strings(rights)
+
+	sids := make([]*SID, 0, len(sidSet))
+	for _, sid := range sidSet {
+		sids = append(sids, sid)
+	}
+	names := map[string]string{}
+	translated, err := p.LookupSids(sids, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range translated {
+		if t.Use.Mapped() {
+			names[t.Sid.String()] = t.AccountName()
+		}
+	}
+
+	doc := &RightsDocument{Rights: make([]RightAssignment, len(rights))}
+	for idx, right := range rights {
+		holders := make([]RightHolder, 0, len(assignments[right]))
+		for s := range assignments[right] {
+			holders = append(holders, RightHolder{Sid: s, Name: names[s]})
+		}
+		sort.Slice(holders, func(i, j int) bool { return holders[i].Sid < holders[j].Sid })
+		doc.Rights[idx] = RightAssignment{Right: right, Accounts: holders}
+	}
+	return doc, nil
+}
+
+// RestoreRights makes the user rights assignments of the system match doc:
+// missing assignments are added and assignments not present in doc are
+// removed. The policy must be opened with AccessAll.
+func (p *Policy) RestoreRights(doc *RightsDocument) error {
+	desired := map[string]map[string]*SID{}
+	for _, a := range doc.Rights {
+		holders := desired[a.Right]
+		if holders == nil {
+			holders = map[string]*SID{}
+			desired[a.Right] = holders
+		}
+		for _, h := range a.Accounts {
+			sid, err := p.resolveRightHolder(h)
+			if err != nil {
+				return fmt.Errorf("right %s: %v", a.Right, err)
+			}
+			holders[sid.String()] = sid
+		}
+	}
+	current, err := p.rightsAssignments()
+	if err != nil {
+		return err
+	}
+
+	sids := map[string]*SID{}
+	add := map[string][]string{}
+	remove := map[string][]string{}
+	for right, holders := range desired {
+		for s, sid := range holders {
+			if _, ok := current[right][s]; !ok {
+				sids[s] = sid
+				add[s] = append(add[s], right)
+			}
+		}
+	}
+	for right, holders := range current {
+		for s, sid := range holders {
+			if _, ok := desired[right][s]; !ok {
+				sids[s] = sid
+				remove[s] = append(remove[s], right)
+			}
+		}
+	}
+
+	for s, sid := range sids {
+		err = p.AddAccountRights(sid, add[s]...)
+		if err != nil {
+			return fmt.Errorf("adding rights to %s: %v", s, err)
+		}
+		err = p.RemoveAccountRights(sid, remove[s]...)
+		if err != nil {
+			return fmt.Errorf("removing rights from %s: %v", s, err)
+		}
+	}
+	return nil
+}
+
+// rightsAssignments maps every assigned right to the set of SIDs holding it,
+// keyed by SID string.
+func (p *Policy) rightsAssignments() (map[string]map[string]*SID, error) {
+	accounts, err := p.EnumerateAccounts()
+	if err != nil {
+		return nil, err
+	}
+	assignments := map[string]map[string]*SID{}
+	for _, sid := range accounts {
+		rights, err := p.EnumerateAccountRights(sid)
+		if err != nil {
+			return nil, err
+		}
+		for _, right := range rights {
+			holders := assignments[right]
+			if holders == nil {
+				holders = map[string]*SID{}
+				assignments[right] = holders
+			}
+			holders[sid.String()] = sid
+		}
+	}
+	return assignments, nil
+}
+
+func (p *Policy) resolveRightHolder(h RightHolder) (*SID, error) {
+	if h.Sid != "" {
+		return lsa.StringToSid(h.Sid)
+	}
+	if h.Name == "" {
+		return nil, fmt.Errorf("account without SID or name")
+	}
+	translated, err := p.LookupNames([]string{h.Name}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if !translated[0].Use.Mapped() || translated[0].Sid == nil {
+		return nil, fmt.Errorf("account %q could not be resolved", h.Name)
+	}
+	return translated[0].Sid, nil
+}
@@ -0,0 +1,38 @@
+package policy
+
+// Prefixes of private data key names that control who may read the data.
+const (
+	// SecretPrefixLocal is the prefix of secrets that cannot be read
+	// remotely, e.g. "L$MySecret".
+	SecretPrefixLocal = "L$"
+	// SecretPrefixMachine is the prefix of secrets only the system can
+	// read, e.g. "M$MySecret".
+	SecretPrefixMachine = "M$"
+	// SecretPrefixGlobal is the prefix of secrets replicated to all
+	// domain controllers, e.g. "G$MySecret".
+	SecretPrefixGlobal = "G$"
+)
+
+// StorePrivateData stores data under the key name, replacing any previous
+// value. The policy must be opened with AccessCreateSecret.
+func (p *Policy) StorePrivateData(name string, data []byte) (err error) {
+	op := startOp("StorePrivateData")
+	defer func() { op.End(err) }()
+	return p.storePrivateData(name, data)
+}
+
+// RetrievePrivateData returns the data stored under the key name. The
+// policy must be opened with AccessGetPrivateInformation.
+func (p *Policy) RetrievePrivateData(name string) (_ []byte, err error) {
+	op := startOp("RetrievePrivateData")
+	defer func() { op.End(err) }()
+	return p.retrievePrivateData(name)
+}
+
+// DeletePrivateData removes the key name and its data. The policy must be
+// opened with AccessCreateSecret.
+func (p *Policy) DeletePrivateData(name string) (err error) {
+	op := startOp("DeletePrivateData")
+	defer func() { op.End(err) }()
+	return p.deletePrivateData(name)
+}
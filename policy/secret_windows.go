@@ -0,0 +1,59 @@
+package policy
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (p *Policy) storePrivateData(name string, data []byte) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(data) > 0xffff {
+		return errors.New("private data too long")
+	}
+	lsaName, err := lsa.NewUnicodeString(name)
+	if err != nil {
+		return err
+	}
+	lsaData := lsa.LSA_UNICODE_STRING{Length: uint16(len(data)), MaximumLength: uint16(len(data))}
+	if len(data) > 0 {
+		lsaData.Buffer = (*uint16)(unsafe.Pointer(&data[0]))
+	}
+	return lsa.LsaStorePrivateData(p.handle, &lsaName, &lsaData)
+}
+
+func (p *Policy) retrievePrivateData(name string) ([]byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	lsaName, err := lsa.NewUnicodeString(name)
+	if err != nil {
+		return nil, err
+	}
+	var buffer *lsa.LSA_UNICODE_STRING
+	err = lsa.LsaRetrievePrivateData(p.handle, &lsaName, &buffer)
+	if err != nil {
+		return nil, err
+	}
+	if buffer == nil {
+		return nil, nil
+	}
+	var data []byte
+	if buffer.Buffer != nil && buffer.Length > 0 {
+		data = make([]byte, buffer.Length)
+		copy(data, (*[1 << 16]byte)(unsafe.Pointer(buffer.Buffer))[:buffer.Length:buffer.Length])
+	}
+
+	return data, lsa.FreeMemory(uintptr(unsafe.Pointer(buffer)))
+}
+
+func (p *Policy) deletePrivateData(name string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	lsaName, err := lsa.NewUnicodeString(name)
+	if err != nil {
+		return err
+	}
+	return lsa.LsaStorePrivateData(p.handle, &lsaName, nil)
+}
@@ -0,0 +1,75 @@
+package policy
+
+import "fmt"
+
+// SidNameUse is the type of account a SID refers to.
+type SidNameUse uint32
+
+const (
+	SidTypeUser SidNameUse = iota + 1
+	SidTypeGroup
+	SidTypeDomain
+	SidTypeAlias
+	SidTypeWellKnownGroup
+	SidTypeDeletedAccount
+	SidTypeInvalid
+	SidTypeUnknown
+	SidTypeComputer
+	SidTypeLabel
+	SidTypeLogonSession
+)
+
+func (u SidNameUse) String() string {
+	switch u {
+	case SidTypeUser:
+		return "User"
+	case SidTypeGroup:
+		return "Group"
+	case SidTypeDomain:
+		return "Domain"
+	case SidTypeAlias:
+		return "Alias"
+	case SidTypeWellKnownGroup:
+		return "WellKnownGroup"
+	case SidTypeDeletedAccount:
+		return "DeletedAccount"
+	case SidTypeInvalid:
+		return "Invalid"
+	case SidTypeUnknown:
+		return "Unknown"
+	case SidTypeComputer:
+		return "Computer"
+	case SidTypeLabel:
+		return "Label"
+	case SidTypeLogonSession:
+		return "LogonSession"
+	default:
+		return fmt.Sprintf("Undefined SidNameUse(%d)", u)
+	}
+}
+
+// Mapped reports whether u denotes a successfully translated entry.
+func (u SidNameUse) Mapped() bool {
+	return u != SidTypeUnknown && u != SidTypeInvalid && u != 0
+}
+
+func (u SidNameUse) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText parses the names returned by String.
+func (u *SidNameUse) UnmarshalText(text []byte) error {
+	for use := SidTypeUser; use <= SidTypeLogonSession; use++ {
+		if string(text) == use.String() {
+			*u = use
+			return nil
+		}
+	}
+	var n uint32
+	_, err := fmt.Sscanf(string(text), "Undefined SidNameUse(%d)", &n)
+	if err != nil {
+		return fmt.Errorf("invalid SID name use %q", text)
+	}
+	*u = SidNameUse(n)
+	return nil
+}
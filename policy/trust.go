@@ -0,0 +1,136 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+)
+
+type TrustDirection uint32
+
+const (
+	TrustDirectionDisabled      TrustDirection = 0
+	TrustDirectionInbound       TrustDirection = 1
+	TrustDirectionOutbound      TrustDirection = 2
+	TrustDirectionBidirectional TrustDirection = 3
+)
+
+func (d TrustDirection) String() string {
+	switch d {
+	case TrustDirectionDisabled:
+		return "Disabled"
+	case TrustDirectionInbound:
+		return "Inbound"
+	case TrustDirectionOutbound:
+		return "Outbound"
+	case TrustDirectionBidirectional:
+		return "Bidirectional"
+	default:
+		return fmt.Sprintf("Undefined TrustDirection(%d)", d)
+	}
+}
+
+type TrustType uint32
+
+const (
+	TrustTypeDownlevel TrustType = 1
+	TrustTypeUplevel   TrustType = 2
+	TrustTypeMIT       TrustType = 3
+	TrustTypeDCE       TrustType = 4
+	TrustTypeAAD       TrustType = 5
+)
+
+func (t TrustType) String() string {
+	switch t {
+	case TrustTypeDownlevel:
+		return "Downlevel"
+	case TrustTypeUplevel:
+		return "Uplevel"
+	case TrustTypeMIT:
+		return "MIT"
+	case TrustTypeDCE:
+		return "DCE"
+	case TrustTypeAAD:
+		return "AAD"
+	default:
+		return fmt.Sprintf("Undefined TrustType(%d)", t)
+	}
+}
+
+type TrustAttributes uint32
+
+const (
+	TrustAttributeNonTransitive                        TrustAttributes = 0x00000001
+	TrustAttributeUplevelOnly                          TrustAttributes = 0x00000002
+	TrustAttributeQuarantinedDomain                    TrustAttributes = 0x00000004
+	TrustAttributeForestTransitive                     TrustAttributes = 0x00000008
+	TrustAttributeCrossOrganization                    TrustAttributes = 0x00000010
+	TrustAttributeWithinForest                         TrustAttributes = 0x00000020
+	TrustAttributeTreatAsExternal                      TrustAttributes = 0x00000040
+	TrustAttributeUsesRC4Encryption                    TrustAttributes = 0x00000080
+	TrustAttributeUsesAESKeys                          TrustAttributes = 0x00000100
+	TrustAttributeCrossOrganizationNoTGTDelegation     TrustAttributes = 0x00000200
+	TrustAttributePIMTrust                             TrustAttributes = 0x00000400
+	TrustAttributeCrossOrganizationEnableTGTDelegation TrustAttributes = 0x00000800
+)
+
+var trustAttributeNames = []struct {
+	flag TrustAttributes
+	name string
+}{
+	{TrustAttributeNonTransitive, "NonTransitive"},
+	{TrustAttributeUplevelOnly, "UplevelOnly"},
+	{TrustAttributeQuarantinedDomain, "QuarantinedDomain"},
+	{TrustAttributeForestTransitive, "ForestTransitive"},
+	{TrustAttributeCrossOrganization, "CrossOrganization"},
+	{TrustAttributeWithinForest, "WithinForest"},
+	{TrustAttributeTreatAsExternal, "TreatAsExternal"},
+	{TrustAttributeUsesRC4Encryption, "UsesRC4Encryption"},
+	{TrustAttributeUsesAESKeys, "UsesAESKeys"},
+	{TrustAttributeCrossOrganizationNoTGTDelegation, "CrossOrganizationNoTGTDelegation"},
+	{TrustAttributePIMTrust, "PIMTrust"},
+	{TrustAttributeCrossOrganizationEnableTGTDelegation, "CrossOrganizationEnableTGTDelegation"},
+}
+
+func (a TrustAttributes) String() string {
+	if a == 0 {
+		return "None"
+	}
+	var names []string
+	for _, n := range trustAttributeNames {
+		if a&n.flag != 0 {
+			names = append(names, n.name)
+			a &^= n.flag
+		}
+	}
+	if a != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint32(a)))
+	}
+	return strings.Join(names, "|")
+}
+
+// A TrustedDomain describes a trust relationship of the domain.
+type TrustedDomain struct {
+	Name       string
+	FlatName   string
+	Sid        *SID
+	Direction  TrustDirection
+	Type       TrustType
+	Attributes TrustAttributes
+}
+
+// EnumerateTrustedDomains returns the trust relationships of the domain.
+// The policy must be opened with AccessViewLocalInformation.
+func (p *Policy) EnumerateTrustedDomains() (_ []TrustedDomain, err error) {
+	op := startOp("EnumerateTrustedDomains")
+	defer func() { op.End(err) }()
+	return p.enumerateTrustedDomains()
+}
+
+// QueryTrustedDomain returns the trust relationship with the domain name,
+// which is either its DNS or NetBIOS name. The policy must be opened with
+// AccessViewLocalInformation.
+func (p *Policy) QueryTrustedDomain(name string) (_ *TrustedDomain, err error) {
+	op := startOp("QueryTrustedDomain")
+	defer func() { op.End(err) }()
+	return p.queryTrustedDomain(name)
+}
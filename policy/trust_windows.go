@@ -0,0 +1,85 @@
+package policy
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func (p *Policy) enumerateTrustedDomains() ([]TrustedDomain, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var domains []TrustedDomain
+	var enumCtx uint32
+	// freeErr is the last failure to free a buffer whose entries were
+	// copied; it is returned with the complete result.
+	var freeErr error
+	for {
+		var buffer *lsa.TRUSTED_DOMAIN_INFORMATION_EX
+		var cnt uint32
+		err := lsa.LsaEnumerateTrustedDomainsEx(p.handle, &enumCtx, &buffer, 0x10000, &cnt)
+		if err == windows.ERROR_NO_MORE_ITEMS {
+			return domains, freeErr
+		}
+		// STATUS_MORE_ENTRIES maps to ERROR_MORE_DATA and indicates a
+		// partial result.
+		if err != nil && err != windows.ERROR_MORE_DATA {
+			return nil, err
+		}
+
+		data := unsafe.Slice(buffer, cnt)
+		for idx := range data {
+			td, err := newTrustedDomain(&data[idx])
+			if err != nil {
+				lsa.LsaFreeMemory(uintptr(unsafe.Pointer(buffer)))
+				return nil, err
+			}
+			domains = append(domains, td)
+		}
+
+		if err := lsa.FreeMemory(uintptr(unsafe.Pointer(buffer))); err != nil {
+			freeErr = err
+		}
+	}
+}
+
+func (p *Policy) queryTrustedDomain(name string) (*TrustedDomain, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	lsaName, err := lsa.NewUnicodeString(name)
+	if err != nil {
+		return nil, err
+	}
+	var buffer unsafe.Pointer
+	err = lsa.LsaQueryTrustedDomainInfoByName(p.handle, &lsaName, lsa.TrustedDomainInformationEx, &buffer)
+	if err != nil {
+		return nil, err
+	}
+	td, err := newTrustedDomain((*lsa.TRUSTED_DOMAIN_INFORMATION_EX)(buffer))
+	if err != nil {
+		lsa.LsaFreeMemory(uintptr(buffer))
+		return nil, err
+	}
+
+	return &td, lsa.FreeMemory(uintptr(buffer))
+}
+
+func newTrustedDomain(info *lsa.TRUSTED_DOMAIN_INFORMATION_EX) (TrustedDomain, error) {
+	td := TrustedDomain{
+		Name:       info.Name.String(),
+		FlatName:   info.FlatName.String(),
+		Direction:  TrustDirection(info.TrustDirection),
+		Type:       TrustType(info.TrustType),
+		Attributes: TrustAttributes(info.TrustAttributes),
+	}
+	if info.Sid != nil {
+		var err error
+		td.Sid, err = info.Sid.Copy()
+		if err != nil {
+			return td, err
+		}
+	}
+	return td, nil
+}
@@ -0,0 +1,150 @@
+//go:build !windows
+// +build !windows
+
+package policy
+
+import "github.com/cobraqxx/winlsa/internal/lsa"
+
+func open(systemName string, access Access) (*Policy, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) close() error {
+	return nil
+}
+
+func (p *Policy) createAccount(sid *SID, access AccountAccess) (*Account, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) openAccount(sid *SID, access AccountAccess) (*Account, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) enumerateAccounts() ([]*SID, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (a *Account) systemAccess() (SystemAccess, error) {
+	return 0, lsa.ErrUnsupportedPlatform
+}
+
+func (a *Account) setSystemAccess(sa SystemAccess) error {
+	return lsa.ErrUnsupportedPlatform
+}
+
+func (a *Account) delete() error {
+	return lsa.ErrUnsupportedPlatform
+}
+
+func (a *Account) close() error {
+	return nil
+}
+
+func appliedCAPIDs(systemName string) ([]*SID, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func appliedCentralAccessPolicies(systemName string) ([]CentralAccessPolicy, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) queryForestTrustInformation(trustedDomainName string) (*ForestTrustInformation, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) setForestTrustInformation(trustedDomainName string, fti *ForestTrustInformation, checkOnly bool) ([]ForestTrustCollision, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) queryAccountDomain() (*DomainInfo, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) queryPrimaryDomain() (*DomainInfo, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) queryDnsDomain() (*DnsDomainInfo, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) queryServerRole() (ServerRole, error) {
+	return 0, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) queryMachineAccount() (*MachineAccount, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) queryAuditEvents() (*AuditEventsInfo, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) queryKerberosTicketInfo() (*KerberosTicketInfo, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) setKerberosTicketInfo(info *KerberosTicketInfo) error {
+	return lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) lookupNames(names []string, flags LookupFlags) ([]TranslatedSid, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) lookupSids(sids []*SID, flags LookupFlags) ([]TranslatedName, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) lookupPrivilegeValue(name string) (LUID, error) {
+	return LUID{}, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) lookupPrivilegeName(luid LUID) (string, error) {
+	return "", lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) lookupPrivilegeDisplayName(name string) (string, error) {
+	return "", lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) enumerateAccountRights(sid *SID) ([]string, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) addAccountRights(sid *SID, rights ...string) error {
+	return lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) removeAccountRights(sid *SID, rights ...string) error {
+	return lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) removeAllAccountRights(sid *SID) error {
+	return lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) enumerateAccountsWithUserRight(right string) ([]*SID, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) storePrivateData(name string, data []byte) error {
+	return lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) retrievePrivateData(name string) ([]byte, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) deletePrivateData(name string) error {
+	return lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) enumerateTrustedDomains() ([]TrustedDomain, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (p *Policy) queryTrustedDomain(name string) (*TrustedDomain, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
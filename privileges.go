@@ -0,0 +1,50 @@
+package winlsa
+
+import "strings"
+
+// Names of the privileges the package's operations need.
+const (
+	// PrivilegeTcb is needed for trusted LSA connections, e.g.
+	// kerberos.ConnectTrusted, and impersonation-level S4U tokens.
+	PrivilegeTcb = "SeTcbPrivilege"
+	// PrivilegeSecurity is needed to read the Security log and to query
+	// and set the audit policy.
+	PrivilegeSecurity = "SeSecurityPrivilege"
+	// PrivilegeDebug is needed to open the processes of other users, e.g.
+	// for OpenSessionToken and TerminateSessionProcesses.
+	PrivilegeDebug = "SeDebugPrivilege"
+	// PrivilegeAssignPrimaryToken is needed to start processes with
+	// another user's token.
+	PrivilegeAssignPrimaryToken = "SeAssignPrimaryTokenPrivilege"
+	PrivilegeImpersonate        = "SeImpersonatePrivilege"
+)
+
+// A PrivilegeReport is the result of EnsurePrivileges.
+type PrivilegeReport struct {
+	// Enabled lists the privileges EnsurePrivileges enabled.
+	Enabled []string
+	// AlreadyEnabled lists the privileges that were enabled before.
+	AlreadyEnabled []string
+	// Missing lists the privileges the token does not hold, which
+	// cannot be enabled.
+	Missing []string
+}
+
+// MissingPrivilegesError is returned by EnsurePrivileges if the process
+// token lacks some of the privileges.
+type MissingPrivilegesError struct {
+	Privileges []string
+}
+
+func (e *MissingPrivilegesError) Error() string {
+	return "missing privileges: " + strings.Join(e.Privileges, ", ")
+}
+
+// EnsurePrivileges enables the named privileges on the process token, so
+// that operations needing them fail early with a clear error rather than
+// with an access denied error from LSASS. Privileges the token does not
+// hold are reported in the report's Missing field and with a
+// *MissingPrivilegesError; the other privileges are enabled regardless.
+func EnsurePrivileges(names ...string) (*PrivilegeReport, error) {
+	return ensurePrivileges(names)
+}
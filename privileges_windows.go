@@ -0,0 +1,54 @@
+package winlsa
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+func ensurePrivileges(names []string) (*PrivilegeReport, error) {
+	var token windows.Token
+	err := windows.OpenProcessToken(windows.CurrentProcess(), windows.TOKEN_QUERY|windows.TOKEN_ADJUST_PRIVILEGES, &token)
+	if err != nil {
+		return nil, err
+	}
+	defer token.Close()
+
+	buf, err := tokenInformation(token, windows.TokenPrivileges)
+	if err != nil {
+		return nil, err
+	}
+	held := map[windows.LUID]uint32{}
+	for _, p := range (*windows.Tokenprivileges)(unsafe.Pointer(&buf[0])).AllPrivileges() {
+		held[p.Luid] = p.Attributes
+	}
+
+	r := &PrivilegeReport{}
+	for _, name := range names {
+		var luid windows.LUID
+		err := windows.LookupPrivilegeValue(nil, windows.StringToUTF16Ptr(name), &luid)
+		if err != nil {
+			return nil, fmt.Errorf("privilege %s: %w", name, err)
+		}
+		attrs, ok := held[luid]
+		switch {
+		case !ok:
+			r.Missing = append(r.Missing, name)
+		case attrs&windows.SE_PRIVILEGE_ENABLED != 0:
+			r.AlreadyEnabled = append(r.AlreadyEnabled, name)
+		default:
+			tp := windows.Tokenprivileges{PrivilegeCount: 1}
+			tp.Privileges[0] = windows.LUIDAndAttributes{Luid: luid, Attributes: windows.SE_PRIVILEGE_ENABLED}
+			err := windows.AdjustTokenPrivileges(token, false, &tp, 0, nil, nil)
+			if err != nil {
+				return nil, fmt.Errorf("enabling %s: %w", name, err)
+			}
+			r.Enabled = append(r.Enabled, name)
+		}
+	}
+	if len(r.Missing) > 0 {
+		return r, &MissingPrivilegesError{Privileges: r.Missing}
+	}
+	return r, nil
+}
@@ -0,0 +1,65 @@
+package winlsa
+
+import "context"
+
+// A Provider is the source of the logon sessions that GetLogonSessions,
+// GetLogonSessionData and everything built on them, such as
+// FindLogonSessions, TakeSnapshot and Watch, report. The default is the
+// system's LSA; installing another Provider, e.g. a fakelsa.LSA, lets code
+// consuming sessions be tested without a Windows host.
+type Provider interface {
+	// AppendLogonSessions appends the LUIDs of the current logon sessions
+	// to dst, like the function of the same name.
+	AppendLogonSessions(dst []LUID) ([]LUID, error)
+	// GetLogonSessionData returns the data of the logon session luid with
+	// at least the fields selected by opts, or ErrNoSuchLogonSession if
+	// there is no such session.
+	GetLogonSessionData(luid LUID, opts GetLogonSessionDataOpts) (*LogonSessionData, error)
+}
+
+var provider Provider = systemProvider{}
+
+// SetProvider makes p the source of logon sessions. A nil p restores the
+// system's LSA.
+//
+// SetProvider must not be called concurrently with other functions of
+// this package.
+func SetProvider(p Provider) {
+	if p == nil {
+		p = systemProvider{}
+	}
+	provider = p
+}
+
+// SystemProvider returns the Provider querying the system's LSA, for
+// Providers that wrap it.
+func SystemProvider() Provider {
+	return systemProvider{}
+}
+
+type systemProvider struct{}
+
+func (systemProvider) AppendLogonSessions(dst []LUID) ([]LUID, error) {
+	return appendLogonSessions(context.Background(), dst)
+}
+
+func (systemProvider) GetLogonSessionData(luid LUID, opts GetLogonSessionDataOpts) (*LogonSessionData, error) {
+	return getLogonSessionData(context.Background(), &luid, opts)
+}
+
+// appendSessions and sessionData query the installed provider. The
+// Provider interface takes no context, so ctx only reaches the system's
+// LSA, whose spans it parents.
+func appendSessions(ctx context.Context, dst []LUID) ([]LUID, error) {
+	if _, ok := provider.(systemProvider); ok {
+		return appendLogonSessions(ctx, dst)
+	}
+	return provider.AppendLogonSessions(dst)
+}
+
+func sessionData(ctx context.Context, luid LUID, opts GetLogonSessionDataOpts) (*LogonSessionData, error) {
+	if _, ok := provider.(systemProvider); ok {
+		return getLogonSessionData(ctx, &luid, opts)
+	}
+	return provider.GetLogonSessionData(luid, opts)
+}
@@ -0,0 +1,44 @@
+package winlsa
+
+import "github.com/cobraqxx/winlsa/policy"
+
+// A ResolvedAccount is the account a session's SID resolves to through the
+// LSA lookup service. Unlike UserName and LogonDomain, which the LSA
+// records at logon, it reflects renames and gives canonical names for
+// services and virtual accounts.
+type ResolvedAccount struct {
+	Domain string
+	Name   string
+	Use    policy.SidNameUse
+}
+
+// String returns the account in DOMAIN\name form, or just the name if it
+// has no domain.
+func (a *ResolvedAccount) String() string {
+	if a.Domain == "" {
+		return a.Name
+	}
+	return a.Domain + `\` + a.Name
+}
+
+// ResolveAccounts sets the ResolvedAccount of the sessions with a SID,
+// looking up all SIDs in a single call. SIDs that cannot be resolved leave
+// ResolvedAccount nil.
+func ResolveAccounts(sessions []*LogonSessionData) error {
+	var sids []*SID
+	var targets []*LogonSessionData
+	for _, sd := range sessions {
+		if sd.Sid != nil {
+			sids = append(sids, sd.Sid)
+			targets = append(targets, sd)
+		}
+	}
+	names, err := lookupSids(sids)
+	if err != nil {
+		return err
+	}
+	for idx, sd := range targets {
+		sd.ResolvedAccount = names[idx]
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package winlsa
+
+import (
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/policy"
+)
+
+func lookupSids(sids []*windows.SID) ([]*ResolvedAccount, error) {
+	if len(sids) == 0 {
+		return nil, nil
+	}
+	p, err := policy.Open("", policy.AccessLookupNames)
+	if err != nil {
+		return nil, err
+	}
+	defer p.Close()
+	names, err := p.LookupSids(sids, 0)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]*ResolvedAccount, len(names))
+	for idx, n := range names {
+		if n.Use.Mapped() {
+			accounts[idx] = &ResolvedAccount{Domain: n.Domain.Name, Name: n.Name, Use: n.Use}
+		}
+	}
+	return accounts, nil
+}
@@ -0,0 +1,58 @@
+package winlsa
+
+import (
+	"time"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// NTSTATUS codes of transient LSA failures, for RetryPolicy.Retryable.
+const (
+	StatusInsufficientResources uint32 = 0xC000009A
+	StatusRPCServerUnavailable  uint32 = 0xC0020017
+	StatusRPCServerTooBusy      uint32 = 0xC0020018
+	StatusRPCCallFailed         uint32 = 0xC002001B
+)
+
+// DefaultRetryableStatus are the statuses retried by a RetryPolicy without
+// Retryable: LSASS running out of resources, and the RPC failures it passes
+// on while waiting for a busy or unreachable domain controller.
+var DefaultRetryableStatus = []uint32{
+	StatusInsufficientResources,
+	StatusRPCServerUnavailable,
+	StatusRPCServerTooBusy,
+	StatusRPCCallFailed,
+}
+
+// A RetryPolicy retries the LSA calls that enumerate and query logon
+// sessions and Kerberos ticket caches when they fail transiently. The zero
+// RetryPolicy, the default, makes every call once.
+type RetryPolicy struct {
+	// Attempts is the number of calls made before the error is returned,
+	// including the first; 0 and 1 disable retries.
+	Attempts int
+	// Backoff is the delay before the first retry. It doubles for every
+	// further retry, up to MaxBackoff if that is positive.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Retryable are the NTSTATUS codes to retry; nil selects
+	// DefaultRetryableStatus. The LSA functions report Windows errors, so
+	// a call is retried if it fails with the error a listed status converts
+	// to, which some other statuses may share.
+	Retryable []uint32
+}
+
+// SetRetryPolicy installs p for all subsequent session and ticket cache
+// queries. Retries block the calling goroutine for their backoff; functions
+// taking a context.Context stop waiting when it is done and return its
+// error.
+//
+// SetRetryPolicy may be called while queries are in progress; they keep
+// the policy they started with. p.Retryable is copied.
+func SetRetryPolicy(p RetryPolicy) {
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = DefaultRetryableStatus
+	}
+	lsa.SetRetryPolicy(p.Attempts, p.Backoff, p.MaxBackoff, retryable)
+}
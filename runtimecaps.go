@@ -0,0 +1,50 @@
+package winlsa
+
+// Builds of the Windows versions that introduced the features of
+// RuntimeFeatures.
+const (
+	windowsServer2003Build = 3790
+	windowsVistaBuild      = 6000
+	windows8Build          = 9200
+	windows10Build         = 10240
+)
+
+// RuntimeFeatures reports which version dependent LSA features a Windows
+// build supports, so that callers can skip calls the LSA would reject
+// instead of interpreting the NTSTATUS they fail with.
+type RuntimeFeatures struct {
+	OSBuild uint32
+	// TicketCacheEx3 is KerbQueryTicketCacheEx3Message, which adds the
+	// session key type and cache flags to the ticket cache, from Windows 8
+	// and Server 2012.
+	TicketCacheEx3 bool
+	// CloudAP is the cloud authentication package, which authenticates
+	// Entra ID and Microsoft accounts and answers the CloudAP calls, from
+	// Windows 10.
+	CloudAP bool
+	// LastLogonInfo is the last logon information, logon script, profile
+	// and password times of session data, from Windows Vista, see
+	// LogonSessionData.Unavailable.
+	LastLogonInfo bool
+	// S4U2Self is the S4U logon of the s4u package, from Windows Server
+	// 2003 and Vista.
+	S4U2Self bool
+}
+
+// FeaturesForBuild returns the features of the Windows build, the third
+// part of the version, e.g. 19045.
+func FeaturesForBuild(build uint32) RuntimeFeatures {
+	return RuntimeFeatures{
+		OSBuild:        build,
+		TicketCacheEx3: build >= windows8Build,
+		CloudAP:        build >= windows10Build,
+		LastLogonInfo:  build >= windowsVistaBuild,
+		S4U2Self:       build >= windowsServer2003Build,
+	}
+}
+
+// RuntimeCapabilities returns the features of the running Windows build.
+// On other platforms, all features are unsupported.
+func RuntimeCapabilities() RuntimeFeatures {
+	return FeaturesForBuild(osBuild())
+}
@@ -0,0 +1,38 @@
+// Package s4u obtains access tokens for users without their credentials
+// through Service for User (S4U) logons.
+//
+// Without SeTcbPrivilege, LsaLogonUser returns identification-level tokens,
+// which can be inspected but not used to impersonate or start processes.
+//
+// On platforms other than Windows, logons fail with
+// winlsa.ErrUnsupportedPlatform.
+package s4u
+
+import "github.com/cobraqxx/winlsa/internal/lsa"
+
+// LUID is the same type as winlsa.LUID.
+type LUID = lsa.LUID
+
+// A Result is a successful S4U logon.
+type Result struct {
+	// Token is the access token of the new logon session, a windows.Token.
+	// It must be closed by the caller.
+	Token lsa.Token
+	// LogonId identifies the new logon session.
+	LogonId LUID
+}
+
+// Logon performs an S4U logon of user. Domain accounts are given as a UPN
+// ("alice@contoso.com") or with their domain, and are logged on through
+// Kerberos. Local accounts are given with domain set to "" or "." and are
+// logged on through MSV1_0.
+func Logon(user, domain string) (*Result, error) {
+	return logon(user, domain)
+}
+
+// PrimaryToken duplicates the token of r as a primary token, which is
+// needed to start processes. It requires an impersonation-level token,
+// i.e. a logon performed with SeTcbPrivilege.
+func (r *Result) PrimaryToken() (lsa.Token, error) {
+	return r.primaryToken()
+}
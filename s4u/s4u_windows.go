@@ -0,0 +1,90 @@
+package s4u
+
+import (
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// logonTypeNetwork is the logon type used for S4U logons.
+const logonTypeNetwork = 3
+
+func logon(user, domain string) (*Result, error) {
+	pkgName := "Kerberos"
+	if domain == "." || (domain == "" && !strings.Contains(user, "@")) {
+		pkgName = lsa.MSV1_0_PACKAGE_NAME
+		domain = "."
+		if host, err := computerName(); err == nil {
+			domain = host
+		}
+	}
+
+	var handle lsa.LSA_HANDLE
+	err := lsa.LsaConnectUntrusted(&handle)
+	if err != nil {
+		return nil, err
+	}
+	defer lsa.LsaDeregisterLogonProcess(handle)
+	lsaPkgName, err := lsa.NewString(pkgName)
+	if err != nil {
+		return nil, err
+	}
+	var pkg uint32
+	err = lsa.LsaLookupAuthenticationPackage(handle, &lsaPkgName, &pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	var logon lsa.KERB_S4U_LOGON
+	buf, names, err := lsa.NewRequest(unsafe.Sizeof(logon), user, domain)
+	if err != nil {
+		return nil, err
+	}
+	p := (*lsa.KERB_S4U_LOGON)(unsafe.Pointer(&buf[0]))
+	p.MessageType = lsa.KerbS4ULogon
+	if pkgName == lsa.MSV1_0_PACKAGE_NAME {
+		p.MessageType = lsa.MsV1_0S4ULogon
+	}
+	p.ClientUpn = names[0]
+	p.ClientRealm = names[1]
+
+	origin, err := lsa.NewString("winlsa")
+	if err != nil {
+		return nil, err
+	}
+	var source lsa.TOKEN_SOURCE
+	copy(source.SourceName[:], "winlsa")
+	var profile unsafe.Pointer
+	var profileLen uint32
+	var quotas lsa.QUOTA_LIMITS
+	var subStatus uint32
+	res := &Result{}
+	err = lsa.LsaLogonUser(handle, &origin, logonTypeNetwork, pkg, unsafe.Pointer(&buf[0]), uint32(len(buf)),
+		nil, &source, &profile, &profileLen, &res.LogonId, &res.Token, &quotas, &subStatus)
+	if profile != nil {
+		lsa.LsaFreeReturnBuffer(uintptr(profile))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Result) primaryToken() (windows.Token, error) {
+	var primary windows.Token
+	err := windows.DuplicateTokenEx(r.Token, windows.MAXIMUM_ALLOWED, nil, windows.SecurityImpersonation, windows.TokenPrimary, &primary)
+	return primary, err
+}
+
+func computerName() (string, error) {
+	var buf [windows.MAX_COMPUTERNAME_LENGTH + 1]uint16
+	n := uint32(len(buf))
+	err := windows.GetComputerName(&buf[0], &n)
+	if err != nil {
+		return "", err
+	}
+	return windows.UTF16ToString(buf[:n]), nil
+}
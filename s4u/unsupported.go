@@ -0,0 +1,14 @@
+//go:build !windows
+// +build !windows
+
+package s4u
+
+import "github.com/cobraqxx/winlsa/internal/lsa"
+
+func logon(user, domain string) (*Result, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
+
+func (r *Result) primaryToken() (lsa.Token, error) {
+	return 0, lsa.ErrUnsupportedPlatform
+}
@@ -0,0 +1,94 @@
+package securitylog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// Security log event IDs.
+const (
+	EventLogon = 4624
+)
+
+// A LogonEvent is a 4624 event, written when a logon session is created.
+type LogonEvent struct {
+	Event
+	TargetLogonId             LUID
+	TargetUserSid             string
+	TargetUserName            string
+	TargetDomainName          string
+	LogonType                 uint32
+	LogonProcessName          string
+	AuthenticationPackageName string
+	// WorkstationName is the NetBIOS name of the client, if known.
+	WorkstationName string
+	// LogonGuid correlates the event with Kerberos events on the domain
+	// controller. It is all zeros for non-Kerberos logons.
+	LogonGuid string
+	// IpAddress and IpPort are the client address of network and remote
+	// interactive logons, or "-" if not applicable.
+	IpAddress   string
+	IpPort      string
+	ProcessId   uint32
+	ProcessName string
+	// TargetOutboundUserName and TargetOutboundDomainName are the
+	// credentials a NewCredentials logon uses for network access. They are
+	// "-" for other logon types.
+	TargetOutboundUserName   string
+	TargetOutboundDomainName string
+}
+
+// NewLogonEvent decodes the fields of a 4624 event.
+func NewLogonEvent(ev Event) (*LogonEvent, error) {
+	if ev.ID != EventLogon {
+		return nil, fmt.Errorf("event %d is not a logon event", ev.ID)
+	}
+	le := &LogonEvent{
+		Event:                     ev,
+		TargetUserSid:             ev.Data["TargetUserSid"],
+		TargetUserName:            ev.Data["TargetUserName"],
+		TargetDomainName:          ev.Data["TargetDomainName"],
+		LogonType:                 parseUint(ev.Data["LogonType"]),
+		LogonProcessName:          ev.Data["LogonProcessName"],
+		AuthenticationPackageName: ev.Data["AuthenticationPackageName"],
+		WorkstationName:           ev.Data["WorkstationName"],
+		LogonGuid:                 ev.Data["LogonGuid"],
+		IpAddress:                 ev.Data["IpAddress"],
+		IpPort:                    ev.Data["IpPort"],
+		ProcessId:                 parseUint(ev.Data["ProcessId"]),
+		ProcessName:               ev.Data["ProcessName"],
+		TargetOutboundUserName:    ev.Data["TargetOutboundUserName"],
+		TargetOutboundDomainName:  ev.Data["TargetOutboundDomainName"],
+	}
+	err := le.TargetLogonId.UnmarshalText([]byte(ev.Data["TargetLogonId"]))
+	if err != nil {
+		return nil, err
+	}
+	return le, nil
+}
+
+// FindLogonEvent returns the 4624 event of the logon session luid that
+// logged on at logonTime, or nil if the Security log does not contain it,
+// e.g. because it was overwritten or logon auditing is disabled. Like
+// FindSpecialPrivilegesEvent, it only matches events written within a
+// minute of logonTime, unless logonTime is zero.
+func FindLogonEvent(luid LUID, logonTime time.Time) (*LogonEvent, error) {
+	return FindLogonEventContext(context.Background(), luid, logonTime)
+}
+
+// FindLogonEventContext is like FindLogonEvent but stops searching once
+// ctx is done, see QueryContext.
+func FindLogonEventContext(ctx context.Context, luid LUID, logonTime time.Time) (_ *LogonEvent, err error) {
+	ctx, op := lsa.StartOp(ctx, "securitylog.FindLogonEvent", &luid)
+	defer func() { op.End(err) }()
+
+	events, err := QueryContext(ctx, fmt.Sprintf("*[System[EventID=%d%s] and EventData[Data[@Name='TargetLogonId']=%s]]",
+		EventLogon, timeCondition(logonTime), xpathString(luid.String())), 1)
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+	return NewLogonEvent(events[0])
+}
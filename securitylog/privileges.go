@@ -0,0 +1,71 @@
+package securitylog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// EventSpecialPrivileges is the ID of the 4672 event.
+const EventSpecialPrivileges = 4672
+
+// A SpecialPrivilegesEvent is a 4672 event, written right after the 4624
+// event of a logon whose token holds administrator equivalent privileges,
+// such as SeDebugPrivilege or SeTcbPrivilege.
+type SpecialPrivilegesEvent struct {
+	Event
+	SubjectLogonId    LUID
+	SubjectUserSid    string
+	SubjectUserName   string
+	SubjectDomainName string
+	// Privileges are the names of the special privileges assigned.
+	Privileges []string
+}
+
+// NewSpecialPrivilegesEvent decodes the fields of a 4672 event.
+func NewSpecialPrivilegesEvent(ev Event) (*SpecialPrivilegesEvent, error) {
+	if ev.ID != EventSpecialPrivileges {
+		return nil, fmt.Errorf("event %d is not a special privileges event", ev.ID)
+	}
+	se := &SpecialPrivilegesEvent{
+		Event:             ev,
+		SubjectUserSid:    ev.Data["SubjectUserSid"],
+		SubjectUserName:   ev.Data["SubjectUserName"],
+		SubjectDomainName: ev.Data["SubjectDomainName"],
+		// The list is separated by newlines and tabs.
+		Privileges: strings.Fields(ev.Data["PrivilegeList"]),
+	}
+	err := se.SubjectLogonId.UnmarshalText([]byte(ev.Data["SubjectLogonId"]))
+	if err != nil {
+		return nil, err
+	}
+	return se, nil
+}
+
+// FindSpecialPrivilegesEvent returns the 4672 event of the logon session
+// luid that logged on at logonTime, or nil if the Security log does not
+// contain it. Sessions without special privileges have none. Only events
+// written within a minute of logonTime match, so that the event of an
+// earlier session with the same LUID, from before a reboot, is not
+// returned and the search does not scan the whole log; a zero logonTime
+// lifts the bound.
+func FindSpecialPrivilegesEvent(luid LUID, logonTime time.Time) (*SpecialPrivilegesEvent, error) {
+	return FindSpecialPrivilegesEventContext(context.Background(), luid, logonTime)
+}
+
+// FindSpecialPrivilegesEventContext is like FindSpecialPrivilegesEvent but
+// stops searching once ctx is done, see QueryContext.
+func FindSpecialPrivilegesEventContext(ctx context.Context, luid LUID, logonTime time.Time) (_ *SpecialPrivilegesEvent, err error) {
+	ctx, op := lsa.StartOp(ctx, "securitylog.FindSpecialPrivilegesEvent", &luid)
+	defer func() { op.End(err) }()
+
+	events, err := QueryContext(ctx, fmt.Sprintf("*[System[EventID=%d%s] and EventData[Data[@Name='SubjectLogonId']=%s]]",
+		EventSpecialPrivileges, timeCondition(logonTime), xpathString(luid.String())), 1)
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+	return NewSpecialPrivilegesEvent(events[0])
+}
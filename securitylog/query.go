@@ -0,0 +1,118 @@
+// Package securitylog reads the events the LSA writes to the Security
+// event log, such as 4624 (an account was successfully logged on), to
+// recover details the LSA APIs do not expose. Reading the Security log
+// requires administrator rights or membership in Event Log Readers.
+//
+// On platforms other than Windows, Query fails with
+// winlsa.ErrUnsupportedPlatform.
+package securitylog
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// LUID is the same type as winlsa.LUID.
+type LUID = lsa.LUID
+
+// An Event is a Security log record with its EventData fields.
+type Event struct {
+	ID       uint32
+	RecordID uint64
+	Time     time.Time
+	Computer string
+	// Data maps the names of the EventData fields to their values.
+	Data map[string]string
+}
+
+// eventXML is the part of the XML rendering of an event that is decoded.
+type eventXML struct {
+	System struct {
+		EventID     uint32 `xml:"EventID"`
+		RecordID    uint64 `xml:"EventRecordID"`
+		TimeCreated struct {
+			SystemTime string `xml:"SystemTime,attr"`
+		} `xml:"TimeCreated"`
+		Computer string `xml:"Computer"`
+	} `xml:"System"`
+	Data []struct {
+		Name  string `xml:"Name,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"EventData>Data"`
+}
+
+// Query returns up to max events of the Security log matching the XPath
+// query, newest first. max <= 0 returns all matches.
+func Query(query string, max int) ([]Event, error) {
+	return QueryContext(context.Background(), query, max)
+}
+
+// QueryContext is like Query, but cancels the query and returns ctx.Err()
+// once ctx is done. Queries of a large log that match few events can take
+// a long time.
+func QueryContext(ctx context.Context, query string, max int) (_ []Event, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	_, op := lsa.StartOp(ctx, "securitylog.Query", nil)
+	defer func() { op.End(err) }()
+	return queryEvents(ctx, op, query, max)
+}
+
+// decodeEvent decodes the XML rendering of an event.
+func decodeEvent(text string) (*Event, error) {
+	var x eventXML
+	err := xml.Unmarshal([]byte(text), &x)
+	if err != nil {
+		return nil, err
+	}
+	ev := &Event{
+		ID:       x.System.EventID,
+		RecordID: x.System.RecordID,
+		Computer: x.System.Computer,
+		Data:     make(map[string]string, len(x.Data)),
+	}
+	ev.Time, _ = time.Parse(time.RFC3339Nano, x.System.TimeCreated.SystemTime)
+	for _, d := range x.Data {
+		if d.Name != "" {
+			ev.Data[d.Name] = d.Value
+		}
+	}
+	return ev, nil
+}
+
+// xpathString quotes s for an XPath query. XPath 1.0 has no escapes, so
+// double quotes are dropped from values containing both quote characters.
+func xpathString(s string) string {
+	if !strings.Contains(s, "'") {
+		return "'" + s + "'"
+	}
+	return `"` + strings.ReplaceAll(s, `"`, "") + `"`
+}
+
+// logonEventWindow is how far from a session's LogonTime the events of its
+// logon are looked for. LUIDs are reused after a reboot, so the logon ID
+// alone may match the events of a session of an earlier boot.
+const logonEventWindow = time.Minute
+
+// timeCondition returns an XPath condition for System that matches events
+// written within logonEventWindow of logonTime, or "" if logonTime is zero.
+func timeCondition(logonTime time.Time) string {
+	if logonTime.IsZero() {
+		return ""
+	}
+	const layout = "2006-01-02T15:04:05.000Z"
+	return fmt.Sprintf(" and TimeCreated[@SystemTime>='%s' and @SystemTime<='%s']",
+		logonTime.Add(-logonEventWindow).UTC().Format(layout), logonTime.Add(logonEventWindow).UTC().Format(layout))
+}
+
+func parseUint(s string) uint32 {
+	n, _ := strconv.ParseUint(s, 0, 32)
+	return uint32(n)
+}
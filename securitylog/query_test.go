@@ -0,0 +1,17 @@
+package securitylog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeCondition(t *testing.T) {
+	if got := timeCondition(time.Time{}); got != "" {
+		t.Errorf("timeCondition(zero) = %q, want \"\"", got)
+	}
+	logonTime := time.Date(2024, 3, 1, 12, 30, 15, 250e6, time.FixedZone("CET", 3600))
+	want := " and TimeCreated[@SystemTime>='2024-03-01T11:29:15.250Z' and @SystemTime<='2024-03-01T11:31:15.250Z']"
+	if got := timeCondition(logonTime); got != want {
+		t.Errorf("timeCondition(%v) = %q, want %q", logonTime, got, want)
+	}
+}
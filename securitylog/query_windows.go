@@ -0,0 +1,101 @@
+package securitylog
+
+import (
+	"context"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func queryEvents(ctx context.Context, op lsa.Op, query string, max int) ([]Event, error) {
+	path, err := windows.UTF16PtrFromString("Security")
+	if err != nil {
+		return nil, err
+	}
+	q, err := windows.UTF16PtrFromString(query)
+	if err != nil {
+		return nil, err
+	}
+	call := op.StartCall("EvtQuery")
+	results, err := lsa.EvtQuery(0, path, q, lsa.EvtQueryChannelPath|lsa.EvtQueryReverseDirection)
+	call.End(err)
+	if err != nil {
+		return nil, fmt.Errorf("EvtQuery: %v", err)
+	}
+	defer lsa.EvtClose(results)
+
+	// EvtNext blocks until the log has been searched far enough to fill a
+	// batch; EvtCancel makes it fail with ERROR_CANCELLED.
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	defer func() {
+		close(done)
+		<-exited
+	}()
+	go func() {
+		defer close(exited)
+		select {
+		case <-ctx.Done():
+			lsa.EvtCancel(results)
+		case <-done:
+		}
+	}()
+
+	var events []Event
+	var handles [16]lsa.EVT_HANDLE
+	var buf []byte
+	for max <= 0 || len(events) < max {
+		var n uint32
+		err := lsa.EvtNext(results, uint32(len(handles)), &handles[0], windows.INFINITE, 0, &n)
+		if err == windows.ERROR_NO_MORE_ITEMS {
+			break
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("EvtNext: %v", err)
+		}
+		for _, h := range handles[:n] {
+			if err == nil && (max <= 0 || len(events) < max) {
+				var ev *Event
+				ev, buf, err = renderEvent(h, buf)
+				if err == nil {
+					events = append(events, *ev)
+				}
+			}
+			lsa.EvtClose(h)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// renderEvent decodes the XML rendering of the event h, reusing buf.
+func renderEvent(h lsa.EVT_HANDLE, buf []byte) (*Event, []byte, error) {
+	var used, props uint32
+	for {
+		var p *byte
+		if len(buf) > 0 {
+			p = &buf[0]
+		}
+		err := lsa.EvtRender(0, h, lsa.EvtRenderEventXml, uint32(len(buf)), p, &used, &props)
+		if err == nil {
+			break
+		}
+		if err != windows.ERROR_INSUFFICIENT_BUFFER {
+			return nil, buf, fmt.Errorf("EvtRender: %v", err)
+		}
+		buf = make([]byte, used)
+	}
+	// The rendering is NUL terminated UTF-16.
+	text := lsa.UTF16String((*uint16)(unsafe.Pointer(&buf[0])), int(used)-2)
+
+	ev, err := decodeEvent(text)
+	return ev, buf, err
+}
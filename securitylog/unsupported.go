@@ -0,0 +1,14 @@
+//go:build !windows
+// +build !windows
+
+package securitylog
+
+import (
+	"context"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func queryEvents(ctx context.Context, op lsa.Op, query string, max int) ([]Event, error) {
+	return nil, lsa.ErrUnsupportedPlatform
+}
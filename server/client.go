@@ -0,0 +1,132 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+// A Client queries a broker.
+type Client struct {
+	hc *http.Client
+}
+
+// DialPipe returns a client for the broker listening on the named pipe
+// name. Connections are made on demand.
+func DialPipe(name string) *Client {
+	return &Client{hc: &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialPipe(ctx, name)
+		},
+	}}}
+}
+
+// Sessions returns the data of all logon sessions.
+func (c *Client) Sessions() ([]*winlsa.LogonSessionData, error) {
+	return c.SessionsContext(context.Background())
+}
+
+// SessionsContext is like Sessions with a context for the request.
+func (c *Client) SessionsContext(ctx context.Context) ([]*winlsa.LogonSessionData, error) {
+	var sessions []*winlsa.LogonSessionData
+	err := c.get(ctx, "/v1/sessions", &sessions)
+	return sessions, err
+}
+
+// Session returns the data of the logon session luid.
+func (c *Client) Session(luid winlsa.LUID) (*winlsa.LogonSessionData, error) {
+	return c.SessionContext(context.Background(), luid)
+}
+
+// SessionContext is like Session with a context for the request.
+func (c *Client) SessionContext(ctx context.Context, luid winlsa.LUID) (*winlsa.LogonSessionData, error) {
+	var sd winlsa.LogonSessionData
+	err := c.get(ctx, "/v1/sessions/"+luid.String(), &sd)
+	if err != nil {
+		return nil, err
+	}
+	return &sd, nil
+}
+
+// Tickets lists the ticket cache of the logon session luid, or of every
+// session if luid is nil.
+func (c *Client) Tickets(luid *winlsa.LUID) ([]SessionTickets, error) {
+	return c.TicketsContext(context.Background(), luid)
+}
+
+// TicketsContext is like Tickets with a context for the request.
+func (c *Client) TicketsContext(ctx context.Context, luid *winlsa.LUID) ([]SessionTickets, error) {
+	path := "/v1/tickets"
+	if luid != nil {
+		path += "?luid=" + url.QueryEscape(luid.String())
+	}
+	var tickets []SessionTickets
+	err := c.get(ctx, path, &tickets)
+	return tickets, err
+}
+
+// Watch streams session events to fn until fn returns false, ctx is done
+// or the connection fails. With existing set, the sessions present when
+// the broker starts watching are reported as logon events first.
+func (c *Client) Watch(ctx context.Context, existing bool, fn func(winlsa.SessionEvent) bool) error {
+	path := "/v1/watch"
+	if existing {
+		path += "?existing=1"
+	}
+	resp, err := c.do(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	sc := bufio.NewScanner(resp.Body)
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		var ev winlsa.SessionEvent
+		err := json.Unmarshal(sc.Bytes(), &ev)
+		if err != nil {
+			return err
+		}
+		if !fn(ev) {
+			return nil
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return sc.Err()
+}
+
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	resp, err := c.do(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (c *Client) do(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, "http://winlsa"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		var e ErrorResponse
+		if json.NewDecoder(resp.Body).Decode(&e) != nil || e.Error == "" {
+			e.Error = resp.Status
+		}
+		return nil, fmt.Errorf("broker: %s", e.Error)
+	}
+	return resp, nil
+}
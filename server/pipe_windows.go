@@ -0,0 +1,16 @@
+package server
+
+import (
+	"context"
+	"net"
+
+	"github.com/Microsoft/go-winio"
+)
+
+func listenPipe(name, sddl string) (net.Listener, error) {
+	return winio.ListenPipe(name, &winio.PipeConfig{SecurityDescriptor: sddl})
+}
+
+func dialPipe(ctx context.Context, name string) (net.Conn, error) {
+	return winio.DialPipeContext(ctx, name)
+}
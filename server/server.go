@@ -0,0 +1,220 @@
+// Package server runs a broker that answers session, Kerberos ticket and
+// session event queries for unprivileged processes, so that only the broker
+// needs SeTcbPrivilege. The API is JSON over HTTP, served on a named pipe
+// whose security descriptor decides who may connect.
+//
+//	l, err := server.ListenPipe(server.DefaultPipe, server.DefaultSecurityDescriptor)
+//	...
+//	err = http.Serve(l, server.Handler(server.Options{}))
+//
+// Clients connect with DialPipe.
+//
+// Named pipes only exist on Windows. On other platforms, ListenPipe and
+// the requests of a Client fail with errors wrapping
+// winlsa.ErrUnsupportedPlatform, while Handler serves whatever the winlsa
+// package returns, so that it can be tested with a fake Provider.
+//
+// Endpoints:
+//
+//	GET /v1/sessions             all logon sessions
+//	GET /v1/sessions/<luid>      one logon session
+//	GET /v1/tickets[?luid=<luid>] ticket caches of one or all sessions
+//	GET /v1/watch[?existing=1]   session events as newline-delimited JSON
+package server
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/kerberos"
+)
+
+// DefaultPipe is the pipe name used by "winlsa serve".
+const DefaultPipe = `\\.\pipe\winlsa`
+
+// DefaultSecurityDescriptor grants full access to SYSTEM and
+// Administrators only.
+const DefaultSecurityDescriptor = "D:P(A;;GA;;;SY)(A;;GA;;;BA)"
+
+// UsersSecurityDescriptor also grants read/write access, which is needed to
+// send requests, to authenticated users. The broker then answers every
+// user's queries about every session, so use it only where that is
+// intended.
+const UsersSecurityDescriptor = "D:P(A;;GA;;;SY)(A;;GA;;;BA)(A;;GRGW;;;AU)"
+
+// ListenPipe creates the named pipe name with the security descriptor sddl
+// and listens for connections on it.
+func ListenPipe(name, sddl string) (net.Listener, error) {
+	return listenPipe(name, sddl)
+}
+
+type Options struct {
+	// WatchInterval is the poll interval of /v1/watch streams. It defaults
+	// to one second.
+	WatchInterval time.Duration
+	// KerberosName is the logon process name the broker registers for
+	// ticket queries. It defaults to "winlsa".
+	KerberosName string
+}
+
+// SessionTickets is the ticket cache of one logon session, as returned by
+// /v1/tickets.
+type SessionTickets struct {
+	LogonId winlsa.LUID
+	Tickets []kerberos.TicketCacheInfo
+}
+
+// An ErrorResponse is the body of a failed request.
+type ErrorResponse struct {
+	Error string
+}
+
+type handler struct {
+	opts Options
+}
+
+// Handler returns the HTTP handler serving the broker API. Requests stop
+// querying the LSA when the client goes away.
+func Handler(opts Options) http.Handler {
+	if opts.WatchInterval <= 0 {
+		opts.WatchInterval = time.Second
+	}
+	if opts.KerberosName == "" {
+		opts.KerberosName = "winlsa"
+	}
+	h := &handler{opts: opts}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/sessions", h.sessions)
+	mux.HandleFunc("/v1/sessions/", h.session)
+	mux.HandleFunc("/v1/tickets", h.tickets)
+	mux.HandleFunc("/v1/watch", h.watch)
+	return getOnly(mux)
+}
+
+func getOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *handler) sessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := winlsa.FindLogonSessionsContext(r.Context(), winlsa.SessionFilter{})
+	if r.Context().Err() != nil {
+		return
+	}
+	if _, partial := err.(winlsa.SessionErrors); err != nil && !partial {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if sessions == nil {
+		sessions = []*winlsa.LogonSessionData{}
+	}
+	writeJSON(w, sessions)
+}
+
+func (h *handler) session(w http.ResponseWriter, r *http.Request) {
+	luid, err := winlsa.ParseLUID(strings.TrimPrefix(r.URL.Path, "/v1/sessions/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	sd, err := winlsa.GetLogonSessionData(&luid)
+	if err == winlsa.ErrNoSuchLogonSession {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil && !winlsa.IsFreeBufferError(err) {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, sd)
+}
+
+func (h *handler) tickets(w http.ResponseWriter, r *http.Request) {
+	var luids []winlsa.LUID
+	if s := r.URL.Query().Get("luid"); s != "" {
+		luid, err := winlsa.ParseLUID(s)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		luids = []winlsa.LUID{luid}
+	} else {
+		var err error
+		luids, err = winlsa.GetLogonSessions()
+		if err != nil && !winlsa.IsFreeBufferError(err) {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	conn, err := kerberos.ConnectTrusted(h.opts.KerberosName)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "LsaRegisterLogonProcess: "+err.Error())
+		return
+	}
+	defer conn.Close()
+	result := []SessionTickets{}
+	for _, luid := range luids {
+		if r.Context().Err() != nil {
+			return
+		}
+		tickets, err := conn.QueryTicketCache(luid)
+		if err == winlsa.ErrNoSuchLogonSession && len(luids) > 1 {
+			continue
+		}
+		if err != nil && !winlsa.IsFreeBufferError(err) {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		result = append(result, SessionTickets{LogonId: luid, Tickets: tickets})
+	}
+	writeJSON(w, result)
+}
+
+func (h *handler) watch(w http.ResponseWriter, r *http.Request) {
+	watcher, err := winlsa.WatchContext(r.Context(), winlsa.WatchOptions{
+		Interval: h.opts.WatchInterval,
+		Existing: r.URL.Query().Get("existing") == "1",
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer watcher.Close()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	enc := json.NewEncoder(w)
+	for ev := range watcher.Events() {
+		if enc.Encode(ev) != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: msg})
+}
@@ -0,0 +1,76 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/fakelsa"
+	"github.com/cobraqxx/winlsa/server"
+)
+
+func TestHandler(t *testing.T) {
+	alice := &winlsa.LogonSessionData{
+		LogonId:               winlsa.LUID{LowPart: 0x1234},
+		UserName:              "alice",
+		LogonDomain:           "CONTOSO",
+		AuthenticationPackage: "Kerberos",
+		LogonType:             winlsa.LogonTypeInteractive,
+		LogonTime:             time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	winlsa.SetProvider(fakelsa.New(alice))
+	defer winlsa.SetProvider(nil)
+	srv := httptest.NewServer(server.Handler(server.Options{}))
+	defer srv.Close()
+
+	var sessions []*winlsa.LogonSessionData
+	if status := get(t, srv.URL+"/v1/sessions", &sessions); status != http.StatusOK {
+		t.Fatalf("/v1/sessions: status %d", status)
+	}
+	if len(sessions) != 1 || sessions[0].LogonId != alice.LogonId || sessions[0].UserName != alice.UserName {
+		t.Errorf("/v1/sessions returned %+v, want alice's session", sessions)
+	}
+
+	var sd winlsa.LogonSessionData
+	if status := get(t, srv.URL+"/v1/sessions/0x1234", &sd); status != http.StatusOK {
+		t.Fatalf("/v1/sessions/0x1234: status %d", status)
+	}
+	if sd.UserName != alice.UserName || !sd.LogonTime.Equal(alice.LogonTime) {
+		t.Errorf("/v1/sessions/0x1234 returned %+v, want alice's session", sd)
+	}
+
+	for path, want := range map[string]int{
+		"/v1/sessions/0x5678": http.StatusNotFound,
+		"/v1/sessions/alice":  http.StatusBadRequest,
+	} {
+		var e server.ErrorResponse
+		if status := get(t, srv.URL+path, &e); status != want || e.Error == "" {
+			t.Errorf("%s: status %d, error %q; want status %d with an error", path, status, e.Error, want)
+		}
+	}
+
+	resp, err := http.Post(srv.URL+"/v1/sessions", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST /v1/sessions: status %d, want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
+
+func get(t *testing.T, url string, v interface{}) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("%s: %v", url, err)
+	}
+	return resp.StatusCode
+}
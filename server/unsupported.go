@@ -0,0 +1,19 @@
+//go:build !windows
+// +build !windows
+
+package server
+
+import (
+	"context"
+	"net"
+
+	"github.com/cobraqxx/winlsa"
+)
+
+func listenPipe(name, sddl string) (net.Listener, error) {
+	return nil, winlsa.ErrUnsupportedPlatform
+}
+
+func dialPipe(ctx context.Context, name string) (net.Conn, error) {
+	return nil, winlsa.ErrUnsupportedPlatform
+}
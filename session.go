@@ -0,0 +1,77 @@
+package winlsa
+
+import (
+	"strings"
+	"time"
+)
+
+// PasswordExpiresIn returns the time left until the account's password must
+// be changed, which is negative if it already expired. ok is false if the
+// password does not expire or the LSA did not report when it does.
+func (sd *LogonSessionData) PasswordExpiresIn() (d time.Duration, ok bool) {
+	if sd.PasswordMustChange.IsZero() {
+		return 0, false
+	}
+	return time.Until(sd.PasswordMustChange), true
+}
+
+// SessionsWithPasswordExpiringWithin returns the logon sessions whose
+// account password must be changed within d, including passwords that
+// already expired. Like FindLogonSessions, it returns the sessions it could
+// query with a SessionErrors error if some could not be.
+func SessionsWithPasswordExpiringWithin(d time.Duration) ([]*LogonSessionData, error) {
+	sessions, err := FindLogonSessions(SessionFilter{})
+	if _, partial := err.(SessionErrors); err != nil && !partial {
+		return nil, err
+	}
+	var expiring []*LogonSessionData
+	for _, sd := range sessions {
+		if left, ok := sd.PasswordExpiresIn(); ok && left <= d {
+			expiring = append(expiring, sd)
+		}
+	}
+	return expiring, err
+}
+
+// Age returns the time since the session logged on, or 0 if the LSA did
+// not report a logon time.
+func (sd *LogonSessionData) Age() time.Duration {
+	if sd.LogonTime.IsZero() {
+		return 0
+	}
+	return time.Since(sd.LogonTime)
+}
+
+// IsLoggedOff reports whether the session's LogoffTime, the time at which
+// it is forced to log off, has passed. Such sessions linger only until
+// their last token is closed.
+func (sd *LogonSessionData) IsLoggedOff() bool {
+	return !sd.LogoffTime.IsZero() && !sd.LogoffTime.After(time.Now())
+}
+
+// ByLogonTime sorts sessions by logon time, oldest first.
+type ByLogonTime []*LogonSessionData
+
+func (s ByLogonTime) Len() int           { return len(s) }
+func (s ByLogonTime) Less(i, j int) bool { return s[i].LogonTime.Before(s[j].LogonTime) }
+func (s ByLogonTime) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// ByUser sorts sessions by DOMAIN\user, compared case-insensitively.
+type ByUser []*LogonSessionData
+
+func (s ByUser) Len() int { return len(s) }
+func (s ByUser) Less(i, j int) bool {
+	di, dj := strings.ToLower(s[i].LogonDomain), strings.ToLower(s[j].LogonDomain)
+	if di != dj {
+		return di < dj
+	}
+	return strings.ToLower(s[i].UserName) < strings.ToLower(s[j].UserName)
+}
+func (s ByUser) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// ByType sorts sessions by logon type.
+type ByType []*LogonSessionData
+
+func (s ByType) Len() int           { return len(s) }
+func (s ByType) Less(i, j int) bool { return s[i].LogonType < s[j].LogonType }
+func (s ByType) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
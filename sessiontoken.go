@@ -0,0 +1,143 @@
+package winlsa
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// ErrNoSessionToken is returned for logon sessions without a process whose
+// token could be opened. Network logons usually have none.
+var ErrNoSessionToken = errors.New("no accessible process runs in the logon session")
+
+// OpenSessionToken returns the primary token of a process running in the
+// logon session luid, opened with TOKEN_QUERY access. Processes of other
+// users can only be opened with SeDebugPrivilege enabled; inaccessible
+// processes are skipped. The caller must close the token.
+func OpenSessionToken(luid LUID) (Token, error) {
+	return openSessionToken(luid, lsa.TOKEN_QUERY)
+}
+
+// GetSessionTokenInfo reads the security context of the logon session luid
+// from the token of one of its processes, see OpenSessionToken.
+func GetSessionTokenInfo(luid LUID) (*TokenInfo, error) {
+	token, err := OpenSessionToken(luid)
+	if err != nil {
+		return nil, err
+	}
+	defer token.Close()
+	return GetTokenInfo(token)
+}
+
+// GroupsForSession returns the groups of the logon session luid, with
+// their attributes, as found in the token of one of its processes.
+func GroupsForSession(luid LUID) ([]Group, error) {
+	info, err := GetSessionTokenInfo(luid)
+	if err != nil {
+		return nil, err
+	}
+	return info.Groups, nil
+}
+
+// PrivilegesForSession returns the privileges of the logon session luid as
+// found in the token of one of its processes. Processes may have removed
+// or enabled privileges, so different processes of a session can report
+// different attributes.
+func PrivilegesForSession(luid LUID) ([]Privilege, error) {
+	info, err := GetSessionTokenInfo(luid)
+	if err != nil {
+		return nil, err
+	}
+	return info.Privileges, nil
+}
+
+// IntegrityForSession returns the integrity level of the logon session
+// luid as found in the token of one of its processes. Tokens of a session
+// usually share its integrity level, but a process can lower its own.
+func IntegrityForSession(luid LUID) (IntegrityLevel, error) {
+	token, err := OpenSessionToken(luid)
+	if err != nil {
+		return 0, err
+	}
+	defer token.Close()
+	return tokenIntegrityLevel(token)
+}
+
+// SessionOrigin returns the logon session that created the logon session
+// luid, as recorded in the TokenOrigin of one of its processes' tokens;
+// see OpenSessionToken. It is zero for sessions the system created
+// without an originating session.
+func SessionOrigin(luid LUID) (LUID, error) {
+	token, err := OpenSessionToken(luid)
+	if err != nil {
+		return LUID{}, err
+	}
+	defer token.Close()
+	return tokenOrigin(token)
+}
+
+// SessionParentage maps logon sessions to the sessions that created them,
+// e.g. a NewCredentials session to the interactive session runas /netonly
+// ran in, or an interactive session to the SYSTEM session of winlogon.
+type SessionParentage map[LUID]LUID
+
+// GetSessionParentage reads the TokenOrigin of the first accessible process
+// token of every logon session, in a single pass over the processes. Sessions without such a process, e.g.
+// most network logons, and sessions without an originating session are
+// left out.
+func GetSessionParentage() (SessionParentage, error) {
+	procs, err := processList()
+	if err != nil {
+		return nil, err
+	}
+	parentage := SessionParentage{}
+	seen := map[LUID]bool{}
+	for _, p := range procs {
+		if !p.attributed || seen[p.luid] {
+			continue
+		}
+		seen[p.luid] = true
+		if p.origin != (LUID{}) && p.origin != p.luid {
+			parentage[p.luid] = p.origin
+		}
+	}
+	return parentage, nil
+}
+
+// Parent returns the session that created luid.
+func (p SessionParentage) Parent(luid LUID) (LUID, bool) {
+	parent, ok := p[luid]
+	return parent, ok
+}
+
+// Children returns the sessions created by luid, ordered by LUID.
+func (p SessionParentage) Children(luid LUID) []LUID {
+	var children []LUID
+	for child, parent := range p {
+		if parent == luid {
+			children = append(children, child)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return luidValue(children[i]) < luidValue(children[j])
+	})
+	return children
+}
+
+// Roots returns the sessions that created others but have no known
+// creator themselves, ordered by LUID.
+func (p SessionParentage) Roots() []LUID {
+	seen := map[LUID]bool{}
+	var roots []LUID
+	for _, parent := range p {
+		if _, ok := p[parent]; !ok && !seen[parent] {
+			seen[parent] = true
+			roots = append(roots, parent)
+		}
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		return luidValue(roots[i]) < luidValue(roots[j])
+	})
+	return roots
+}
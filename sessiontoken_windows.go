@@ -0,0 +1,53 @@
+package winlsa
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func openSessionToken(luid LUID, access uint32) (windows.Token, error) {
+	snap, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer windows.CloseHandle(snap)
+
+	entry := windows.ProcessEntry32{Size: uint32(unsafe.Sizeof(windows.ProcessEntry32{}))}
+	for err = windows.Process32First(snap, &entry); err == nil; err = windows.Process32Next(snap, &entry) {
+		if entry.ProcessID == 0 {
+			continue
+		}
+		token, ok := openProcessTokenIn(entry.ProcessID, luid, access)
+		if ok {
+			return token, nil
+		}
+	}
+	if err != windows.ERROR_NO_MORE_FILES {
+		return 0, err
+	}
+	return 0, ErrNoSessionToken
+}
+
+// openProcessTokenIn opens the token of process pid with access, which
+// must include TOKEN_QUERY, if it belongs to the logon session luid.
+func openProcessTokenIn(pid uint32, luid LUID, access uint32) (windows.Token, bool) {
+	process, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return 0, false
+	}
+	defer windows.CloseHandle(process)
+	var token windows.Token
+	err = windows.OpenProcessToken(process, access, &token)
+	if err != nil {
+		return 0, false
+	}
+	buf, err := tokenInformation(token, windows.TokenStatistics)
+	if err != nil || (*lsa.TOKEN_STATISTICS)(unsafe.Pointer(&buf[0])).AuthenticationId != luid {
+		token.Close()
+		return 0, false
+	}
+	return token, true
+}
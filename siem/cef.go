@@ -0,0 +1,110 @@
+package siem
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// CEF device fields.
+const (
+	CEFVendor  = "cobraqxx"
+	CEFProduct = "winlsa"
+	CEFVersion = "1"
+)
+
+// CEF signature IDs.
+const (
+	CEFSignatureLogon   = "logon"
+	CEFSignatureLogoff  = "logoff"
+	CEFSignatureSession = "session"
+
+	CEFSignatureExplicitCredentials = "explicit-credentials"
+)
+
+// CEF formats a watcher event as a CEF record, without a syslog header.
+func CEF(ev winlsa.SessionEvent) string {
+	sig, name := CEFSignatureLogon, "Logon session started"
+	switch ev.Type {
+	case winlsa.SessionLogoff:
+		sig, name = CEFSignatureLogoff, "Logon session ended"
+	case winlsa.SessionExplicitCredentials:
+		sig, name = CEFSignatureExplicitCredentials, "Explicit credentials used"
+	}
+	ms := ev.Time.UnixNano() / 1e6
+	return cefRecord(sig, name, ms, ev.LogonId, ev.Data, ev.ExplicitCredentials)
+}
+
+// CEFSession formats session data as a CEF record timestamped with the
+// session's logon time.
+func CEFSession(sd *winlsa.LogonSessionData) string {
+	var ms int64
+	if !sd.LogonTime.IsZero() {
+		ms = sd.LogonTime.UnixNano() / 1e6
+	}
+	return cefRecord(CEFSignatureSession, "Logon session", ms, sd.LogonId, sd, nil)
+}
+
+func cefRecord(sig, name string, ms int64, luid winlsa.LUID, sd *winlsa.LogonSessionData, ec *winlsa.ExplicitCredentials) string {
+	var b strings.Builder
+	b.WriteString("CEF:0")
+	for _, f := range []string{CEFVendor, CEFProduct, CEFVersion, sig, name, "3"} {
+		b.WriteByte('|')
+		b.WriteString(cefHeaderEscaper.Replace(f))
+	}
+	b.WriteByte('|')
+
+	ext := []string{"cat", "authentication"}
+	if ms != 0 {
+		ext = append(ext, "rt", strconv.FormatInt(ms, 10))
+	}
+	if h := hostname(); h != "" {
+		ext = append(ext, "dvchost", h)
+	}
+	ext = append(ext, "cs1Label", "LogonId", "cs1", luid.String())
+	if sd != nil {
+		ext = append(ext,
+			"suser", sd.UserName,
+			"sntdom", sd.LogonDomain,
+			"suid", lsa.SidString(sd.Sid),
+			"cs2Label", "LogonType", "cs2", sd.LogonType.String(),
+			"cs3Label", "AuthenticationPackage", "cs3", sd.AuthenticationPackage,
+			"cn1Label", "Session", "cn1", strconv.FormatUint(uint64(sd.Session), 10),
+		)
+		if o := sd.RemoteOrigin; o != nil {
+			ext = append(ext, "src", o.Address, "shost", o.Workstation)
+			if o.Port != 0 {
+				ext = append(ext, "spt", strconv.Itoa(int(o.Port)))
+			}
+		}
+	}
+	if ec != nil {
+		ext = append(ext,
+			"duser", ec.TargetUserName,
+			"dntdom", ec.TargetDomainName,
+			"dhost", ec.TargetServerName,
+			"dst", ec.IpAddress,
+			"sproc", ec.ProcessName,
+			"spid", strconv.FormatUint(uint64(ec.ProcessId), 10),
+		)
+	}
+	sep := ""
+	for idx := 0; idx < len(ext); idx += 2 {
+		if ext[idx+1] == "" {
+			continue
+		}
+		b.WriteString(sep)
+		b.WriteString(ext[idx])
+		b.WriteByte('=')
+		b.WriteString(cefExtensionEscaper.Replace(ext[idx+1]))
+		sep = " "
+	}
+	return b.String()
+}
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r", " ", "\n", " ")
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r", `\r`, "\n", `\n`)
+)
@@ -0,0 +1,182 @@
+// Package siem maps session events and session data to formats that SIEMs
+// ingest without a custom pipeline: Elastic Common Schema documents and
+// ArcSight Common Event Format records.
+package siem
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// ECSVersion is the Elastic Common Schema version the documents follow.
+const ECSVersion = "8.11.0"
+
+// An ECSDocument is an Elastic Common Schema event. Session details without
+// an ECS field are stored under winlog.logon, as Winlogbeat does for logon
+// events from the Security log.
+type ECSDocument struct {
+	Timestamp time.Time `json:"@timestamp"`
+	ECS       ECSInfo   `json:"ecs"`
+	Event     ECSEvent  `json:"event"`
+	Host      ECSHost   `json:"host"`
+	User      ECSUser   `json:"user"`
+	// Source is set from the session's RemoteOrigin, which the LSA does not
+	// record itself; see winlsa.EnrichRemoteOrigin.
+	Source *ECSSource `json:"source,omitempty"`
+	// Destination and Process are set for explicit credentials events.
+	Destination *ECSDestination `json:"destination,omitempty"`
+	Process     *ECSProcess     `json:"process,omitempty"`
+	Winlog      ECSWinlog       `json:"winlog"`
+}
+
+type ECSInfo struct {
+	Version string `json:"version"`
+}
+
+type ECSEvent struct {
+	Kind     string    `json:"kind"`
+	Category []string  `json:"category"`
+	Type     []string  `json:"type"`
+	Action   string    `json:"action,omitempty"`
+	Outcome  string    `json:"outcome,omitempty"`
+	Provider string    `json:"provider"`
+	Created  time.Time `json:"created"`
+}
+
+type ECSHost struct {
+	Name string `json:"name,omitempty"`
+}
+
+type ECSUser struct {
+	Name   string `json:"name,omitempty"`
+	Domain string `json:"domain,omitempty"`
+	ID     string `json:"id,omitempty"`
+	Email  string `json:"email,omitempty"`
+	// Target is the account whose credentials were used explicitly.
+	Target *ECSTargetUser `json:"target,omitempty"`
+}
+
+type ECSTargetUser struct {
+	Name   string `json:"name,omitempty"`
+	Domain string `json:"domain,omitempty"`
+}
+
+type ECSDestination struct {
+	Domain string `json:"domain,omitempty"`
+	IP     string `json:"ip,omitempty"`
+}
+
+type ECSProcess struct {
+	PID        uint32 `json:"pid,omitempty"`
+	Executable string `json:"executable,omitempty"`
+}
+
+type ECSSource struct {
+	Address string `json:"address,omitempty"`
+	IP      string `json:"ip,omitempty"`
+	Port    uint16 `json:"port,omitempty"`
+	Domain  string `json:"domain,omitempty"`
+}
+
+type ECSWinlog struct {
+	Logon ECSLogon `json:"logon"`
+}
+
+type ECSLogon struct {
+	ID                    string `json:"id"`
+	Type                  string `json:"type,omitempty"`
+	AuthenticationPackage string `json:"authentication_package,omitempty"`
+	Session               uint32 `json:"session"`
+	LogonServer           string `json:"logon_server,omitempty"`
+	// Admin and Privileges are set from the 4672 event of the logon, see
+	// winlsa.WatchOptions.SpecialPrivileges.
+	Admin      bool     `json:"admin,omitempty"`
+	Privileges []string `json:"privileges,omitempty"`
+}
+
+// ECS maps a watcher event to an ECS authentication event. Logons map to
+// event.type start, logoffs to end.
+func ECS(ev winlsa.SessionEvent) ECSDocument {
+	doc := newECSDocument(ev.Time, ev.LogonId, ev.Data)
+	doc.Winlog.Logon.Admin = ev.AdminLogon
+	doc.Winlog.Logon.Privileges = ev.Privileges
+	switch ev.Type {
+	case winlsa.SessionLogoff:
+		doc.Event.Type = []string{"end"}
+		doc.Event.Action = "logged-out"
+	case winlsa.SessionExplicitCredentials:
+		ec := ev.ExplicitCredentials
+		doc.Event.Category = []string{"authentication"}
+		doc.Event.Type = []string{"info"}
+		doc.Event.Action = "explicit-credentials"
+		if ec != nil {
+			doc.User.Target = &ECSTargetUser{Name: ec.TargetUserName, Domain: ec.TargetDomainName}
+			doc.Destination = &ECSDestination{Domain: ec.TargetServerName, IP: ec.IpAddress}
+			doc.Process = &ECSProcess{PID: ec.ProcessId, Executable: ec.ProcessName}
+		}
+	}
+	return doc
+}
+
+// ECSSession maps session data to an ECS event describing the session's
+// logon, timestamped with its logon time.
+func ECSSession(sd *winlsa.LogonSessionData) ECSDocument {
+	ts := sd.LogonTime
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	return newECSDocument(ts, sd.LogonId, sd)
+}
+
+func newECSDocument(ts time.Time, luid winlsa.LUID, sd *winlsa.LogonSessionData) ECSDocument {
+	doc := ECSDocument{
+		Timestamp: ts.UTC(),
+		ECS:       ECSInfo{Version: ECSVersion},
+		Event: ECSEvent{
+			Kind:     "event",
+			Category: []string{"authentication", "session"},
+			Type:     []string{"start"},
+			Action:   "logged-in",
+			Outcome:  "success",
+			Provider: "winlsa",
+			Created:  time.Now().UTC(),
+		},
+		Host:   ECSHost{Name: hostname()},
+		Winlog: ECSWinlog{Logon: ECSLogon{ID: luid.String()}},
+	}
+	if sd != nil {
+		doc.User = ECSUser{
+			Name:   sd.UserName,
+			Domain: sd.LogonDomain,
+			ID:     lsa.SidString(sd.Sid),
+			Email:  sd.Upn,
+		}
+		doc.Winlog.Logon.Type = sd.LogonType.String()
+		doc.Winlog.Logon.AuthenticationPackage = sd.AuthenticationPackage
+		doc.Winlog.Logon.Session = sd.Session
+		doc.Winlog.Logon.LogonServer = sd.LogonServer
+		if o := sd.RemoteOrigin; o != nil && (o.Address != "" || o.Workstation != "") {
+			doc.Source = &ECSSource{Address: o.Address, IP: o.Address, Port: o.Port, Domain: o.Workstation}
+			if o.Address == "" {
+				doc.Source.Address = o.Workstation
+			}
+		}
+	}
+	return doc
+}
+
+var (
+	hostOnce sync.Once
+	hostName string
+)
+
+func hostname() string {
+	hostOnce.Do(func() {
+		hostName, _ = os.Hostname()
+	})
+	return hostName
+}
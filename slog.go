@@ -0,0 +1,71 @@
+//go:build go1.21
+// +build go1.21
+
+package winlsa
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// LogValue logs the identifying fields of the session as a group.
+func (sd *LogonSessionData) LogValue() slog.Value {
+	if sd == nil {
+		return slog.Value{}
+	}
+	attrs := []slog.Attr{
+		slog.Any("logon_id", sd.LogonId),
+		slog.String("user", sd.UserName),
+		slog.String("domain", sd.LogonDomain),
+		slog.String("logon_type", sd.LogonType.String()),
+		slog.String("auth_package", sd.AuthenticationPackage),
+		slog.Uint64("session", uint64(sd.Session)),
+	}
+	if sd.Sid != nil {
+		attrs = append(attrs, slog.String("sid", sd.Sid.String()))
+	}
+	if !sd.LogonTime.IsZero() {
+		attrs = append(attrs, slog.Time("logon_time", sd.LogonTime))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue logs the event type, time and session as a group.
+func (ev SessionEvent) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("type", ev.Type.String()),
+		slog.Time("time", ev.Time),
+		slog.Any("logon_id", ev.LogonId),
+	}
+	if ev.AdminLogon {
+		attrs = append(attrs, slog.Bool("admin", true), slog.Any("privileges", ev.Privileges))
+	}
+	if ev.Data != nil {
+		attrs = append(attrs, slog.Any("session", ev.Data))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// SetLogger logs every failed LSA call of this package, and of the Kerberos
+// and Security log queries of the kerberos and securitylog packages, to
+// logger at warning level, with the call, the session concerned and the
+// error as attributes.
+// A nil logger disables logging, which is the default.
+//
+// SetLogger must not be called concurrently with other functions of this
+// package.
+func SetLogger(logger *slog.Logger) {
+	if logger == nil {
+		lsa.SetFailureHook(nil)
+		return
+	}
+	lsa.SetFailureHook(func(call string, luid *LUID, err error) {
+		attrs := []slog.Attr{slog.String("call", call), slog.Any("error", err)}
+		if luid != nil {
+			attrs = append(attrs, slog.Any("logon_id", *luid))
+		}
+		logger.LogAttrs(context.Background(), slog.LevelWarn, "LSA call failed", attrs...)
+	})
+}
@@ -0,0 +1,76 @@
+package winlsa
+
+import (
+	"strings"
+	"time"
+)
+
+// An SMBSession is a client session of the local SMB server, as listed by
+// "net session".
+type SMBSession struct {
+	// Client is the client's address or computer name, without the
+	// leading backslashes.
+	Client    string
+	UserName  string
+	OpenFiles uint32
+	// Duration is how long the session has been established.
+	Duration time.Duration
+	Idle     time.Duration
+	// Guest reports whether the client was logged on as guest.
+	Guest     bool
+	Transport string `json:",omitempty"`
+}
+
+// GetSMBSessions returns the client sessions of the local SMB server.
+// Listing them requires administrator rights.
+func GetSMBSessions() ([]SMBSession, error) {
+	return getSMBSessions()
+}
+
+// An SMBCorrelation pairs a network logon session with the SMB session it
+// most likely belongs to.
+type SMBCorrelation struct {
+	Session *LogonSessionData
+	SMB     SMBSession
+}
+
+// CorrelateSMBSessions maps the Network logon sessions among sessions to
+// the SMB sessions of the local server. SMB sessions do not carry a logon
+// ID, so a logon session is matched by user name, by client address if
+// its RemoteOrigin is known, and by the logon time closest to the SMB
+// session's start. Each logon session and SMB session is used at most
+// once; logon sessions without a match are left out.
+func CorrelateSMBSessions(sessions []*LogonSessionData) ([]SMBCorrelation, error) {
+	smb, err := GetSMBSessions()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	used := make([]bool, len(sessions))
+	var matches []SMBCorrelation
+	for _, s := range smb {
+		start := now.Add(-s.Duration)
+		best := -1
+		var bestDelta time.Duration
+		for idx, sd := range sessions {
+			if used[idx] || sd.LogonType != LogonTypeNetwork || !strings.EqualFold(sd.UserName, s.UserName) {
+				continue
+			}
+			if o := sd.RemoteOrigin; o != nil && o.Address != "" && !strings.EqualFold(o.Address, s.Client) && !strings.EqualFold(o.Workstation, s.Client) {
+				continue
+			}
+			delta := sd.LogonTime.Sub(start)
+			if delta < 0 {
+				delta = -delta
+			}
+			if best < 0 || delta < bestDelta {
+				best, bestDelta = idx, delta
+			}
+		}
+		if best >= 0 {
+			used[best] = true
+			matches = append(matches, SMBCorrelation{Session: sessions[best], SMB: s})
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,49 @@
+package winlsa
+
+import (
+	"strings"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// sessGuest is SESS_GUEST from lmshare.h.
+const sessGuest = 0x1
+
+func getSMBSessions() ([]SMBSession, error) {
+	var (
+		sessions []SMBSession
+		resume   uint32
+	)
+	for {
+		var (
+			buf         *byte
+			read, total uint32
+		)
+		err := lsa.NetSessionEnum(nil, nil, nil, 502, &buf, lsa.MAX_PREFERRED_LENGTH, &read, &total, &resume)
+		if err != nil && err != windows.Errno(lsa.ERROR_MORE_DATA) {
+			return nil, err
+		}
+		if buf != nil {
+			infos := unsafe.Slice((*lsa.SESSION_INFO_502)(unsafe.Pointer(buf)), read)
+			for _, info := range infos {
+				sessions = append(sessions, SMBSession{
+					Client:    strings.TrimLeft(windows.UTF16PtrToString(info.Cname), `\`),
+					UserName:  windows.UTF16PtrToString(info.Username),
+					OpenFiles: info.NumOpens,
+					Duration:  time.Duration(info.Time) * time.Second,
+					Idle:      time.Duration(info.IdleTime) * time.Second,
+					Guest:     info.UserFlags&sessGuest != 0,
+					Transport: windows.UTF16PtrToString(info.Transport),
+				})
+			}
+			windows.NetApiBufferFree(buf)
+		}
+		if err == nil {
+			return sessions, nil
+		}
+	}
+}
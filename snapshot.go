@@ -0,0 +1,57 @@
+package winlsa
+
+import (
+	"time"
+)
+
+// A Snapshot is the data of all logon sessions at one point in time.
+type Snapshot struct {
+	// Time is when the session list was enumerated.
+	Time     time.Time
+	Sessions []*LogonSessionData
+	// Consistent reports whether every enumerated session could be
+	// queried. If sessions kept ending during enumeration until the retries
+	// were exhausted, it is false and Sessions lacks the ended sessions.
+	Consistent bool
+	// Attempts is the number of enumerations made.
+	Attempts int
+	// Errors holds the sessions of the last attempt that could not be
+	// queried for reasons other than having ended. They are missing from
+	// Sessions.
+	Errors SessionErrors
+}
+
+// TakeSnapshot queries the data of all logon sessions. A session ending
+// between enumeration and query makes the list inconsistent, so the whole
+// snapshot is retried up to retries times; the last attempt is returned
+// with Consistent set accordingly. Other failures to query a session are
+// recorded in Errors; only a failure to enumerate aborts the snapshot.
+func TakeSnapshot(retries int) (*Snapshot, error) {
+	var luids []LUID
+	for attempt := 1; ; attempt++ {
+		snap := &Snapshot{Time: time.Now(), Consistent: true, Attempts: attempt}
+		var err error
+		luids, err = AppendLogonSessions(luids[:0])
+		if err != nil && !IsFreeBufferError(err) {
+			return nil, err
+		}
+		for idx := range luids {
+			sd, err := GetLogonSessionData(&luids[idx])
+			if err == ErrNoSuchLogonSession {
+				snap.Consistent = false
+				continue
+			}
+			if err != nil && !IsFreeBufferError(err) {
+				if snap.Errors == nil {
+					snap.Errors = SessionErrors{}
+				}
+				snap.Errors[luids[idx]] = err
+				continue
+			}
+			snap.Sessions = append(snap.Sessions, sd)
+		}
+		if snap.Consistent || attempt > retries {
+			return snap, nil
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package winlsa
+
+import (
+	"fmt"
+	"time"
+)
+
+// WTSState is the connection state of a Terminal Services session.
+type WTSState uint32
+
+const (
+	WTSStateActive WTSState = iota
+	WTSStateConnected
+	WTSStateConnectQuery
+	WTSStateShadow
+	WTSStateDisconnected
+	WTSStateIdle
+	WTSStateListen
+	WTSStateReset
+	WTSStateDown
+	WTSStateInit
+)
+
+func (s WTSState) String() string {
+	switch s {
+	case WTSStateActive:
+		return "Active"
+	case WTSStateConnected:
+		return "Connected"
+	case WTSStateConnectQuery:
+		return "ConnectQuery"
+	case WTSStateShadow:
+		return "Shadow"
+	case WTSStateDisconnected:
+		return "Disconnected"
+	case WTSStateIdle:
+		return "Idle"
+	case WTSStateListen:
+		return "Listen"
+	case WTSStateReset:
+		return "Reset"
+	case WTSStateDown:
+		return "Down"
+	case WTSStateInit:
+		return "Init"
+	default:
+		return fmt.Sprintf("Undefined WTSState(%d)", s)
+	}
+}
+
+func (s WTSState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// A StaleSession is a Terminal Services session that has been
+// disconnected or without user input for longer than a threshold, with
+// the logon sessions it holds.
+type StaleSession struct {
+	WTSSession  uint32
+	State       WTSState
+	WinStation  string
+	LogonDomain string
+	UserName    string
+	// DisconnectTime is the time of the last disconnect; it is zero if
+	// the session was never disconnected.
+	DisconnectTime time.Time
+	// LastInputTime is zero if Windows has not recorded any input, as is
+	// usual for console sessions.
+	LastInputTime time.Time
+	// Idle is the time since the disconnect for disconnected sessions and
+	// since the last input otherwise.
+	Idle     time.Duration
+	Sessions []*LogonSessionData
+}
+
+// StaleSessions lists the user sessions of Terminal Services that have
+// been disconnected, or had no input, for at least threshold. Such
+// sessions keep holding Remote Desktop licenses, file locks and the
+// credentials of their logon sessions. Sessions that end while they are
+// queried are left out. Like FindLogonSessions, it returns
+// a partial report with a SessionErrors error if some logon sessions could
+// not be queried.
+func StaleSessions(threshold time.Duration) ([]*StaleSession, error) {
+	stale, err := wtsStaleSessions(threshold)
+	if err != nil || len(stale) == 0 {
+		return nil, err
+	}
+	byID := map[uint32]*StaleSession{}
+	for _, s := range stale {
+		byID[s.WTSSession] = s
+	}
+
+	sessions, err := GetLogonSessionsDataParallel(0)
+	if _, partial := err.(SessionErrors); err != nil && !partial {
+		return nil, err
+	}
+	for _, sd := range sessions {
+		if s, ok := byID[sd.Session]; ok && sd.UserName != "" {
+			s.Sessions = append(s.Sessions, sd)
+		}
+	}
+	return stale, err
+}
@@ -0,0 +1,104 @@
+package winlsa
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// wtsStaleSessions returns the Terminal Services user sessions idle for at
+// least threshold, without their logon sessions.
+func wtsStaleSessions(threshold time.Duration) ([]*StaleSession, error) {
+	var infos *windows.WTS_SESSION_INFO
+	var count uint32
+	err := windows.WTSEnumerateSessions(lsa.WTS_CURRENT_SERVER_HANDLE, 0, 1, &infos, &count)
+	if err != nil {
+		return nil, err
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(infos)))
+
+	var stale []*StaleSession
+	for _, info := range unsafe.Slice(infos, count) {
+		s, err := staleSession(info.SessionID, threshold)
+		if wtsSessionEnded(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if s != nil {
+			stale = append(stale, s)
+		}
+	}
+	return stale, nil
+}
+
+// wtsSession queries the Terminal Services session id. It also returns the
+// current time of the session's server.
+func wtsSession(id uint32) (*WTSSession, time.Time, error) {
+	var buf *byte
+	var size uint32
+	err := lsa.WTSQuerySessionInformation(lsa.WTS_CURRENT_SERVER_HANDLE, id, lsa.WTSSessionInfo, &buf, &size)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("WTS session %d: %w", id, err)
+	}
+	defer windows.WTSFreeMemory(uintptr(unsafe.Pointer(buf)))
+	info := (*lsa.WTSINFO)(unsafe.Pointer(buf))
+	return &WTSSession{
+		ID:             id,
+		State:          WTSState(info.State),
+		WinStation:     windows.UTF16ToString(info.WinStationName[:]),
+		LogonDomain:    windows.UTF16ToString(info.Domain[:]),
+		UserName:       windows.UTF16ToString(info.UserName[:]),
+		ConnectTime:    lsa.TimeFromUint64(uint64(info.ConnectTime)),
+		DisconnectTime: lsa.TimeFromUint64(uint64(info.DisconnectTime)),
+		LastInputTime:  lsa.TimeFromUint64(uint64(info.LastInputTime)),
+		LogonTime:      lsa.TimeFromUint64(uint64(info.LogonTime)),
+	}, lsa.TimeFromUint64(uint64(info.CurrentTime)), nil
+}
+
+// wtsSessionEnded reports whether err is the error of querying a Terminal
+// Services session that ended after it was enumerated.
+func wtsSessionEnded(err error) bool {
+	return errors.Is(err, windows.ERROR_CTX_WINSTATION_NOT_FOUND) ||
+		errors.Is(err, windows.ERROR_FILE_NOT_FOUND)
+}
+
+// staleSession queries the Terminal Services session id and returns it if
+// it is a user session idle for at least threshold.
+func staleSession(id uint32, threshold time.Duration) (*StaleSession, error) {
+	ws, now, err := wtsSession(id)
+	if err != nil {
+		return nil, err
+	}
+	if ws.UserName == "" {
+		return nil, nil
+	}
+
+	s := &StaleSession{
+		WTSSession:     id,
+		State:          ws.State,
+		WinStation:     ws.WinStation,
+		LogonDomain:    ws.LogonDomain,
+		UserName:       ws.UserName,
+		DisconnectTime: ws.DisconnectTime,
+		LastInputTime:  ws.LastInputTime,
+	}
+	switch {
+	case s.State == WTSStateDisconnected && !s.DisconnectTime.IsZero():
+		s.Idle = now.Sub(s.DisconnectTime)
+	case !s.LastInputTime.IsZero():
+		s.Idle = now.Sub(s.LastInputTime)
+	default:
+		return nil, nil
+	}
+	if s.Idle < threshold {
+		return nil, nil
+	}
+	return s, nil
+}
@@ -0,0 +1,38 @@
+package winlsa
+
+import "os"
+
+// StartProcessOpts are the options of StartProcessInSession.
+type StartProcessOpts struct {
+	// Dir is the working directory. It defaults to the user's profile
+	// directory.
+	Dir string
+	// Env is the environment, as "name=value" strings. If nil, the process
+	// gets the user's environment, see GetUserEnvironment.
+	Env []string
+	// WTSSession, if set, starts the process in the Terminal Services
+	// session with this ID rather than in that of the logon session's
+	// processes, e.g. to show the process of a service account on a
+	// user's desktop. It requires SeTcbPrivilege.
+	WTSSession *uint32
+	// Desktop is the window station and desktop to show the process on.
+	// It defaults to `winsta0\default`, the interactive desktop.
+	Desktop string
+	// Hidden starts the process without showing its window.
+	Hidden bool
+}
+
+// StartProcessInSession starts cmdline as the user of the logon session
+// luid, with a primary token duplicated from one of the session's
+// processes, see OpenSessionToken. cmdline is passed to the process as
+// is; quote its arguments with syscall.EscapeArg. The process gets a new
+// console and inherits no handles.
+//
+// It calls CreateProcessAsUser, which requires SeAssignPrimaryTokenPrivilege
+// as held by LocalSystem, and falls back to CreateProcessWithTokenW, which
+// requires SeImpersonatePrivilege as held by administrators and services.
+// Processes of other users can only be opened with SeDebugPrivilege
+// enabled. The caller must release the returned process.
+func StartProcessInSession(luid LUID, cmdline string, opts StartProcessOpts) (*os.Process, error) {
+	return startProcessInSession(luid, cmdline, opts)
+}
@@ -0,0 +1,110 @@
+package winlsa
+
+import (
+	"fmt"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func startProcessInSession(luid LUID, cmdline string, opts StartProcessOpts) (*os.Process, error) {
+	token, err := openSessionToken(luid, windows.TOKEN_QUERY|windows.TOKEN_DUPLICATE)
+	if err != nil {
+		return nil, err
+	}
+	defer token.Close()
+	var primary windows.Token
+	err = windows.DuplicateTokenEx(token,
+		windows.TOKEN_QUERY|windows.TOKEN_DUPLICATE|windows.TOKEN_ASSIGN_PRIMARY|windows.TOKEN_ADJUST_DEFAULT|windows.TOKEN_ADJUST_SESSIONID,
+		nil, windows.SecurityImpersonation, windows.TokenPrimary, &primary)
+	if err != nil {
+		return nil, err
+	}
+	defer primary.Close()
+	if opts.WTSSession != nil {
+		id := *opts.WTSSession
+		err = windows.SetTokenInformation(primary, windows.TokenSessionId, (*byte)(unsafe.Pointer(&id)), uint32(unsafe.Sizeof(id)))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	env, dir := opts.Env, opts.Dir
+	if env == nil || dir == "" {
+		ue, err := userEnvironment(primary)
+		if err != nil {
+			return nil, err
+		}
+		if env == nil {
+			env = ue.Env
+		}
+		if dir == "" {
+			dir = ue.ProfileDirectory
+		}
+	}
+	block, err := environmentBlock(env)
+	if err != nil {
+		return nil, err
+	}
+	cmd, err := windows.UTF16PtrFromString(cmdline)
+	if err != nil {
+		return nil, err
+	}
+	cwd, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return nil, err
+	}
+	desktop := opts.Desktop
+	if desktop == "" {
+		desktop = `winsta0\default`
+	}
+	si := windows.StartupInfo{Cb: uint32(unsafe.Sizeof(windows.StartupInfo{}))}
+	si.Desktop, err = windows.UTF16PtrFromString(desktop)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Hidden {
+		si.Flags |= windows.STARTF_USESHOWWINDOW
+		si.ShowWindow = windows.SW_HIDE
+	}
+
+	const flags = windows.CREATE_UNICODE_ENVIRONMENT | windows.CREATE_NEW_CONSOLE
+	var pi windows.ProcessInformation
+	err = windows.CreateProcessAsUser(primary, nil, cmd, nil, nil, false, flags, block, cwd, &si, &pi)
+	if err == windows.ERROR_PRIVILEGE_NOT_HELD {
+		err = lsa.CreateProcessWithToken(primary, lsa.LOGON_WITH_PROFILE, nil, cmd, flags, block, cwd, &si, &pi)
+	}
+	if err != nil {
+		return nil, err
+	}
+	windows.CloseHandle(pi.Thread)
+	// pi.Process keeps the process ID from being reused until
+	// FindProcess has opened its own handle.
+	defer windows.CloseHandle(pi.Process)
+	p, err := os.FindProcess(int(pi.ProcessId))
+	if err != nil {
+		return nil, fmt.Errorf("process %d started but cannot be opened: %w", pi.ProcessId, err)
+	}
+	return p, nil
+}
+
+// environmentBlock encodes env as the block CreateProcess expects with
+// CREATE_UNICODE_ENVIRONMENT.
+func environmentBlock(env []string) (*uint16, error) {
+	var block []uint16
+	for _, kv := range env {
+		s, err := windows.UTF16FromString(kv)
+		if err != nil {
+			return nil, err
+		}
+		block = append(block, s...)
+	}
+	if len(block) == 0 {
+		block = append(block, 0)
+	}
+	block = append(block, 0)
+	return &block[0], nil
+}
@@ -0,0 +1,160 @@
+package winlsa_test
+
+import (
+	"flag"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/fakelsa"
+)
+
+var stressDuration = flag.Duration("stress.duration", time.Second, "how long TestConcurrentUse runs")
+
+// TestConcurrentUse calls the stateful types of this package from many
+// goroutines at once while sessions are added to and removed from a fake
+// LSA, so that sessions appear and end between enumeration and query. It
+// is meant to be run under the race detector:
+//
+//	go test -race -run TestConcurrentUse -stress.duration 30s
+func TestConcurrentUse(t *testing.T) {
+	lsa := fakelsa.New()
+	winlsa.SetProvider(lsa)
+	defer winlsa.SetProvider(nil)
+
+	stresses := []struct {
+		name string
+		run  func(stop <-chan struct{}) error
+	}{
+		{"enumerate", stressEnumerate},
+		{"find", stressFind},
+		{"cache", stressCache(winlsa.NewSessionCache(time.Millisecond))},
+		{"watch", stressWatch},
+		{"churn", stressChurn(lsa)},
+	}
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, s := range stresses {
+		for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+			wg.Add(1)
+			go func(name string, run func(<-chan struct{}) error) {
+				defer wg.Done()
+				if err := run(stop); err != nil {
+					t.Errorf("%s: %v", name, err)
+				}
+			}(s.name, s.run)
+		}
+	}
+	time.Sleep(*stressDuration)
+	close(stop)
+	wg.Wait()
+}
+
+func stopped(stop <-chan struct{}) bool {
+	select {
+	case <-stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// partial reports whether err only reports sessions that could not be
+// queried, which is expected for sessions that end while being queried.
+func partial(err error) bool {
+	_, ok := err.(winlsa.SessionErrors)
+	return ok
+}
+
+func stressEnumerate(stop <-chan struct{}) error {
+	for !stopped(stop) {
+		if _, err := winlsa.GetLogonSessionsDataParallel(4); err != nil && !partial(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func stressFind(stop <-chan struct{}) error {
+	for !stopped(stop) {
+		if _, err := winlsa.FindLogonSessions(winlsa.SessionFilter{}); err != nil && !partial(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// stressCache looks the sessions up in c, whose entries expire quickly, so
+// that lookups, insertions and invalidations interleave.
+func stressCache(c *winlsa.SessionCache) func(stop <-chan struct{}) error {
+	return func(stop <-chan struct{}) error {
+		for !stopped(stop) {
+			luids, err := winlsa.GetLogonSessions()
+			if err != nil {
+				return err
+			}
+			for _, luid := range luids {
+				// Sessions may end between enumeration and lookup, which
+				// is not what this stress is about.
+				c.Get(luid)
+			}
+		}
+		return nil
+	}
+}
+
+// stressWatch starts watchers and closes each from several goroutines
+// while it polls and while its events and errors are read.
+func stressWatch(stop <-chan struct{}) error {
+	for !stopped(stop) {
+		w, err := winlsa.Watch(winlsa.WatchOptions{Interval: time.Millisecond, Existing: true})
+		if err != nil {
+			return err
+		}
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range w.Events() {
+			}
+		}()
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				time.Sleep(5 * time.Millisecond)
+				w.Err()
+				w.Close()
+			}()
+		}
+		wg.Wait()
+	}
+	return nil
+}
+
+// stressChurn adds sessions to and removes them from lsa, keeping the
+// newest 64 of them.
+func stressChurn(lsa *fakelsa.LSA) func(stop <-chan struct{}) error {
+	var next uint32
+	return func(stop <-chan struct{}) error {
+		for !stopped(stop) {
+			luid := winlsa.LUID{LowPart: atomic.AddUint32(&next, 1)}
+			lsa.Add(&winlsa.LogonSessionData{
+				LogonId:               luid,
+				UserName:              fmt.Sprintf("user%d", luid.LowPart%16),
+				LogonDomain:           "STRESS",
+				AuthenticationPackage: "Kerberos",
+				LogonType:             winlsa.LogonTypeInteractive,
+				LogonTime:             time.Now(),
+			})
+			if luid.LowPart > 64 {
+				lsa.Remove(winlsa.LUID{LowPart: luid.LowPart - 64})
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,126 @@
+package winlsa
+
+import (
+	"fmt"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+// A Token is an access token handle. It is windows.Token on Windows.
+type Token = lsa.Token
+
+// A Group is a group SID of an access token.
+type Group struct {
+	Sid *SID
+	// Attributes is a combination of the windows.SE_GROUP_* flags.
+	Attributes uint32
+}
+
+// Enabled reports whether the group is used for access checks.
+func (g *Group) Enabled() bool {
+	return g.Attributes&lsa.SE_GROUP_ENABLED != 0
+}
+
+// DenyOnly reports whether the group is only used to deny access.
+func (g *Group) DenyOnly() bool {
+	return g.Attributes&lsa.SE_GROUP_USE_FOR_DENY_ONLY != 0
+}
+
+// A Privilege is a privilege held by an access token.
+type Privilege struct {
+	Name string
+	// Attributes is a combination of the windows.SE_PRIVILEGE_* flags.
+	Attributes uint32
+}
+
+// Enabled reports whether the privilege is enabled.
+func (p *Privilege) Enabled() bool {
+	return p.Attributes&lsa.SE_PRIVILEGE_ENABLED != 0
+}
+
+// IntegrityLevel is the mandatory integrity level of an access token, i.e.
+// the RID of its mandatory label SID.
+type IntegrityLevel uint32
+
+const (
+	IntegrityUntrusted  IntegrityLevel = 0x0000
+	IntegrityLow        IntegrityLevel = 0x1000
+	IntegrityMedium     IntegrityLevel = 0x2000
+	IntegrityMediumPlus IntegrityLevel = 0x2100
+	IntegrityHigh       IntegrityLevel = 0x3000
+	IntegritySystem     IntegrityLevel = 0x4000
+	IntegrityProtected  IntegrityLevel = 0x5000
+)
+
+func (il IntegrityLevel) String() string {
+	switch il {
+	case IntegrityUntrusted:
+		return "Untrusted"
+	case IntegrityLow:
+		return "Low"
+	case IntegrityMedium:
+		return "Medium"
+	case IntegrityMediumPlus:
+		return "MediumPlus"
+	case IntegrityHigh:
+		return "High"
+	case IntegritySystem:
+		return "System"
+	case IntegrityProtected:
+		return "Protected"
+	default:
+		return fmt.Sprintf("IntegrityLevel(0x%x)", uint32(il))
+	}
+}
+
+// ElevationType is the UAC elevation type of an access token.
+type ElevationType uint32
+
+const (
+	// ElevationTypeDefault tokens have no linked token, e.g. because UAC
+	// is disabled or the user is not an administrator.
+	ElevationTypeDefault ElevationType = iota + 1
+	ElevationTypeFull
+	ElevationTypeLimited
+)
+
+func (et ElevationType) String() string {
+	switch et {
+	case ElevationTypeDefault:
+		return "Default"
+	case ElevationTypeFull:
+		return "Full"
+	case ElevationTypeLimited:
+		return "Limited"
+	default:
+		return fmt.Sprintf("ElevationType(%d)", et)
+	}
+}
+
+// TokenInfo is the security context an access token carries.
+type TokenInfo struct {
+	// LogonId is the logon session the token belongs to.
+	LogonId LUID
+	// OriginLogonId is the logon session that created LogonId, e.g. the
+	// interactive session a runas /netonly was started from. It is zero if
+	// the token does not record one.
+	OriginLogonId  LUID
+	User           *SID
+	Groups         []Group
+	Privileges     []Privilege
+	IntegrityLevel IntegrityLevel
+	Elevated       bool
+	ElevationType  ElevationType
+}
+
+// GetTokenInfo reads the security context of token, which must have been
+// opened with TOKEN_QUERY access.
+func GetTokenInfo(token Token) (*TokenInfo, error) {
+	return getTokenInfo(token)
+}
+
+// GetCurrentTokenInfo reads the security context of the calling thread, i.e.
+// of its impersonation token or else of the process token.
+func GetCurrentTokenInfo() (*TokenInfo, error) {
+	return getCurrentTokenInfo()
+}
@@ -0,0 +1,123 @@
+package winlsa
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func getTokenInfo(token windows.Token) (*TokenInfo, error) {
+	var info TokenInfo
+	buf, err := tokenInformation(token, windows.TokenStatistics)
+	if err != nil {
+		return nil, err
+	}
+	info.LogonId = (*lsa.TOKEN_STATISTICS)(unsafe.Pointer(&buf[0])).AuthenticationId
+	info.OriginLogonId, err = tokenOrigin(token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := token.GetTokenUser()
+	if err != nil {
+		return nil, err
+	}
+	info.User, err = user.User.Sid.Copy()
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := token.GetTokenGroups()
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups.AllGroups() {
+		sid, err := g.Sid.Copy()
+		if err != nil {
+			return nil, err
+		}
+		info.Groups = append(info.Groups, Group{Sid: sid, Attributes: g.Attributes})
+	}
+
+	buf, err = tokenInformation(token, windows.TokenPrivileges)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range (*windows.Tokenprivileges)(unsafe.Pointer(&buf[0])).AllPrivileges() {
+		name, err := lookupPrivilegeName(p.Luid)
+		if err != nil {
+			return nil, err
+		}
+		info.Privileges = append(info.Privileges, Privilege{Name: name, Attributes: p.Attributes})
+	}
+
+	info.IntegrityLevel, err = tokenIntegrityLevel(token)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Elevated = token.IsElevated()
+	buf, err = tokenInformation(token, windows.TokenElevationType)
+	if err != nil {
+		return nil, err
+	}
+	info.ElevationType = ElevationType(*(*uint32)(unsafe.Pointer(&buf[0])))
+	return &info, nil
+}
+
+func getCurrentTokenInfo() (*TokenInfo, error) {
+	return getTokenInfo(windows.GetCurrentThreadEffectiveToken())
+}
+
+func tokenIntegrityLevel(token windows.Token) (IntegrityLevel, error) {
+	buf, err := tokenInformation(token, windows.TokenIntegrityLevel)
+	if err != nil {
+		return 0, err
+	}
+	label := (*windows.Tokenmandatorylabel)(unsafe.Pointer(&buf[0]))
+	if n := label.Label.Sid.SubAuthorityCount(); n > 0 {
+		return IntegrityLevel(label.Label.Sid.SubAuthority(uint32(n - 1))), nil
+	}
+	return 0, nil
+}
+
+// tokenOrigin returns the logon session that created the token's logon
+// session.
+func tokenOrigin(token windows.Token) (LUID, error) {
+	buf, err := tokenInformation(token, windows.TokenOrigin)
+	if err != nil {
+		return LUID{}, err
+	}
+	return (*lsa.TOKEN_ORIGIN)(unsafe.Pointer(&buf[0])).OriginatingLogonSession, nil
+}
+
+func tokenInformation(token windows.Token, class uint32) ([]byte, error) {
+	n := uint32(64)
+	for {
+		buf := make([]byte, n)
+		err := windows.GetTokenInformation(token, class, &buf[0], n, &n)
+		if err == nil {
+			return buf, nil
+		}
+		if err != windows.ERROR_INSUFFICIENT_BUFFER {
+			return nil, err
+		}
+	}
+}
+
+func lookupPrivilegeName(luid windows.LUID) (string, error) {
+	l := LUID{LowPart: luid.LowPart, HighPart: luid.HighPart}
+	n := uint32(64)
+	for {
+		buf := make([]uint16, n)
+		err := lsa.LookupPrivilegeName(nil, &l, &buf[0], &n)
+		if err == nil {
+			return windows.UTF16ToString(buf[:n]), nil
+		}
+		if err != windows.ERROR_INSUFFICIENT_BUFFER {
+			return "", err
+		}
+	}
+}
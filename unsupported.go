@@ -0,0 +1,101 @@
+//go:build !windows
+// +build !windows
+
+package winlsa
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// The LSA and the other system calls the package wraps only exist on
+// Windows; elsewhere they fail with ErrUnsupportedPlatform.
+
+func appendLogonSessions(ctx context.Context, dst []LUID) ([]LUID, error) {
+	return dst, ErrUnsupportedPlatform
+}
+
+func getLogonSessionData(ctx context.Context, luid *LUID, opts GetLogonSessionDataOpts) (*LogonSessionData, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func getTokenInfo(token Token) (*TokenInfo, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func getCurrentTokenInfo() (*TokenInfo, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func tokenIntegrityLevel(token Token) (IntegrityLevel, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+func openSessionToken(luid LUID, access uint32) (Token, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+func tokenOrigin(token Token) (LUID, error) {
+	return LUID{}, ErrUnsupportedPlatform
+}
+
+func logoffWTSSession(id uint32, wait bool) error {
+	return ErrUnsupportedPlatform
+}
+
+func disconnectWTSSession(id uint32, wait bool) error {
+	return ErrUnsupportedPlatform
+}
+
+func sessionProcessIDs(luid LUID) ([]uint32, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func terminateProcess(pid uint32) error {
+	return ErrUnsupportedPlatform
+}
+
+func getSMBSessions() ([]SMBSession, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func wtsStaleSessions(threshold time.Duration) ([]*StaleSession, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func aadJoinInfo() (*CloudAPInfo, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func probeCapabilities() (*CapabilityReport, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func ensurePrivileges(names []string) (*PrivilegeReport, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func lookupSids(sids []*SID) ([]*ResolvedAccount, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func processList() ([]processEntry, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func wtsSessions() ([]*WTSSession, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func osBuild() uint32 {
+	return 0
+}
+
+func userEnvironment(token Token) (*UserEnvironment, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func startProcessInSession(luid LUID, cmdline string, opts StartProcessOpts) (*os.Process, error) {
+	return nil, ErrUnsupportedPlatform
+}
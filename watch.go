@@ -0,0 +1,417 @@
+package winlsa
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+	"github.com/cobraqxx/winlsa/securitylog"
+)
+
+type SessionEventType uint32
+
+const (
+	SessionLogon SessionEventType = iota + 1
+	SessionLogoff
+	// SessionExplicitCredentials reports that a process of the session
+	// used other credentials, see WatchOptions.ExplicitCredentials.
+	SessionExplicitCredentials
+)
+
+func (t SessionEventType) String() string {
+	switch t {
+	case SessionLogon:
+		return "Logon"
+	case SessionLogoff:
+		return "Logoff"
+	case SessionExplicitCredentials:
+		return "ExplicitCredentials"
+	default:
+		return fmt.Sprintf("Undefined SessionEventType(%d)", t)
+	}
+}
+
+func (t SessionEventType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *SessionEventType) UnmarshalText(text []byte) error {
+	for _, typ := range []SessionEventType{SessionLogon, SessionLogoff, SessionExplicitCredentials} {
+		if string(text) == typ.String() {
+			*t = typ
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid session event type %q", text)
+}
+
+// A SessionEvent reports a logon session that appeared or disappeared, or
+// credentials used explicitly by one of its processes.
+type SessionEvent struct {
+	Type SessionEventType
+	// Time is when the watcher observed the change, or when the 4648 event
+	// of a SessionExplicitCredentials event was written.
+	Time    time.Time
+	LogonId LUID
+	// Data is the session data queried when the session was first seen. It
+	// is nil if the session could not be queried.
+	Data *LogonSessionData
+	// AdminLogon reports whether the Security log holds a 4672 event for
+	// the session, i.e. whether it logged on with administrator equivalent
+	// privileges, and Privileges lists them. They are only set on logon
+	// events of watchers with WatchOptions.SpecialPrivileges, and left
+	// unset if the Security log cannot be read.
+	AdminLogon bool     `json:",omitempty"`
+	Privileges []string `json:",omitempty"`
+	// ExplicitCredentials is set on SessionExplicitCredentials events.
+	ExplicitCredentials *ExplicitCredentials `json:",omitempty"`
+}
+
+// ExplicitCredentials is a use of credentials other than the session's, as
+// recorded by a 4648 Security log event.
+type ExplicitCredentials struct {
+	// RecordID is the record ID of the 4648 event.
+	RecordID         uint64
+	TargetUserName   string
+	TargetDomainName string
+	// TargetServerName is the server the credentials were used for, or
+	// localhost for local logons, and TargetInfo the target name the
+	// caller passed, typically an SPN.
+	TargetServerName string
+	TargetInfo       string `json:",omitempty"`
+	ProcessId        uint32
+	ProcessName      string `json:",omitempty"`
+	// IpAddress and IpPort are the address of the target, if known.
+	IpAddress string `json:",omitempty"`
+	IpPort    string `json:",omitempty"`
+}
+
+type WatchOptions struct {
+	// Interval is the time between two polls of the session list. It
+	// defaults to one second.
+	Interval time.Duration
+	// Existing reports the sessions present when watching starts as logon
+	// events.
+	Existing bool
+	// SpecialPrivileges sets AdminLogon and Privileges of logon events from
+	// the 4672 events in the Security log, which requires administrator
+	// rights or membership in Event Log Readers.
+	SpecialPrivileges bool
+	// ExplicitCredentials reports the 4648 events written after watching
+	// starts as SessionExplicitCredentials events of the sessions whose
+	// processes used the credentials. It requires the same rights as
+	// SpecialPrivileges; Watch fails if the Security log cannot be read.
+	ExplicitCredentials bool
+	// OnPanic is called with the value and stack of a panic recovered from
+	// an OnSessionEvent handler. Such panics are discarded if it is nil.
+	OnPanic func(v interface{}, stack []byte)
+}
+
+// A Watcher polls the logon session list and reports sessions that appear
+// and disappear. Its methods are safe for concurrent use, and Close may be
+// called more than once.
+type Watcher struct {
+	ctx    context.Context
+	events chan SessionEvent
+	stop   chan struct{}
+	done   chan struct{}
+	// wake is signalled by OnSessionEvent to hand buffered events to the
+	// handlers.
+	wake chan struct{}
+	opts WatchOptions
+
+	known map[LUID]*LogonSessionData
+	// luids is reused by every poll to avoid allocating the session list.
+	luids []LUID
+	// lastRecord is the record ID of the last 4648 event reported.
+	lastRecord uint64
+
+	closeOnce sync.Once
+
+	mu       sync.Mutex
+	err      error
+	handlers []func(SessionEvent)
+}
+
+// Watch starts watching logon sessions. The initial session list is taken
+// before Watch returns, so sessions ending afterwards are always reported.
+func Watch(opts WatchOptions) (*Watcher, error) {
+	return WatchContext(context.Background(), opts)
+}
+
+// WatchContext is like Watch, but the watcher also stops when ctx is done,
+// as if Close had been called. The initial session list is taken with ctx.
+func WatchContext(ctx context.Context, opts WatchOptions) (*Watcher, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+	w := &Watcher{
+		ctx:    ctx,
+		events: make(chan SessionEvent, 64),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		wake:   make(chan struct{}, 1),
+		opts:   opts,
+		known:  map[LUID]*LogonSessionData{},
+	}
+	if opts.ExplicitCredentials {
+		var err error
+		w.lastRecord, err = securitylog.LastRecordID(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var initial []SessionEvent
+	err := w.poll(func(ev SessionEvent) bool {
+		initial = append(initial, ev)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !opts.Existing {
+		initial = nil
+	}
+	go w.run(initial)
+	return w, nil
+}
+
+// Events returns the channel events are delivered on. It is closed by Close.
+func (w *Watcher) Events() <-chan SessionEvent {
+	return w.events
+}
+
+// OnSessionEvent registers fn to be called with every event. Handlers run
+// one after another on the watcher's goroutine, in the order they were
+// registered, and see the events in order; a slow handler delays all of
+// them. A panicking handler is recovered and reported to
+// WatchOptions.OnPanic, and the other handlers still get the event.
+//
+// Handlers replace the Events channel: once one is registered, the events
+// still buffered in the channel and all later events go to the handlers
+// only, so register handlers right after Watch and do not read Events.
+func (w *Watcher) OnSessionEvent(fn func(SessionEvent)) {
+	w.mu.Lock()
+	w.handlers = append(w.handlers, fn)
+	w.mu.Unlock()
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Err returns the error of the last failed poll, or nil if the last poll
+// succeeded. Polling continues after errors. Once the context of a
+// watcher started by WatchContext is done, Err returns ctx.Err().
+func (w *Watcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// Close stops the watcher and closes the events channel.
+func (w *Watcher) Close() error {
+	w.closeOnce.Do(func() { close(w.stop) })
+	<-w.done
+	return nil
+}
+
+func (w *Watcher) run(initial []SessionEvent) {
+	defer close(w.done)
+	defer close(w.events)
+
+	for _, ev := range initial {
+		if !w.send(ev) {
+			return
+		}
+	}
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-w.ctx.Done():
+			w.setErr(w.ctx.Err())
+			return
+		case <-w.wake:
+			w.drain()
+			continue
+		case <-ticker.C:
+		}
+		stopped := false
+		err := w.poll(func(ev SessionEvent) bool {
+			if !w.send(ev) {
+				stopped = true
+			}
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+		w.setErr(err)
+	}
+}
+
+func (w *Watcher) setErr(err error) {
+	w.mu.Lock()
+	w.err = err
+	w.mu.Unlock()
+}
+
+func (w *Watcher) send(ev SessionEvent) bool {
+	for {
+		if handlers := w.getHandlers(); handlers != nil {
+			w.drain()
+			w.dispatch(handlers, ev)
+			return true
+		}
+		select {
+		case w.events <- ev:
+			return true
+		case <-w.wake:
+		case <-w.stop:
+			return false
+		case <-w.ctx.Done():
+			w.setErr(w.ctx.Err())
+			return false
+		}
+	}
+}
+
+func (w *Watcher) getHandlers() []func(SessionEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.handlers
+}
+
+// drain hands the events buffered in the channel to the handlers.
+func (w *Watcher) drain() {
+	handlers := w.getHandlers()
+	for {
+		select {
+		case ev := <-w.events:
+			w.dispatch(handlers, ev)
+		default:
+			return
+		}
+	}
+}
+
+func (w *Watcher) dispatch(handlers []func(SessionEvent), ev SessionEvent) {
+	for _, fn := range handlers {
+		w.call(fn, ev)
+	}
+}
+
+func (w *Watcher) call(fn func(SessionEvent), ev SessionEvent) {
+	defer func() {
+		if v := recover(); v != nil && w.opts.OnPanic != nil {
+			w.opts.OnPanic(v, debug.Stack())
+		}
+	}()
+	fn(ev)
+}
+
+func (w *Watcher) addSpecialPrivileges(ev *SessionEvent) {
+	// Without the logon time, an event of an earlier session with the same
+	// LUID could match.
+	if ev.Data == nil {
+		return
+	}
+	se, err := securitylog.FindSpecialPrivilegesEventContext(w.ctx, ev.LogonId, ev.Data.LogonTime)
+	if err != nil || se == nil {
+		return
+	}
+	ev.AdminLogon = true
+	ev.Privileges = se.Privileges
+}
+
+// poll diffs the current session list against the known sessions and calls
+// emit for every change until emit returns false.
+func (w *Watcher) poll(emit func(SessionEvent) bool) (err error) {
+	ctx, op := lsa.StartOp(w.ctx, "winlsa.Watcher.poll", nil)
+	defer func() { op.End(err) }()
+
+	luids, err := appendSessions(ctx, w.luids[:0])
+	if err != nil && !IsFreeBufferError(err) {
+		return err
+	}
+	w.luids = luids
+	now := time.Now()
+	current := make(map[LUID]bool, len(luids))
+	for _, luid := range luids {
+		if err := w.ctx.Err(); err != nil {
+			return err
+		}
+		current[luid] = true
+		if _, ok := w.known[luid]; ok {
+			continue
+		}
+		sd, err := sessionData(ctx, luid, GetLogonSessionDataOpts{Fields: SessionFieldAll})
+		if err == ErrNoSuchLogonSession {
+			// The session ended before it could be queried.
+			delete(current, luid)
+			continue
+		}
+		w.known[luid] = sd
+		ev := SessionEvent{Type: SessionLogon, Time: now, LogonId: luid, Data: sd}
+		if w.opts.SpecialPrivileges {
+			w.addSpecialPrivileges(&ev)
+		}
+		if !emit(ev) {
+			return nil
+		}
+	}
+	// Report explicit credentials before logoffs, so that the data of
+	// sessions that just ended is still known. If the Security log cannot
+	// be read, the logoffs are still reported and the error is returned
+	// afterwards; lastRecord stays put so the events are read next time.
+	var logErr error
+	if w.opts.ExplicitCredentials {
+		var events []*securitylog.ExplicitCredentialsEvent
+		events, logErr = securitylog.QueryExplicitCredentialsEvents(w.ctx, w.lastRecord)
+		for _, ee := range events {
+			w.lastRecord = ee.RecordID
+			if !emit(newExplicitCredentialsEvent(ee, w.known[ee.SubjectLogonId])) {
+				return nil
+			}
+		}
+	}
+	for luid, sd := range w.known {
+		if current[luid] {
+			continue
+		}
+		delete(w.known, luid)
+		if !emit(SessionEvent{Type: SessionLogoff, Time: now, LogonId: luid, Data: sd}) {
+			return nil
+		}
+	}
+	return logErr
+}
+
+func newExplicitCredentialsEvent(ee *securitylog.ExplicitCredentialsEvent, sd *LogonSessionData) SessionEvent {
+	return SessionEvent{
+		Type:    SessionExplicitCredentials,
+		Time:    ee.Time,
+		LogonId: ee.SubjectLogonId,
+		Data:    sd,
+		ExplicitCredentials: &ExplicitCredentials{
+			RecordID:         ee.RecordID,
+			TargetUserName:   ee.TargetUserName,
+			TargetDomainName: ee.TargetDomainName,
+			TargetServerName: ee.TargetServerName,
+			TargetInfo:       unlessDash(ee.TargetInfo),
+			ProcessId:        ee.ProcessId,
+			ProcessName:      unlessDash(ee.ProcessName),
+			IpAddress:        unlessDash(ee.IpAddress),
+			IpPort:           unlessDash(ee.IpPort),
+		},
+	}
+}
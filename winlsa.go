@@ -1,13 +1,10 @@
 package winlsa
 
 import (
+	"context"
 	"fmt"
-	"reflect"
-	"syscall"
+	"strings"
 	"time"
-	"unsafe"
-
-	"golang.org/x/sys/windows"
 
 	"github.com/cobraqxx/winlsa/internal/lsa"
 )
@@ -18,6 +15,32 @@ import (
 // In the context of winlsa, it is a session identifier.
 type LUID = lsa.LUID
 
+// ParseLUID parses a LUID formatted by LUID.String, i.e. a hexadecimal number
+// with an optional 0x prefix.
+func ParseLUID(s string) (LUID, error) {
+	var luid LUID
+	err := luid.UnmarshalText([]byte(s))
+	return luid, err
+}
+
+// A SID is a security identifier. It is windows.SID on Windows; on other
+// platforms it only holds the string form of a SID.
+type SID = lsa.SID
+
+// ErrUnsupportedPlatform is returned by all functions that query the LSA
+// or the system when the package is built for a platform other than
+// Windows. The types, filters and encodings work everywhere, so that code
+// handling session data, e.g. a client of the server package, can be
+// built and tested on any platform, with a fake Provider standing in for
+// the LSA.
+var ErrUnsupportedPlatform = lsa.ErrUnsupportedPlatform
+
+// ErrNoSuchLogonSession is returned for logon sessions that do not exist,
+// typically because they ended after being enumerated. It is
+// windows.ERROR_NO_SUCH_LOGON_SESSION on Windows; Provider implementations
+// should return it too.
+var ErrNoSuchLogonSession = lsa.ErrNoSuchLogonSession
+
 type LogonType uint32
 
 func (lt LogonType) String() string {
@@ -74,12 +97,13 @@ const (
 )
 
 type LogonSessionData struct {
+	LogonId                                    LUID
 	UserName                                   string
 	LogonDomain                                string
 	AuthenticationPackage                      string
 	LogonType                                  LogonType
 	Session                                    uint32
-	Sid                                        *windows.SID
+	Sid                                        *SID
 	LogonTime                                  time.Time
 	LogonServer                                string
 	DnsDomainName                              string
@@ -97,95 +121,156 @@ type LogonSessionData struct {
 	PasswordLastSet                            time.Time
 	PasswordCanChange                          time.Time
 	PasswordMustChange                         time.Time
+	// RawTimes holds the timestamps as reported by the LSA. It is only set
+	// when requested with SessionFieldRawTimes.
+	RawTimes *RawTimes `json:",omitempty"`
+	// ResolvedAccount is the account the SID currently resolves to. It is
+	// only set when requested with SessionFieldResolvedAccount or by
+	// ResolveAccounts.
+	ResolvedAccount *ResolvedAccount `json:",omitempty"`
+	// RemoteOrigin is the client of a network or remote interactive logon.
+	// It is only set when requested with SessionFieldRemoteOrigin or by
+	// EnrichRemoteOrigin.
+	RemoteOrigin *RemoteOrigin `json:",omitempty"`
+	// Unavailable holds the requested field groups the LSA did not return,
+	// whose fields are left zero. Windows XP and Server 2003 lack
+	// SessionFieldProfile and SessionFieldPolicy, including the last logon
+	// information.
+	Unavailable SessionField `json:",omitempty"`
 }
 
-func newLogonSessionData(data *lsa.SECURITY_LOGON_SESSION_DATA) *LogonSessionData {
-	var sid *windows.SID
-	if data.Sid != nil {
-		sid, _ = data.Sid.Copy()
-	}
-	return &LogonSessionData{
-		UserName:              stringFromLSAString(data.UserName),
-		LogonDomain:           stringFromLSAString(data.LogonDomain),
-		AuthenticationPackage: stringFromLSAString(data.AuthenticationPackage),
-		LogonType:             LogonType(data.LogonType),
-		Session:               data.Session,
-		Sid:                   sid,
-		LogonTime:             timeFromUint64(data.LogonTime),
-		LogonServer:           stringFromLSAString(data.LogonServer),
-		DnsDomainName:         stringFromLSAString(data.DnsDomainName),
-		Upn:                   stringFromLSAString(data.Upn),
-		UserFlags:             data.UserFlags,
-		LogonScript:           stringFromLSAString(data.LogonScript),
-		ProfilePath:           stringFromLSAString(data.ProfilePath),
-		HomeDirectory:         stringFromLSAString(data.HomeDirectory),
-		HomeDirectoryDrive:    stringFromLSAString(data.HomeDirectoryDrive),
-		LogoffTime:            timeFromUint64(data.LogoffTime),
-		KickOffTime:           timeFromUint64(data.KickOffTime),
-		PasswordLastSet:       timeFromUint64(data.PasswordLastSet),
-		PasswordCanChange:     timeFromUint64(data.PasswordCanChange),
-		PasswordMustChange:    timeFromUint64(data.PasswordMustChange),
-		LastSuccessfulLogon:   timeFromUint64(data.LastLogonInfo.LastSuccessfulLogon),
-		LastFailedLogon:       timeFromUint64(data.LastLogonInfo.LastFailedLogon),
-		FailedAttemptCountSinceLastSuccessfulLogon: data.LastLogonInfo.FailedAttemptCountSinceLastSuccessfulLogon,
-	}
+// A FileTime is a timestamp as reported by the LSA, in 100ns intervals
+// since January 1, 1601 UTC.
+type FileTime uint64
+
+// FileTimeNever is the largest FileTime, which the LSA reports for events
+// that will never happen, e.g. PasswordMustChange of a password that does
+// not expire.
+const FileTimeNever FileTime = 0x7FFFFFFFFFFFFFFF
+
+// IsSet reports whether t is not zero, which the LSA reports for unknown
+// or not applicable times.
+func (t FileTime) IsSet() bool {
+	return t != 0
 }
 
-func stringFromLSAString(s lsa.LSA_UNICODE_STRING) string {
-	if s.Buffer == 0 || s.Length == 0 {
-		return ""
-	}
-	var data []uint16
-	sh := (*reflect.SliceHeader)(unsafe.Pointer(&data))
-	sh.Data = s.Buffer
-	sh.Len = int(s.Length)
-	sh.Cap = int(s.Length)
-	return syscall.UTF16ToString(data)
+func (t FileTime) IsNever() bool {
+	return t == FileTimeNever
 }
-func timeFromUint64(nsec uint64) time.Time {
-	if nsec == 0 || nsec == ^uint64(0)>>1 {
-		return time.Time{}
+
+// Time converts t, mapping both zero and FileTimeNever to the zero time.
+func (t FileTime) Time() time.Time {
+	return lsa.TimeFromUint64(uint64(t))
+}
+
+// RawTimes are the timestamps of a logon session as reported by the LSA.
+// Unlike the time.Time fields of LogonSessionData, they tell times that
+// are not set from times that are never reached.
+type RawTimes struct {
+	LogonTime           FileTime
+	LogoffTime          FileTime
+	KickOffTime         FileTime
+	PasswordLastSet     FileTime
+	PasswordCanChange   FileTime
+	PasswordMustChange  FileTime
+	LastSuccessfulLogon FileTime
+	LastFailedLogon     FileTime
+}
+
+// SessionField selects optional groups of LogonSessionData fields for
+// GetLogonSessionDataOpts. LogonId, UserName, LogonDomain,
+// AuthenticationPackage, LogonType, Session and LogonTime are always
+// decoded.
+type SessionField uint32
+
+const (
+	// SessionFieldSid copies Sid.
+	SessionFieldSid SessionField = 1 << iota
+	// SessionFieldDomain decodes LogonServer, DnsDomainName and Upn.
+	SessionFieldDomain
+	// SessionFieldProfile decodes LogonScript, ProfilePath, HomeDirectory
+	// and HomeDirectoryDrive.
+	SessionFieldProfile
+	// SessionFieldPolicy decodes UserFlags, the last logon information,
+	// LogoffTime, KickOffTime and the password times.
+	SessionFieldPolicy
+	// SessionFieldRawTimes sets RawTimes. It is not part of SessionFieldAll.
+	// The raw times of an unavailable SessionFieldPolicy are zero.
+	SessionFieldRawTimes
+	// SessionFieldResolvedAccount sets ResolvedAccount by looking up the
+	// SID, which costs an extra LSA call. It is not part of SessionFieldAll;
+	// use ResolveAccounts to resolve many sessions at once.
+	SessionFieldResolvedAccount
+	// SessionFieldRemoteOrigin sets RemoteOrigin by searching the Security
+	// log, see EnrichRemoteOrigin. It is not part of SessionFieldAll.
+	SessionFieldRemoteOrigin
+
+	// SessionFieldAll selects the fields decoded by GetLogonSessionData.
+	SessionFieldAll = SessionFieldSid | SessionFieldDomain | SessionFieldProfile | SessionFieldPolicy
+)
+
+var sessionFieldNames = []string{"sid", "domain", "profile", "policy", "rawtimes", "resolvedaccount", "remoteorigin"}
+
+// String lists the selected groups, separated by commas.
+func (f SessionField) String() string {
+	var names []string
+	for i, name := range sessionFieldNames {
+		if f&(1<<i) != 0 {
+			names = append(names, name)
+			f &^= 1 << i
+		}
+	}
+	if f != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint32(f)))
 	}
-	const windowsEpoch = 116444736000000000
-	return time.Unix(0, int64(nsec-windowsEpoch)*100)
+	return strings.Join(names, ",")
 }
 
+// GetLogonSessionDataOpts are the options of GetLogonSessionDataWithOpts.
+type GetLogonSessionDataOpts struct {
+	// Fields selects the optional fields to decode. Leaving out fields a
+	// caller does not need saves their string and SID allocations.
+	Fields SessionField
+}
+
+// GetLogonSessions returns the LUIDs of the current logon sessions.
 func GetLogonSessions() ([]LUID, error) {
-	var cnt uint32
-	var buffer uintptr
-	err := lsa.LsaEnumerateLogonSessions(&cnt, &buffer)
-	if err != nil {
-		return nil, err
-	}
+	return AppendLogonSessions(nil)
+}
 
-	var data []LUID
-	sh := (*reflect.SliceHeader)(unsafe.Pointer(&data))
-	sh.Data = buffer
-	sh.Len = int(cnt)
-	sh.Cap = int(cnt)
-	luids := make([]LUID, cnt)
-	for idx := uint32(0); idx < cnt; idx++ {
-		luids[idx] = data[idx]
-	}
+// GetLogonSessionsContext is like GetLogonSessions but starts its
+// operation as a child of the span carried by ctx; see Instrumentation.
+// The enumeration is not interrupted when ctx is done, but it is not
+// retried after.
+func GetLogonSessionsContext(ctx context.Context) ([]LUID, error) {
+	return appendSessions(ctx, nil)
+}
 
-	err = lsa.LsaFreeReturnBuffer(buffer)
-	if err != nil {
-		return nil, err
-	}
-	return luids, nil
+// AppendLogonSessions appends the LUIDs of the current logon sessions to dst
+// and returns the extended slice. The LUIDs are copied straight out of the
+// LSA buffer, so passing a dst with enough spare capacity, e.g. the previous
+// result truncated to zero length, avoids any allocation.
+//
+// If the LSA buffer cannot be freed, the LUIDs are still returned, with a
+// *FreeBufferError; so are the session data of GetLogonSessionData.
+func AppendLogonSessions(dst []LUID) ([]LUID, error) {
+	return appendSessions(context.Background(), dst)
 }
+
 func GetLogonSessionData(luid *LUID) (*LogonSessionData, error) {
-	var dataBuffer *lsa.SECURITY_LOGON_SESSION_DATA
-	err := lsa.LsaGetLogonSessionData(luid, &dataBuffer)
-	if err != nil {
-		return nil, err
-	}
-	sessionData := newLogonSessionData(dataBuffer)
+	return GetLogonSessionDataWithOpts(luid, GetLogonSessionDataOpts{Fields: SessionFieldAll})
+}
 
-	err = lsa.LsaFreeReturnBuffer(uintptr(unsafe.Pointer(dataBuffer)))
-	if err != nil {
-		return nil, err
-	}
+// GetLogonSessionDataWithOpts is like GetLogonSessionData but only decodes
+// the fields selected by opts; the others are left zero.
+func GetLogonSessionDataWithOpts(luid *LUID, opts GetLogonSessionDataOpts) (*LogonSessionData, error) {
+	return sessionData(context.Background(), *luid, opts)
+}
 
-	return sessionData, nil
+// GetLogonSessionDataContext is like GetLogonSessionDataWithOpts but starts
+// its operation as a child of the span carried by ctx; see
+// Instrumentation. The query is not interrupted when ctx is done, but it is
+// not retried after.
+func GetLogonSessionDataContext(ctx context.Context, luid *LUID, opts GetLogonSessionDataOpts) (*LogonSessionData, error) {
+	return sessionData(ctx, *luid, opts)
 }
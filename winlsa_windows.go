@@ -0,0 +1,135 @@
+package winlsa
+
+import (
+	"context"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cobraqxx/winlsa/internal/lsa"
+)
+
+func newLogonSessionData(data *lsa.SECURITY_LOGON_SESSION_DATA, fields SessionField) *LogonSessionData {
+	sd := &LogonSessionData{
+		LogonId:               data.LogonId,
+		UserName:              data.UserName.String(),
+		LogonDomain:           data.LogonDomain.String(),
+		AuthenticationPackage: data.AuthenticationPackage.String(),
+		LogonType:             LogonType(data.LogonType),
+		Session:               data.Session,
+		LogonTime:             lsa.TimeFromUint64(data.LogonTime),
+	}
+	if fields&SessionFieldSid != 0 && data.Sid != nil {
+		sd.Sid, _ = data.Sid.Copy()
+	}
+	// Windows versions before Vista return a shorter structure; zero the
+	// groups it lacks instead of reading past it.
+	decode := func(group SessionField, present bool) bool {
+		if fields&group != 0 && !present {
+			sd.Unavailable |= group
+		}
+		return fields&group != 0 && present
+	}
+	hasPolicy := data.Has(unsafe.Pointer(&data.PasswordMustChange), unsafe.Sizeof(data.PasswordMustChange))
+	if decode(SessionFieldDomain, data.Has(unsafe.Pointer(&data.Upn), unsafe.Sizeof(data.Upn))) {
+		sd.LogonServer = data.LogonServer.String()
+		sd.DnsDomainName = data.DnsDomainName.String()
+		sd.Upn = data.Upn.String()
+	}
+	if decode(SessionFieldProfile, data.Has(unsafe.Pointer(&data.HomeDirectoryDrive), unsafe.Sizeof(data.HomeDirectoryDrive))) {
+		sd.LogonScript = data.LogonScript.String()
+		sd.ProfilePath = data.ProfilePath.String()
+		sd.HomeDirectory = data.HomeDirectory.String()
+		sd.HomeDirectoryDrive = data.HomeDirectoryDrive.String()
+	}
+	if decode(SessionFieldPolicy, hasPolicy) {
+		sd.UserFlags = data.UserFlags
+		sd.LogoffTime = lsa.TimeFromUint64(data.LogoffTime)
+		sd.KickOffTime = lsa.TimeFromUint64(data.KickOffTime)
+		sd.PasswordLastSet = lsa.TimeFromUint64(data.PasswordLastSet)
+		sd.PasswordCanChange = lsa.TimeFromUint64(data.PasswordCanChange)
+		sd.PasswordMustChange = lsa.TimeFromUint64(data.PasswordMustChange)
+		sd.LastSuccessfulLogon = lsa.TimeFromUint64(data.LastLogonInfo.LastSuccessfulLogon)
+		sd.LastFailedLogon = lsa.TimeFromUint64(data.LastLogonInfo.LastFailedLogon)
+		sd.FailedAttemptCountSinceLastSuccessfulLogon = data.LastLogonInfo.FailedAttemptCountSinceLastSuccessfulLogon
+	}
+	if fields&SessionFieldRawTimes != 0 {
+		sd.RawTimes = &RawTimes{LogonTime: FileTime(data.LogonTime)}
+		if hasPolicy {
+			sd.RawTimes.LogoffTime = FileTime(data.LogoffTime)
+			sd.RawTimes.KickOffTime = FileTime(data.KickOffTime)
+			sd.RawTimes.PasswordLastSet = FileTime(data.PasswordLastSet)
+			sd.RawTimes.PasswordCanChange = FileTime(data.PasswordCanChange)
+			sd.RawTimes.PasswordMustChange = FileTime(data.PasswordMustChange)
+			sd.RawTimes.LastSuccessfulLogon = FileTime(data.LastLogonInfo.LastSuccessfulLogon)
+			sd.RawTimes.LastFailedLogon = FileTime(data.LastLogonInfo.LastFailedLogon)
+		}
+	}
+	return sd
+}
+
+func appendLogonSessions(ctx context.Context, dst []LUID) (luids []LUID, err error) {
+	ctx, op := lsa.StartOp(ctx, "winlsa.GetLogonSessions", nil)
+	defer func() { op.End(err) }()
+
+	var cnt uint32
+	var buffer *LUID
+	call := op.StartCall("LsaEnumerateLogonSessions")
+	err = lsa.Retry(ctx, func() error {
+		return lsa.LsaEnumerateLogonSessions(&cnt, &buffer)
+	})
+	call.End(err)
+	if err != nil {
+		return dst, err
+	}
+
+	n := len(dst)
+	if cap(dst)-n < int(cnt) {
+		luids = make([]LUID, n, n+int(cnt))
+		copy(luids, dst)
+	} else {
+		luids = dst
+	}
+	if cnt > 0 {
+		luids = append(luids, unsafe.Slice(buffer, cnt)...)
+	}
+
+	call = op.StartCall("LsaFreeReturnBuffer")
+	err = lsa.FreeReturnBuffer(uintptr(unsafe.Pointer(buffer)))
+	call.End(err)
+	return luids, err
+}
+
+func getLogonSessionData(ctx context.Context, luid *LUID, opts GetLogonSessionDataOpts) (sd *LogonSessionData, err error) {
+	ctx, op := lsa.StartOp(ctx, "winlsa.GetLogonSessionData", luid)
+	defer func() { op.End(err) }()
+
+	var dataBuffer *lsa.SECURITY_LOGON_SESSION_DATA
+	call := op.StartCall("LsaGetLogonSessionData")
+	err = lsa.Retry(ctx, func() error {
+		return lsa.LsaGetLogonSessionData(luid, &dataBuffer)
+	})
+	call.End(err)
+	if err != nil {
+		return nil, err
+	}
+	sessionData := newLogonSessionData(dataBuffer, opts.Fields)
+	if opts.Fields&SessionFieldResolvedAccount != 0 && dataBuffer.Sid != nil {
+		// Resolution is best effort; the session data is still valid if
+		// the lookup fails.
+		names, err := lookupSids([]*windows.SID{dataBuffer.Sid})
+		if err == nil {
+			sessionData.ResolvedAccount = names[0]
+		}
+	}
+
+	call = op.StartCall("LsaFreeReturnBuffer")
+	err = lsa.FreeReturnBuffer(uintptr(unsafe.Pointer(dataBuffer)))
+	call.End(err)
+	if opts.Fields&SessionFieldRemoteOrigin != 0 {
+		// Like the account resolution, this is best effort.
+		EnrichRemoteOriginContext(ctx, sessionData)
+	}
+
+	return sessionData, err
+}
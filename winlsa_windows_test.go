@@ -0,0 +1,35 @@
+package winlsa
+
+import (
+	"context"
+	"testing"
+)
+
+// BenchmarkGetLogonSessions allocates the result of every enumeration.
+func BenchmarkGetLogonSessions(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := appendLogonSessions(context.Background(), nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkAppendLogonSessions reuses the previous result, as Watcher.poll
+// does, and allocates nothing once the slice holds all sessions.
+func BenchmarkAppendLogonSessions(b *testing.B) {
+	b.ReportAllocs()
+	luids, err := appendLogonSessions(context.Background(), nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	// Leave room for sessions that log on during the benchmark.
+	luids = make([]LUID, 0, 2*len(luids)+16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		luids, err = appendLogonSessions(context.Background(), luids[:0])
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
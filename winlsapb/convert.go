@@ -0,0 +1,225 @@
+// Package winlsapb holds the protocol buffer form of the data reported by
+// the winlsa packages, generated from winlsa.proto, and converters from and
+// to the Go types.
+package winlsapb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative winlsa.proto
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/internal/lsa"
+	"github.com/cobraqxx/winlsa/kerberos"
+)
+
+// FromLUID encodes luid as HighPart<<32 | LowPart.
+func FromLUID(luid winlsa.LUID) uint64 {
+	return uint64(uint32(luid.HighPart))<<32 | uint64(luid.LowPart)
+}
+
+// ToLUID decodes a LUID encoded by FromLUID.
+func ToLUID(v uint64) winlsa.LUID {
+	return winlsa.LUID{LowPart: uint32(v), HighPart: int32(v >> 32)}
+}
+
+func fromTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func toTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func FromLogonSessionData(sd *winlsa.LogonSessionData) *LogonSessionData {
+	if sd == nil {
+		return nil
+	}
+	return &LogonSessionData{
+		LogonId:               FromLUID(sd.LogonId),
+		UserName:              sd.UserName,
+		LogonDomain:           sd.LogonDomain,
+		AuthenticationPackage: sd.AuthenticationPackage,
+		LogonType:             LogonType(sd.LogonType),
+		Session:               sd.Session,
+		Sid:                   lsa.SidString(sd.Sid),
+		LogonTime:             fromTime(sd.LogonTime),
+		LogonServer:           sd.LogonServer,
+		DnsDomainName:         sd.DnsDomainName,
+		Upn:                   sd.Upn,
+		UserFlags:             sd.UserFlags,
+		LastSuccessfulLogon:   fromTime(sd.LastSuccessfulLogon),
+		LastFailedLogon:       fromTime(sd.LastFailedLogon),
+		FailedAttemptCountSinceLastSuccessfulLogon: sd.FailedAttemptCountSinceLastSuccessfulLogon,
+		LogonScript:        sd.LogonScript,
+		ProfilePath:        sd.ProfilePath,
+		HomeDirectory:      sd.HomeDirectory,
+		HomeDirectoryDrive: sd.HomeDirectoryDrive,
+		LogoffTime:         fromTime(sd.LogoffTime),
+		KickOffTime:        fromTime(sd.KickOffTime),
+		PasswordLastSet:    fromTime(sd.PasswordLastSet),
+		PasswordCanChange:  fromTime(sd.PasswordCanChange),
+		PasswordMustChange: fromTime(sd.PasswordMustChange),
+	}
+}
+
+// ToLogonSessionData converts m back. It fails only if the SID is invalid.
+func ToLogonSessionData(m *LogonSessionData) (*winlsa.LogonSessionData, error) {
+	if m == nil {
+		return nil, nil
+	}
+	var sid *winlsa.SID
+	if m.Sid != "" {
+		var err error
+		sid, err = lsa.StringToSid(m.Sid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SID %q: %v", m.Sid, err)
+		}
+	}
+	return &winlsa.LogonSessionData{
+		LogonId:               ToLUID(m.LogonId),
+		UserName:              m.UserName,
+		LogonDomain:           m.LogonDomain,
+		AuthenticationPackage: m.AuthenticationPackage,
+		LogonType:             winlsa.LogonType(m.LogonType),
+		Session:               m.Session,
+		Sid:                   sid,
+		LogonTime:             toTime(m.LogonTime),
+		LogonServer:           m.LogonServer,
+		DnsDomainName:         m.DnsDomainName,
+		Upn:                   m.Upn,
+		UserFlags:             m.UserFlags,
+		LastSuccessfulLogon:   toTime(m.LastSuccessfulLogon),
+		LastFailedLogon:       toTime(m.LastFailedLogon),
+		FailedAttemptCountSinceLastSuccessfulLogon: m.FailedAttemptCountSinceLastSuccessfulLogon,
+		LogonScript:        m.LogonScript,
+		ProfilePath:        m.ProfilePath,
+		HomeDirectory:      m.HomeDirectory,
+		HomeDirectoryDrive: m.HomeDirectoryDrive,
+		LogoffTime:         toTime(m.LogoffTime),
+		KickOffTime:        toTime(m.KickOffTime),
+		PasswordLastSet:    toTime(m.PasswordLastSet),
+		PasswordCanChange:  toTime(m.PasswordCanChange),
+		PasswordMustChange: toTime(m.PasswordMustChange),
+	}, nil
+}
+
+func FromSessionEvent(ev winlsa.SessionEvent) *SessionEvent {
+	return &SessionEvent{
+		Type:                SessionEvent_Type(ev.Type),
+		Time:                fromTime(ev.Time),
+		LogonId:             FromLUID(ev.LogonId),
+		Data:                FromLogonSessionData(ev.Data),
+		AdminLogon:          ev.AdminLogon,
+		Privileges:          ev.Privileges,
+		ExplicitCredentials: FromExplicitCredentials(ev.ExplicitCredentials),
+	}
+}
+
+func ToSessionEvent(m *SessionEvent) (winlsa.SessionEvent, error) {
+	data, err := ToLogonSessionData(m.Data)
+	if err != nil {
+		return winlsa.SessionEvent{}, err
+	}
+	return winlsa.SessionEvent{
+		Type:                winlsa.SessionEventType(m.Type),
+		Time:                toTime(m.Time),
+		LogonId:             ToLUID(m.LogonId),
+		Data:                data,
+		AdminLogon:          m.AdminLogon,
+		Privileges:          m.Privileges,
+		ExplicitCredentials: ToExplicitCredentials(m.ExplicitCredentials),
+	}, nil
+}
+
+func FromExplicitCredentials(ec *winlsa.ExplicitCredentials) *ExplicitCredentials {
+	if ec == nil {
+		return nil
+	}
+	return &ExplicitCredentials{
+		RecordId:         ec.RecordID,
+		TargetUserName:   ec.TargetUserName,
+		TargetDomainName: ec.TargetDomainName,
+		TargetServerName: ec.TargetServerName,
+		TargetInfo:       ec.TargetInfo,
+		ProcessId:        ec.ProcessId,
+		ProcessName:      ec.ProcessName,
+		IpAddress:        ec.IpAddress,
+		IpPort:           ec.IpPort,
+	}
+}
+
+func ToExplicitCredentials(m *ExplicitCredentials) *winlsa.ExplicitCredentials {
+	if m == nil {
+		return nil
+	}
+	return &winlsa.ExplicitCredentials{
+		RecordID:         m.RecordId,
+		TargetUserName:   m.TargetUserName,
+		TargetDomainName: m.TargetDomainName,
+		TargetServerName: m.TargetServerName,
+		TargetInfo:       m.TargetInfo,
+		ProcessId:        m.ProcessId,
+		ProcessName:      m.ProcessName,
+		IpAddress:        m.IpAddress,
+		IpPort:           m.IpPort,
+	}
+}
+
+func FromTicketCacheInfo(t *kerberos.TicketCacheInfo) *TicketCacheInfo {
+	return &TicketCacheInfo{
+		ClientName:     t.ClientName,
+		ClientRealm:    t.ClientRealm,
+		ServerName:     t.ServerName,
+		ServerRealm:    t.ServerRealm,
+		StartTime:      fromTime(t.StartTime),
+		EndTime:        fromTime(t.EndTime),
+		RenewTime:      fromTime(t.RenewTime),
+		EncryptionType: int32(t.EncryptionType),
+		TicketFlags:    uint32(t.TicketFlags),
+		SessionKeyType: int32(t.SessionKeyType),
+		BranchId:       t.BranchId,
+	}
+}
+
+func ToTicketCacheInfo(m *TicketCacheInfo) kerberos.TicketCacheInfo {
+	return kerberos.TicketCacheInfo{
+		ClientName:     m.ClientName,
+		ClientRealm:    m.ClientRealm,
+		ServerName:     m.ServerName,
+		ServerRealm:    m.ServerRealm,
+		StartTime:      toTime(m.StartTime),
+		EndTime:        toTime(m.EndTime),
+		RenewTime:      toTime(m.RenewTime),
+		EncryptionType: kerberos.EncryptionType(m.EncryptionType),
+		TicketFlags:    kerberos.TicketFlags(m.TicketFlags),
+		SessionKeyType: kerberos.EncryptionType(m.SessionKeyType),
+		BranchId:       m.BranchId,
+	}
+}
+
+// FromSessionTickets converts the ticket cache of the logon session luid.
+func FromSessionTickets(luid winlsa.LUID, tickets []kerberos.TicketCacheInfo) *SessionTickets {
+	m := &SessionTickets{LogonId: FromLUID(luid)}
+	for idx := range tickets {
+		m.Tickets = append(m.Tickets, FromTicketCacheInfo(&tickets[idx]))
+	}
+	return m
+}
+
+func ToSessionTickets(m *SessionTickets) (winlsa.LUID, []kerberos.TicketCacheInfo) {
+	var tickets []kerberos.TicketCacheInfo
+	for _, t := range m.Tickets {
+		tickets = append(tickets, ToTicketCacheInfo(t))
+	}
+	return ToLUID(m.LogonId), tickets
+}
@@ -0,0 +1,79 @@
+package winlsapb_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/cobraqxx/winlsa"
+	"github.com/cobraqxx/winlsa/winlsapb"
+)
+
+func TestSessionEventRoundTrip(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, ev := range []winlsa.SessionEvent{{
+		Type:    winlsa.SessionLogon,
+		Time:    at,
+		LogonId: winlsa.LUID{LowPart: 0x1234, HighPart: 1},
+		Data: &winlsa.LogonSessionData{
+			LogonId:   winlsa.LUID{LowPart: 0x1234, HighPart: 1},
+			UserName:  "alice",
+			LogonType: winlsa.LogonTypeInteractive,
+			LogonTime: at.Add(-time.Second),
+		},
+		AdminLogon: true,
+		Privileges: []string{"SeDebugPrivilege", "SeBackupPrivilege"},
+	}, {
+		Type:    winlsa.SessionLogoff,
+		Time:    at,
+		LogonId: winlsa.LUID{LowPart: 0x5678},
+	}, {
+		Type:    winlsa.SessionExplicitCredentials,
+		Time:    at,
+		LogonId: winlsa.LUID{LowPart: 0x1234},
+		ExplicitCredentials: &winlsa.ExplicitCredentials{
+			RecordID:         42,
+			TargetUserName:   "admin",
+			TargetDomainName: "CONTOSO",
+			TargetServerName: "fs01.contoso.com",
+			TargetInfo:       "cifs/fs01.contoso.com",
+			ProcessId:        4242,
+			ProcessName:      `C:\Windows\System32\runas.exe`,
+			IpAddress:        "10.0.0.5",
+			IpPort:           "445",
+		},
+	}} {
+		b, err := proto.Marshal(winlsapb.FromSessionEvent(ev))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m winlsapb.SessionEvent
+		if err := proto.Unmarshal(b, &m); err != nil {
+			t.Fatal(err)
+		}
+		got, err := winlsapb.ToSessionEvent(&m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, ev) {
+			t.Errorf("%v event round trips to\n%+v\nwant\n%+v", ev.Type, got, ev)
+		}
+	}
+}
+
+func TestSessionEventTypes(t *testing.T) {
+	for _, tt := range []struct {
+		typ  winlsa.SessionEventType
+		want winlsapb.SessionEvent_Type
+	}{
+		{winlsa.SessionLogon, winlsapb.SessionEvent_TYPE_LOGON},
+		{winlsa.SessionLogoff, winlsapb.SessionEvent_TYPE_LOGOFF},
+		{winlsa.SessionExplicitCredentials, winlsapb.SessionEvent_TYPE_EXPLICIT_CREDENTIALS},
+	} {
+		if got := winlsapb.FromSessionEvent(winlsa.SessionEvent{Type: tt.typ}).Type; got != tt.want {
+			t.Errorf("the type of a %v event is %v, want %v", tt.typ, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,1125 @@
+// Protocol buffer definitions of the data winlsa reports, for consumers of
+// the broker and webhook output in other languages.
+//
+// Times are unset rather than zero when Windows does not report them. LUIDs
+// are encoded as HighPart<<32 | LowPart, which matches the hexadecimal form
+// printed by winlsa.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        (unknown)
+// source: winlsa.proto
+
+package winlsapb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// LogonType values equal the Windows SECURITY_LOGON_TYPE values.
+type LogonType int32
+
+const (
+	LogonType_LOGON_TYPE_SYSTEM                    LogonType = 0
+	LogonType_LOGON_TYPE_INTERACTIVE               LogonType = 2
+	LogonType_LOGON_TYPE_NETWORK                   LogonType = 3
+	LogonType_LOGON_TYPE_BATCH                     LogonType = 4
+	LogonType_LOGON_TYPE_SERVICE                   LogonType = 5
+	LogonType_LOGON_TYPE_PROXY                     LogonType = 6
+	LogonType_LOGON_TYPE_UNLOCK                    LogonType = 7
+	LogonType_LOGON_TYPE_NETWORK_CLEARTEXT         LogonType = 8
+	LogonType_LOGON_TYPE_NEW_CREDENTIALS           LogonType = 9
+	LogonType_LOGON_TYPE_REMOTE_INTERACTIVE        LogonType = 10
+	LogonType_LOGON_TYPE_CACHED_INTERACTIVE        LogonType = 11
+	LogonType_LOGON_TYPE_CACHED_REMOTE_INTERACTIVE LogonType = 12
+	LogonType_LOGON_TYPE_CACHED_UNLOCK             LogonType = 13
+)
+
+// Enum value maps for LogonType.
+var (
+	LogonType_name = map[int32]string{
+		0:  "LOGON_TYPE_SYSTEM",
+		2:  "LOGON_TYPE_INTERACTIVE",
+		3:  "LOGON_TYPE_NETWORK",
+		4:  "LOGON_TYPE_BATCH",
+		5:  "LOGON_TYPE_SERVICE",
+		6:  "LOGON_TYPE_PROXY",
+		7:  "LOGON_TYPE_UNLOCK",
+		8:  "LOGON_TYPE_NETWORK_CLEARTEXT",
+		9:  "LOGON_TYPE_NEW_CREDENTIALS",
+		10: "LOGON_TYPE_REMOTE_INTERACTIVE",
+		11: "LOGON_TYPE_CACHED_INTERACTIVE",
+		12: "LOGON_TYPE_CACHED_REMOTE_INTERACTIVE",
+		13: "LOGON_TYPE_CACHED_UNLOCK",
+	}
+	LogonType_value = map[string]int32{
+		"LOGON_TYPE_SYSTEM":                    0,
+		"LOGON_TYPE_INTERACTIVE":               2,
+		"LOGON_TYPE_NETWORK":                   3,
+		"LOGON_TYPE_BATCH":                     4,
+		"LOGON_TYPE_SERVICE":                   5,
+		"LOGON_TYPE_PROXY":                     6,
+		"LOGON_TYPE_UNLOCK":                    7,
+		"LOGON_TYPE_NETWORK_CLEARTEXT":         8,
+		"LOGON_TYPE_NEW_CREDENTIALS":           9,
+		"LOGON_TYPE_REMOTE_INTERACTIVE":        10,
+		"LOGON_TYPE_CACHED_INTERACTIVE":        11,
+		"LOGON_TYPE_CACHED_REMOTE_INTERACTIVE": 12,
+		"LOGON_TYPE_CACHED_UNLOCK":             13,
+	}
+)
+
+func (x LogonType) Enum() *LogonType {
+	p := new(LogonType)
+	*p = x
+	return p
+}
+
+func (x LogonType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LogonType) Descriptor() protoreflect.EnumDescriptor {
+	return file_winlsa_proto_enumTypes[0].Descriptor()
+}
+
+func (LogonType) Type() protoreflect.EnumType {
+	return &file_winlsa_proto_enumTypes[0]
+}
+
+func (x LogonType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LogonType.Descriptor instead.
+func (LogonType) EnumDescriptor() ([]byte, []int) {
+	return file_winlsa_proto_rawDescGZIP(), []int{0}
+}
+
+type SessionEvent_Type int32
+
+const (
+	SessionEvent_TYPE_UNSPECIFIED          SessionEvent_Type = 0
+	SessionEvent_TYPE_LOGON                SessionEvent_Type = 1
+	SessionEvent_TYPE_LOGOFF               SessionEvent_Type = 2
+	SessionEvent_TYPE_EXPLICIT_CREDENTIALS SessionEvent_Type = 3
+)
+
+// Enum value maps for SessionEvent_Type.
+var (
+	SessionEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_LOGON",
+		2: "TYPE_LOGOFF",
+		3: "TYPE_EXPLICIT_CREDENTIALS",
+	}
+	SessionEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED":          0,
+		"TYPE_LOGON":                1,
+		"TYPE_LOGOFF":               2,
+		"TYPE_EXPLICIT_CREDENTIALS": 3,
+	}
+)
+
+func (x SessionEvent_Type) Enum() *SessionEvent_Type {
+	p := new(SessionEvent_Type)
+	*p = x
+	return p
+}
+
+func (x SessionEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SessionEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_winlsa_proto_enumTypes[1].Descriptor()
+}
+
+func (SessionEvent_Type) Type() protoreflect.EnumType {
+	return &file_winlsa_proto_enumTypes[1]
+}
+
+func (x SessionEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SessionEvent_Type.Descriptor instead.
+func (SessionEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_winlsa_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type LogonSessionData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LogonId               uint64    `protobuf:"varint,1,opt,name=logon_id,json=logonId,proto3" json:"logon_id,omitempty"`
+	UserName              string    `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	LogonDomain           string    `protobuf:"bytes,3,opt,name=logon_domain,json=logonDomain,proto3" json:"logon_domain,omitempty"`
+	AuthenticationPackage string    `protobuf:"bytes,4,opt,name=authentication_package,json=authenticationPackage,proto3" json:"authentication_package,omitempty"`
+	LogonType             LogonType `protobuf:"varint,5,opt,name=logon_type,json=logonType,proto3,enum=winlsa.v1.LogonType" json:"logon_type,omitempty"`
+	Session               uint32    `protobuf:"varint,6,opt,name=session,proto3" json:"session,omitempty"`
+	// The user SID in string form, e.g. S-1-5-18.
+	Sid                                        string                 `protobuf:"bytes,7,opt,name=sid,proto3" json:"sid,omitempty"`
+	LogonTime                                  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=logon_time,json=logonTime,proto3" json:"logon_time,omitempty"`
+	LogonServer                                string                 `protobuf:"bytes,9,opt,name=logon_server,json=logonServer,proto3" json:"logon_server,omitempty"`
+	DnsDomainName                              string                 `protobuf:"bytes,10,opt,name=dns_domain_name,json=dnsDomainName,proto3" json:"dns_domain_name,omitempty"`
+	Upn                                        string                 `protobuf:"bytes,11,opt,name=upn,proto3" json:"upn,omitempty"`
+	UserFlags                                  uint32                 `protobuf:"varint,12,opt,name=user_flags,json=userFlags,proto3" json:"user_flags,omitempty"`
+	LastSuccessfulLogon                        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=last_successful_logon,json=lastSuccessfulLogon,proto3" json:"last_successful_logon,omitempty"`
+	LastFailedLogon                            *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=last_failed_logon,json=lastFailedLogon,proto3" json:"last_failed_logon,omitempty"`
+	FailedAttemptCountSinceLastSuccessfulLogon uint32                 `protobuf:"varint,15,opt,name=failed_attempt_count_since_last_successful_logon,json=failedAttemptCountSinceLastSuccessfulLogon,proto3" json:"failed_attempt_count_since_last_successful_logon,omitempty"`
+	LogonScript                                string                 `protobuf:"bytes,16,opt,name=logon_script,json=logonScript,proto3" json:"logon_script,omitempty"`
+	ProfilePath                                string                 `protobuf:"bytes,17,opt,name=profile_path,json=profilePath,proto3" json:"profile_path,omitempty"`
+	HomeDirectory                              string                 `protobuf:"bytes,18,opt,name=home_directory,json=homeDirectory,proto3" json:"home_directory,omitempty"`
+	HomeDirectoryDrive                         string                 `protobuf:"bytes,19,opt,name=home_directory_drive,json=homeDirectoryDrive,proto3" json:"home_directory_drive,omitempty"`
+	LogoffTime                                 *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=logoff_time,json=logoffTime,proto3" json:"logoff_time,omitempty"`
+	KickOffTime                                *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=kick_off_time,json=kickOffTime,proto3" json:"kick_off_time,omitempty"`
+	PasswordLastSet                            *timestamppb.Timestamp `protobuf:"bytes,22,opt,name=password_last_set,json=passwordLastSet,proto3" json:"password_last_set,omitempty"`
+	PasswordCanChange                          *timestamppb.Timestamp `protobuf:"bytes,23,opt,name=password_can_change,json=passwordCanChange,proto3" json:"password_can_change,omitempty"`
+	PasswordMustChange                         *timestamppb.Timestamp `protobuf:"bytes,24,opt,name=password_must_change,json=passwordMustChange,proto3" json:"password_must_change,omitempty"`
+}
+
+func (x *LogonSessionData) Reset() {
+	*x = LogonSessionData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_winlsa_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogonSessionData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogonSessionData) ProtoMessage() {}
+
+func (x *LogonSessionData) ProtoReflect() protoreflect.Message {
+	mi := &file_winlsa_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogonSessionData.ProtoReflect.Descriptor instead.
+func (*LogonSessionData) Descriptor() ([]byte, []int) {
+	return file_winlsa_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LogonSessionData) GetLogonId() uint64 {
+	if x != nil {
+		return x.LogonId
+	}
+	return 0
+}
+
+func (x *LogonSessionData) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *LogonSessionData) GetLogonDomain() string {
+	if x != nil {
+		return x.LogonDomain
+	}
+	return ""
+}
+
+func (x *LogonSessionData) GetAuthenticationPackage() string {
+	if x != nil {
+		return x.AuthenticationPackage
+	}
+	return ""
+}
+
+func (x *LogonSessionData) GetLogonType() LogonType {
+	if x != nil {
+		return x.LogonType
+	}
+	return LogonType_LOGON_TYPE_SYSTEM
+}
+
+func (x *LogonSessionData) GetSession() uint32 {
+	if x != nil {
+		return x.Session
+	}
+	return 0
+}
+
+func (x *LogonSessionData) GetSid() string {
+	if x != nil {
+		return x.Sid
+	}
+	return ""
+}
+
+func (x *LogonSessionData) GetLogonTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LogonTime
+	}
+	return nil
+}
+
+func (x *LogonSessionData) GetLogonServer() string {
+	if x != nil {
+		return x.LogonServer
+	}
+	return ""
+}
+
+func (x *LogonSessionData) GetDnsDomainName() string {
+	if x != nil {
+		return x.DnsDomainName
+	}
+	return ""
+}
+
+func (x *LogonSessionData) GetUpn() string {
+	if x != nil {
+		return x.Upn
+	}
+	return ""
+}
+
+func (x *LogonSessionData) GetUserFlags() uint32 {
+	if x != nil {
+		return x.UserFlags
+	}
+	return 0
+}
+
+func (x *LogonSessionData) GetLastSuccessfulLogon() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSuccessfulLogon
+	}
+	return nil
+}
+
+func (x *LogonSessionData) GetLastFailedLogon() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastFailedLogon
+	}
+	return nil
+}
+
+func (x *LogonSessionData) GetFailedAttemptCountSinceLastSuccessfulLogon() uint32 {
+	if x != nil {
+		return x.FailedAttemptCountSinceLastSuccessfulLogon
+	}
+	return 0
+}
+
+func (x *LogonSessionData) GetLogonScript() string {
+	if x != nil {
+		return x.LogonScript
+	}
+	return ""
+}
+
+func (x *LogonSessionData) GetProfilePath() string {
+	if x != nil {
+		return x.ProfilePath
+	}
+	return ""
+}
+
+func (x *LogonSessionData) GetHomeDirectory() string {
+	if x != nil {
+		return x.HomeDirectory
+	}
+	return ""
+}
+
+func (x *LogonSessionData) GetHomeDirectoryDrive() string {
+	if x != nil {
+		return x.HomeDirectoryDrive
+	}
+	return ""
+}
+
+func (x *LogonSessionData) GetLogoffTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LogoffTime
+	}
+	return nil
+}
+
+func (x *LogonSessionData) GetKickOffTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.KickOffTime
+	}
+	return nil
+}
+
+func (x *LogonSessionData) GetPasswordLastSet() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PasswordLastSet
+	}
+	return nil
+}
+
+func (x *LogonSessionData) GetPasswordCanChange() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PasswordCanChange
+	}
+	return nil
+}
+
+func (x *LogonSessionData) GetPasswordMustChange() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PasswordMustChange
+	}
+	return nil
+}
+
+type SessionEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type SessionEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=winlsa.v1.SessionEvent_Type" json:"type,omitempty"`
+	// When the watcher observed the change, or when the 4648 event of an
+	// explicit credentials event was written.
+	Time    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	LogonId uint64                 `protobuf:"varint,3,opt,name=logon_id,json=logonId,proto3" json:"logon_id,omitempty"`
+	// Unset if the session could not be queried.
+	Data *LogonSessionData `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// Whether the Security log holds a 4672 event for the session, and the
+	// special privileges it lists. Only set on logon events.
+	AdminLogon bool     `protobuf:"varint,5,opt,name=admin_logon,json=adminLogon,proto3" json:"admin_logon,omitempty"`
+	Privileges []string `protobuf:"bytes,6,rep,name=privileges,proto3" json:"privileges,omitempty"`
+	// Set on TYPE_EXPLICIT_CREDENTIALS events.
+	ExplicitCredentials *ExplicitCredentials `protobuf:"bytes,7,opt,name=explicit_credentials,json=explicitCredentials,proto3" json:"explicit_credentials,omitempty"`
+}
+
+func (x *SessionEvent) Reset() {
+	*x = SessionEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_winlsa_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionEvent) ProtoMessage() {}
+
+func (x *SessionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_winlsa_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionEvent.ProtoReflect.Descriptor instead.
+func (*SessionEvent) Descriptor() ([]byte, []int) {
+	return file_winlsa_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SessionEvent) GetType() SessionEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return SessionEvent_TYPE_UNSPECIFIED
+}
+
+func (x *SessionEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *SessionEvent) GetLogonId() uint64 {
+	if x != nil {
+		return x.LogonId
+	}
+	return 0
+}
+
+func (x *SessionEvent) GetData() *LogonSessionData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SessionEvent) GetAdminLogon() bool {
+	if x != nil {
+		return x.AdminLogon
+	}
+	return false
+}
+
+func (x *SessionEvent) GetPrivileges() []string {
+	if x != nil {
+		return x.Privileges
+	}
+	return nil
+}
+
+func (x *SessionEvent) GetExplicitCredentials() *ExplicitCredentials {
+	if x != nil {
+		return x.ExplicitCredentials
+	}
+	return nil
+}
+
+// A use of credentials other than the session's, as recorded by a 4648
+// Security log event.
+type ExplicitCredentials struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecordId         uint64 `protobuf:"varint,1,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	TargetUserName   string `protobuf:"bytes,2,opt,name=target_user_name,json=targetUserName,proto3" json:"target_user_name,omitempty"`
+	TargetDomainName string `protobuf:"bytes,3,opt,name=target_domain_name,json=targetDomainName,proto3" json:"target_domain_name,omitempty"`
+	TargetServerName string `protobuf:"bytes,4,opt,name=target_server_name,json=targetServerName,proto3" json:"target_server_name,omitempty"`
+	TargetInfo       string `protobuf:"bytes,5,opt,name=target_info,json=targetInfo,proto3" json:"target_info,omitempty"`
+	ProcessId        uint32 `protobuf:"varint,6,opt,name=process_id,json=processId,proto3" json:"process_id,omitempty"`
+	ProcessName      string `protobuf:"bytes,7,opt,name=process_name,json=processName,proto3" json:"process_name,omitempty"`
+	IpAddress        string `protobuf:"bytes,8,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	IpPort           string `protobuf:"bytes,9,opt,name=ip_port,json=ipPort,proto3" json:"ip_port,omitempty"`
+}
+
+func (x *ExplicitCredentials) Reset() {
+	*x = ExplicitCredentials{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_winlsa_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExplicitCredentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplicitCredentials) ProtoMessage() {}
+
+func (x *ExplicitCredentials) ProtoReflect() protoreflect.Message {
+	mi := &file_winlsa_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplicitCredentials.ProtoReflect.Descriptor instead.
+func (*ExplicitCredentials) Descriptor() ([]byte, []int) {
+	return file_winlsa_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ExplicitCredentials) GetRecordId() uint64 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+func (x *ExplicitCredentials) GetTargetUserName() string {
+	if x != nil {
+		return x.TargetUserName
+	}
+	return ""
+}
+
+func (x *ExplicitCredentials) GetTargetDomainName() string {
+	if x != nil {
+		return x.TargetDomainName
+	}
+	return ""
+}
+
+func (x *ExplicitCredentials) GetTargetServerName() string {
+	if x != nil {
+		return x.TargetServerName
+	}
+	return ""
+}
+
+func (x *ExplicitCredentials) GetTargetInfo() string {
+	if x != nil {
+		return x.TargetInfo
+	}
+	return ""
+}
+
+func (x *ExplicitCredentials) GetProcessId() uint32 {
+	if x != nil {
+		return x.ProcessId
+	}
+	return 0
+}
+
+func (x *ExplicitCredentials) GetProcessName() string {
+	if x != nil {
+		return x.ProcessName
+	}
+	return ""
+}
+
+func (x *ExplicitCredentials) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *ExplicitCredentials) GetIpPort() string {
+	if x != nil {
+		return x.IpPort
+	}
+	return ""
+}
+
+// A ticket in a logon session's Kerberos ticket cache.
+type TicketCacheInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientName  string                 `protobuf:"bytes,1,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	ClientRealm string                 `protobuf:"bytes,2,opt,name=client_realm,json=clientRealm,proto3" json:"client_realm,omitempty"`
+	ServerName  string                 `protobuf:"bytes,3,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`
+	ServerRealm string                 `protobuf:"bytes,4,opt,name=server_realm,json=serverRealm,proto3" json:"server_realm,omitempty"`
+	StartTime   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	RenewTime   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=renew_time,json=renewTime,proto3" json:"renew_time,omitempty"`
+	// Kerberos etype numbers, e.g. 18 for AES256-CTS-HMAC-SHA1-96.
+	EncryptionType int32 `protobuf:"varint,8,opt,name=encryption_type,json=encryptionType,proto3" json:"encryption_type,omitempty"`
+	// The ticket flags as in the KerbTicketFlags field of KDC messages.
+	TicketFlags    uint32 `protobuf:"varint,9,opt,name=ticket_flags,json=ticketFlags,proto3" json:"ticket_flags,omitempty"`
+	SessionKeyType int32  `protobuf:"varint,10,opt,name=session_key_type,json=sessionKeyType,proto3" json:"session_key_type,omitempty"`
+	BranchId       uint32 `protobuf:"varint,11,opt,name=branch_id,json=branchId,proto3" json:"branch_id,omitempty"`
+}
+
+func (x *TicketCacheInfo) Reset() {
+	*x = TicketCacheInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_winlsa_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TicketCacheInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketCacheInfo) ProtoMessage() {}
+
+func (x *TicketCacheInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_winlsa_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketCacheInfo.ProtoReflect.Descriptor instead.
+func (*TicketCacheInfo) Descriptor() ([]byte, []int) {
+	return file_winlsa_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TicketCacheInfo) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *TicketCacheInfo) GetClientRealm() string {
+	if x != nil {
+		return x.ClientRealm
+	}
+	return ""
+}
+
+func (x *TicketCacheInfo) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
+func (x *TicketCacheInfo) GetServerRealm() string {
+	if x != nil {
+		return x.ServerRealm
+	}
+	return ""
+}
+
+func (x *TicketCacheInfo) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *TicketCacheInfo) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *TicketCacheInfo) GetRenewTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RenewTime
+	}
+	return nil
+}
+
+func (x *TicketCacheInfo) GetEncryptionType() int32 {
+	if x != nil {
+		return x.EncryptionType
+	}
+	return 0
+}
+
+func (x *TicketCacheInfo) GetTicketFlags() uint32 {
+	if x != nil {
+		return x.TicketFlags
+	}
+	return 0
+}
+
+func (x *TicketCacheInfo) GetSessionKeyType() int32 {
+	if x != nil {
+		return x.SessionKeyType
+	}
+	return 0
+}
+
+func (x *TicketCacheInfo) GetBranchId() uint32 {
+	if x != nil {
+		return x.BranchId
+	}
+	return 0
+}
+
+type SessionTickets struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LogonId uint64             `protobuf:"varint,1,opt,name=logon_id,json=logonId,proto3" json:"logon_id,omitempty"`
+	Tickets []*TicketCacheInfo `protobuf:"bytes,2,rep,name=tickets,proto3" json:"tickets,omitempty"`
+}
+
+func (x *SessionTickets) Reset() {
+	*x = SessionTickets{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_winlsa_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionTickets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionTickets) ProtoMessage() {}
+
+func (x *SessionTickets) ProtoReflect() protoreflect.Message {
+	mi := &file_winlsa_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionTickets.ProtoReflect.Descriptor instead.
+func (*SessionTickets) Descriptor() ([]byte, []int) {
+	return file_winlsa_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SessionTickets) GetLogonId() uint64 {
+	if x != nil {
+		return x.LogonId
+	}
+	return 0
+}
+
+func (x *SessionTickets) GetTickets() []*TicketCacheInfo {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+var File_winlsa_proto protoreflect.FileDescriptor
+
+var file_winlsa_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x77, 0x69, 0x6e, 0x6c, 0x73, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09,
+	0x77, 0x69, 0x6e, 0x6c, 0x73, 0x61, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb8, 0x09, 0x0a, 0x10, 0x4c,
+	0x6f, 0x67, 0x6f, 0x6e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x67, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x07, 0x6c, 0x6f, 0x67, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x6f, 0x67, 0x6f, 0x6e,
+	0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c,
+	0x6f, 0x67, 0x6f, 0x6e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x35, 0x0a, 0x16, 0x61, 0x75,
+	0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x61, 0x75, 0x74, 0x68,
+	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x12, 0x33, 0x0a, 0x0a, 0x6c, 0x6f, 0x67, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x77, 0x69, 0x6e, 0x6c, 0x73, 0x61, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x6c, 0x6f, 0x67,
+	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73,
+	0x69, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x6c, 0x6f, 0x67, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x6c, 0x6f, 0x67, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x6c, 0x6f, 0x67, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x6f, 0x67, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x12, 0x26, 0x0a, 0x0f, 0x64, 0x6e, 0x73, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x6e, 0x73, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x70, 0x6e, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x70, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
+	0x75, 0x73, 0x65, 0x72, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x4e, 0x0a, 0x15, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x5f, 0x6c, 0x6f, 0x67,
+	0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x66, 0x75, 0x6c, 0x4c, 0x6f, 0x67, 0x6f, 0x6e, 0x12, 0x46, 0x0a, 0x11, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x6c, 0x6f, 0x67, 0x6f, 0x6e, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4c, 0x6f, 0x67, 0x6f,
+	0x6e, 0x12, 0x64, 0x0a, 0x30, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x74, 0x65,
+	0x6d, 0x70, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x5f,
+	0x6c, 0x6f, 0x67, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x2a, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x53,
+	0x69, 0x6e, 0x63, 0x65, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66,
+	0x75, 0x6c, 0x4c, 0x6f, 0x67, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x6f, 0x67, 0x6f, 0x6e,
+	0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c,
+	0x6f, 0x67, 0x6f, 0x6e, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x25, 0x0a,
+	0x0e, 0x68, 0x6f, 0x6d, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18,
+	0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x68, 0x6f, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x79, 0x12, 0x30, 0x0a, 0x14, 0x68, 0x6f, 0x6d, 0x65, 0x5f, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x64, 0x72, 0x69, 0x76, 0x65, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x12, 0x68, 0x6f, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x79, 0x44, 0x72, 0x69, 0x76, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x6f, 0x66, 0x66,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x6f, 0x66, 0x66, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x6b, 0x69, 0x63, 0x6b, 0x5f, 0x6f, 0x66, 0x66, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6b, 0x69, 0x63, 0x6b, 0x4f, 0x66, 0x66, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x46, 0x0a, 0x11, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0f, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x74, 0x12, 0x4a, 0x0a, 0x13, 0x70,
+	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x63, 0x61, 0x6e, 0x5f, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x11, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x43, 0x61,
+	0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x4c, 0x0a, 0x14, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x5f, 0x6d, 0x75, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18,
+	0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x12, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4d, 0x75, 0x73, 0x74, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0xae, 0x03, 0x0a, 0x0c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x77, 0x69, 0x6e, 0x6c, 0x73, 0x61, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x67, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x6c, 0x6f, 0x67, 0x6f,
+	0x6e, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x69, 0x6e, 0x6c, 0x73, 0x61, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f,
+	0x67, 0x6f, 0x6e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x6f,
+	0x67, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x4c, 0x6f, 0x67, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65,
+	0x67, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x69,
+	0x6c, 0x65, 0x67, 0x65, 0x73, 0x12, 0x51, 0x0a, 0x14, 0x65, 0x78, 0x70, 0x6c, 0x69, 0x63, 0x69,
+	0x74, 0x5f, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x77, 0x69, 0x6e, 0x6c, 0x73, 0x61, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x78, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x61, 0x6c, 0x73, 0x52, 0x13, 0x65, 0x78, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x43, 0x72, 0x65,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x22, 0x5c, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c,
+	0x4f, 0x47, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c,
+	0x4f, 0x47, 0x4f, 0x46, 0x46, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x45, 0x58, 0x50, 0x4c, 0x49, 0x43, 0x49, 0x54, 0x5f, 0x43, 0x52, 0x45, 0x44, 0x45, 0x4e, 0x54,
+	0x49, 0x41, 0x4c, 0x53, 0x10, 0x03, 0x22, 0xd3, 0x02, 0x0a, 0x13, 0x45, 0x78, 0x70, 0x6c, 0x69,
+	0x63, 0x69, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x55, 0x73, 0x65,
+	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f,
+	0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x69, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x49,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x70, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x70, 0x50, 0x6f, 0x72, 0x74, 0x22, 0xd9, 0x03, 0x0a,
+	0x0f, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x61, 0x6c,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x61, 0x6c, 0x6d, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f,
+	0x72, 0x65, 0x61, 0x6c, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x52, 0x65, 0x61, 0x6c, 0x6d, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x72, 0x65,
+	0x6e, 0x65, 0x77, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x72, 0x65, 0x6e, 0x65,
+	0x77, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x67,
+	0x73, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x62,
+	0x72, 0x61, 0x6e, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x49, 0x64, 0x22, 0x61, 0x0a, 0x0e, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f,
+	0x67, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x6c, 0x6f,
+	0x67, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x69, 0x6e, 0x6c, 0x73, 0x61, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x07, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2a, 0x81, 0x03, 0x0a, 0x09,
+	0x4c, 0x6f, 0x67, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x4c, 0x4f, 0x47,
+	0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10, 0x00,
+	0x12, 0x1a, 0x0a, 0x16, 0x4c, 0x4f, 0x47, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49,
+	0x4e, 0x54, 0x45, 0x52, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12,
+	0x4c, 0x4f, 0x47, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x45, 0x54, 0x57, 0x4f,
+	0x52, 0x4b, 0x10, 0x03, 0x12, 0x14, 0x0a, 0x10, 0x4c, 0x4f, 0x47, 0x4f, 0x4e, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x10, 0x04, 0x12, 0x16, 0x0a, 0x12, 0x4c, 0x4f,
+	0x47, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x49, 0x43, 0x45,
+	0x10, 0x05, 0x12, 0x14, 0x0a, 0x10, 0x4c, 0x4f, 0x47, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x50, 0x52, 0x4f, 0x58, 0x59, 0x10, 0x06, 0x12, 0x15, 0x0a, 0x11, 0x4c, 0x4f, 0x47, 0x4f,
+	0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x07, 0x12,
+	0x20, 0x0a, 0x1c, 0x4c, 0x4f, 0x47, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x45,
+	0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x54, 0x45, 0x58, 0x54, 0x10,
+	0x08, 0x12, 0x1e, 0x0a, 0x1a, 0x4c, 0x4f, 0x47, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x4e, 0x45, 0x57, 0x5f, 0x43, 0x52, 0x45, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c, 0x53, 0x10,
+	0x09, 0x12, 0x21, 0x0a, 0x1d, 0x4c, 0x4f, 0x47, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x52, 0x45, 0x4d, 0x4f, 0x54, 0x45, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x41, 0x43, 0x54, 0x49,
+	0x56, 0x45, 0x10, 0x0a, 0x12, 0x21, 0x0a, 0x1d, 0x4c, 0x4f, 0x47, 0x4f, 0x4e, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x43, 0x41, 0x43, 0x48, 0x45, 0x44, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x41,
+	0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x0b, 0x12, 0x28, 0x0a, 0x24, 0x4c, 0x4f, 0x47, 0x4f, 0x4e,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x41, 0x43, 0x48, 0x45, 0x44, 0x5f, 0x52, 0x45, 0x4d,
+	0x4f, 0x54, 0x45, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10,
+	0x0c, 0x12, 0x1c, 0x0a, 0x18, 0x4c, 0x4f, 0x47, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x43, 0x41, 0x43, 0x48, 0x45, 0x44, 0x5f, 0x55, 0x4e, 0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x0d, 0x42,
+	0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f,
+	0x62, 0x72, 0x61, 0x71, 0x78, 0x78, 0x2f, 0x77, 0x69, 0x6e, 0x6c, 0x73, 0x61, 0x2f, 0x77, 0x69,
+	0x6e, 0x6c, 0x73, 0x61, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_winlsa_proto_rawDescOnce sync.Once
+	file_winlsa_proto_rawDescData = file_winlsa_proto_rawDesc
+)
+
+func file_winlsa_proto_rawDescGZIP() []byte {
+	file_winlsa_proto_rawDescOnce.Do(func() {
+		file_winlsa_proto_rawDescData = protoimpl.X.CompressGZIP(file_winlsa_proto_rawDescData)
+	})
+	return file_winlsa_proto_rawDescData
+}
+
+var file_winlsa_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_winlsa_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_winlsa_proto_goTypes = []interface{}{
+	(LogonType)(0),                // 0: winlsa.v1.LogonType
+	(SessionEvent_Type)(0),        // 1: winlsa.v1.SessionEvent.Type
+	(*LogonSessionData)(nil),      // 2: winlsa.v1.LogonSessionData
+	(*SessionEvent)(nil),          // 3: winlsa.v1.SessionEvent
+	(*ExplicitCredentials)(nil),   // 4: winlsa.v1.ExplicitCredentials
+	(*TicketCacheInfo)(nil),       // 5: winlsa.v1.TicketCacheInfo
+	(*SessionTickets)(nil),        // 6: winlsa.v1.SessionTickets
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_winlsa_proto_depIdxs = []int32{
+	0,  // 0: winlsa.v1.LogonSessionData.logon_type:type_name -> winlsa.v1.LogonType
+	7,  // 1: winlsa.v1.LogonSessionData.logon_time:type_name -> google.protobuf.Timestamp
+	7,  // 2: winlsa.v1.LogonSessionData.last_successful_logon:type_name -> google.protobuf.Timestamp
+	7,  // 3: winlsa.v1.LogonSessionData.last_failed_logon:type_name -> google.protobuf.Timestamp
+	7,  // 4: winlsa.v1.LogonSessionData.logoff_time:type_name -> google.protobuf.Timestamp
+	7,  // 5: winlsa.v1.LogonSessionData.kick_off_time:type_name -> google.protobuf.Timestamp
+	7,  // 6: winlsa.v1.LogonSessionData.password_last_set:type_name -> google.protobuf.Timestamp
+	7,  // 7: winlsa.v1.LogonSessionData.password_can_change:type_name -> google.protobuf.Timestamp
+	7,  // 8: winlsa.v1.LogonSessionData.password_must_change:type_name -> google.protobuf.Timestamp
+	1,  // 9: winlsa.v1.SessionEvent.type:type_name -> winlsa.v1.SessionEvent.Type
+	7,  // 10: winlsa.v1.SessionEvent.time:type_name -> google.protobuf.Timestamp
+	2,  // 11: winlsa.v1.SessionEvent.data:type_name -> winlsa.v1.LogonSessionData
+	4,  // 12: winlsa.v1.SessionEvent.explicit_credentials:type_name -> winlsa.v1.ExplicitCredentials
+	7,  // 13: winlsa.v1.TicketCacheInfo.start_time:type_name -> google.protobuf.Timestamp
+	7,  // 14: winlsa.v1.TicketCacheInfo.end_time:type_name -> google.protobuf.Timestamp
+	7,  // 15: winlsa.v1.TicketCacheInfo.renew_time:type_name -> google.protobuf.Timestamp
+	5,  // 16: winlsa.v1.SessionTickets.tickets:type_name -> winlsa.v1.TicketCacheInfo
+	17, // [17:17] is the sub-list for method output_type
+	17, // [17:17] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_winlsa_proto_init() }
+func file_winlsa_proto_init() {
+	if File_winlsa_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_winlsa_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogonSessionData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_winlsa_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_winlsa_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExplicitCredentials); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_winlsa_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TicketCacheInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_winlsa_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionTickets); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_winlsa_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_winlsa_proto_goTypes,
+		DependencyIndexes: file_winlsa_proto_depIdxs,
+		EnumInfos:         file_winlsa_proto_enumTypes,
+		MessageInfos:      file_winlsa_proto_msgTypes,
+	}.Build()
+	File_winlsa_proto = out.File
+	file_winlsa_proto_rawDesc = nil
+	file_winlsa_proto_goTypes = nil
+	file_winlsa_proto_depIdxs = nil
+}